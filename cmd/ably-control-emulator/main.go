@@ -0,0 +1,206 @@
+// Command ably-control-emulator serves a local, offline stand-in for the Ably
+// Control API, so Terraform configurations can be planned and applied with no
+// Ably account:
+//
+//	go run ./cmd/ably-control-emulator -data ./emulator.json &
+//	ABLY_URL=http://localhost:8080 ABLY_ACCOUNT_TOKEN=anything terraform apply
+//
+// It serves the fake in control/controltest, so it stores what it is sent and
+// echoes it back without the real API's validation. It is meant for workshops
+// and module CI, not for checking a configuration will apply against Ably.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/ably/terraform-provider-ably/internal/controlspec"
+)
+
+// resetPath deletes everything the emulator holds. It is outside the Control
+// API's paths, so it cannot shadow one.
+const resetPath = "/_emulator/reset"
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "ably-control-emulator: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// options are the flag values, gathered so that flag registration can be shared
+// with the tests.
+type options struct {
+	addr      *string
+	data      *string
+	accountID *string
+	token     *string
+	spec      *string
+	verbose   *bool
+}
+
+// registerFlags defines the command line.
+func registerFlags() (*flag.FlagSet, *options) {
+	flags := flag.NewFlagSet("ably-control-emulator", flag.ContinueOnError)
+	opts := &options{
+		addr: flags.String("addr", "localhost:8080", "Address to listen on."),
+		data: flags.String("data", "",
+			"JSON file to keep the emulator's state in, read at start and rewritten after every change. Empty keeps it in memory only."),
+		accountID: flags.String("account-id", controltest.AccountID, "ID of the account the emulator serves."),
+		token: flags.String("token", "",
+			"Account token requests must carry. Defaults to $ABLY_ACCOUNT_TOKEN; when both are empty any token is accepted."),
+		spec: flags.String("spec", "",
+			"OpenAPI spec, such as codegen/control-api.yaml, to validate every request and response against. Requests that break it are refused."),
+		verbose: flags.Bool("verbose", false, "Log every request, with its response, to stderr."),
+	}
+	flags.SetOutput(os.Stderr)
+	return flags, opts
+}
+
+func run() error {
+	flags, opts := registerFlags()
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	token := *opts.token
+	if token == "" {
+		token = os.Getenv("ABLY_ACCOUNT_TOKEN")
+	}
+	serverOpts := []controltest.Option{controltest.WithAccountID(*opts.accountID)}
+	if token != "" {
+		serverOpts = append(serverOpts, controltest.WithToken(token))
+	}
+	if *opts.spec != "" {
+		spec, err := controlspec.Load(*opts.spec)
+		if err != nil {
+			return err
+		}
+		serverOpts = append(serverOpts, controltest.WithSpec(spec))
+	}
+	if *opts.verbose {
+		serverOpts = append(serverOpts, controltest.WithLog(os.Stderr))
+	}
+
+	handler, err := newEmulator(*opts.data, serverOpts...)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *opts.addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving the Control API emulator for account %s on http://%s\n", *opts.accountID, listener.Addr())
+	if *opts.data != "" {
+		fmt.Fprintf(os.Stderr, "State is kept in %s.\n", *opts.data)
+	}
+	fmt.Fprintf(os.Stderr, "POST %s to start over.\n", resetPath)
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// emulator serves the fake, adding the reset endpoint and, when it has a data
+// file, saving the state after every request that may have changed it.
+type emulator struct {
+	api  *controltest.Server
+	data string
+
+	// mu serializes saves, so an older state never overwrites a newer one.
+	mu sync.Mutex
+}
+
+// newEmulator returns the emulator's handler, loading data if it exists.
+func newEmulator(data string, opts ...controltest.Option) (*emulator, error) {
+	e := &emulator{api: controltest.NewHandler(opts...), data: data}
+	if data == "" {
+		return e, nil
+	}
+	f, err := os.Open(data)
+	if errors.Is(err, os.ErrNotExist) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := e.api.Load(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", data, err)
+	}
+	return e, nil
+}
+
+func (e *emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == resetPath {
+		if r.Method != http.MethodPost {
+			http.Error(w, "use POST", http.StatusMethodNotAllowed)
+			return
+		}
+		e.api.Reset()
+		if err := e.save(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	e.api.ServeHTTP(w, r)
+	if r.Method != http.MethodGet {
+		if err := e.save(); err != nil {
+			// The response has been written, so the client cannot be told.
+			fmt.Fprintf(os.Stderr, "ably-control-emulator: saving state: %s\n", err)
+		}
+	}
+}
+
+// save writes the state to the data file, through a temporary file so that a
+// crash mid-write leaves the previous state intact.
+func (e *emulator) save() error {
+	if e.data == "" {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(e.data), filepath.Base(e.data)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := e.api.Save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), e.data)
+}
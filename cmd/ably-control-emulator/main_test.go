@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+)
+
+// startEmulator serves an emulator keeping its state in data, returning a client
+// for it and its URL.
+func startEmulator(t *testing.T, data string, opts ...controltest.Option) (*control.Client, string) {
+	t.Helper()
+	e, err := newEmulator(data, opts...)
+	if err != nil {
+		t.Fatalf("newEmulator: %s", err)
+	}
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	client := control.NewClient("token", control.WithRetryMax(0))
+	client.BaseURL = server.URL
+	return client, server.URL
+}
+
+func TestEmulatorKeepsStateAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	data := filepath.Join(t.TempDir(), "state.json")
+
+	client, _ := startEmulator(t, data, controltest.WithAccountID("acc1"))
+	app, err := client.CreateApp(ctx, "acc1", control.AppPost{Name: "workshop"})
+	if err != nil {
+		t.Fatalf("CreateApp: %s", err)
+	}
+	if app.AccountID != "acc1" {
+		t.Errorf("app account = %q, want acc1", app.AccountID)
+	}
+
+	// A second emulator on the same file sees the app, and does not reuse its ID.
+	client, _ = startEmulator(t, data, controltest.WithAccountID("acc1"))
+	apps, err := client.ListApps(ctx, "acc1")
+	if err != nil {
+		t.Fatalf("ListApps: %s", err)
+	}
+	if len(apps) != 1 || apps[0].ID != app.ID {
+		t.Fatalf("apps after restart = %+v, want just %s", apps, app.ID)
+	}
+	second, err := client.CreateApp(ctx, "acc1", control.AppPost{Name: "second"})
+	if err != nil {
+		t.Fatalf("CreateApp: %s", err)
+	}
+	if second.ID == app.ID {
+		t.Errorf("the restarted emulator reused ID %s", app.ID)
+	}
+}
+
+func TestEmulatorReset(t *testing.T) {
+	ctx := context.Background()
+	data := filepath.Join(t.TempDir(), "state.json")
+	client, url := startEmulator(t, data)
+
+	if _, err := client.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "workshop"}); err != nil {
+		t.Fatalf("CreateApp: %s", err)
+	}
+
+	resp, err := http.Get(url + resetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET %s = %d, want %d", resetPath, resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	resp, err = http.Post(url+resetPath, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("POST %s = %d, want %d", resetPath, resp.StatusCode, http.StatusNoContent)
+	}
+
+	// The reset is saved too.
+	client, _ = startEmulator(t, data)
+	apps, err := client.ListApps(ctx, controltest.AccountID)
+	if err != nil {
+		t.Fatalf("ListApps: %s", err)
+	}
+	if len(apps) != 0 {
+		t.Errorf("apps after reset = %+v, want none", apps)
+	}
+}
+
+func TestEmulatorChecksToken(t *testing.T) {
+	_, url := startEmulator(t, "", controltest.WithToken("secret"))
+
+	client := control.NewClient("wrong", control.WithRetryMax(0))
+	client.BaseURL = url
+	if _, err := client.Me(context.Background()); err == nil {
+		t.Error("a wrong token was accepted")
+	}
+
+	client = control.NewClient("secret", control.WithRetryMax(0))
+	client.BaseURL = url
+	if _, err := client.Me(context.Background()); err != nil {
+		t.Errorf("the right token was refused: %s", err)
+	}
+}
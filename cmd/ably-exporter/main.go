@@ -45,6 +45,22 @@ func (a *appList) Set(value string) error {
 	return nil
 }
 
+// patternList collects a repeatable pattern flag. Unlike appList it does not
+// split on commas, which a regular expression can legitimately contain.
+type patternList []string
+
+func (p *patternList) String() string { return strings.Join(*p, " ") }
+
+func (p *patternList) Set(value string) error {
+	value = strings.TrimSpace(value)
+	// An empty pattern would silently match nothing, or everything.
+	if value == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	*p = append(*p, value)
+	return nil
+}
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "ably-exporter: %s\n", err)
@@ -55,34 +71,63 @@ func main() {
 // options are the flag values, gathered so that flag registration can be shared
 // with the tests.
 type options struct {
+	apps           appList
+	excludeApps    appList
+	includeTypes   patternList
+	excludeTypes   patternList
+	keyNames       patternList
+	namespaceNames patternList
+	naming         patternList
+
 	token           *string
 	url             *string
 	out             *string
 	secrets         *string
+	secretsOut      *string
 	imports         *bool
 	singleFile      *bool
 	providerVersion *string
 	force           *bool
+	priorManifest   *string
+	labelOverrides  *string
+	excludeRevoked  *bool
 	showVersion     *bool
 }
 
-// registerFlags defines the command line, writing app filters into apps.
-func registerFlags(apps *appList) (*flag.FlagSet, *options) {
+// registerFlags defines the command line.
+func registerFlags() (*flag.FlagSet, *options) {
 	flags := flag.NewFlagSet("ably-exporter", flag.ContinueOnError)
 	opts := &options{
 		token: flags.String("token", "", "Ably account token. Defaults to $ABLY_ACCOUNT_TOKEN."),
 		url:   flags.String("url", "", "Control API URL. Defaults to $ABLY_URL."),
 		out:   flags.String("out", "./ably-export", "Directory to write the generated configuration into."),
 		secrets: flags.String("secrets", string(exporter.SecretsInline),
-			"What to do with sensitive values: inline (write them), vars (reference sensitive variables) or omit (leave them out)."),
+			"What to do with sensitive values: inline (write them), vars (reference sensitive variables), env (variables, with a TF_VAR_ template), vault or aws-sm (read them from a secret manager), or omit (leave them out)."),
+		secretsOut: flags.String("secrets-out", "",
+			"Write the sensitive values the export replaced to this JSON file, 0600, for seeding a secret manager. Never overwrites."),
 		imports:    flags.Bool("imports", true, "Generate import blocks alongside the configuration."),
 		singleFile: flags.Bool("single-file", false, "Write everything to main.tf instead of one file per app."),
 		providerVersion: flags.String("provider-version", exporter.DefaultProviderVersion,
 			"Version constraint for the generated required_providers block. Empty omits it."),
-		force:       flags.Bool("force", false, "Write into the output directory even if it already contains .tf files."),
+		force: flags.Bool("force", false, "Write into the output directory even if it already contains .tf files."),
+		labelOverrides: flags.String("label-overrides", "",
+			"YAML file mapping Control API IDs to labels chosen by hand. Namespaces are keyed app_id,id."),
+		priorManifest: flags.String("prior-manifest", "",
+			"The "+exporter.ManifestFile+" a previous export wrote. Generates moved blocks for resources whose address changed and removed blocks for resources that left the account."),
+		excludeRevoked: flags.Bool("exclude-revoked", true,
+			"Leave revoked API keys out. Set to false to list them in the output, as comments, for review."),
 		showVersion: flags.Bool("version", false, "Print the exporter version and exit."),
 	}
-	flags.Var(apps, "app", "Only export this app, by ID or name. Repeatable, or comma-separated.")
+	flags.Var(&opts.apps, "app", "Only export this app, by ID or name. Repeatable, or comma-separated.")
+	flags.Var(&opts.excludeApps, "exclude-app", "Leave out this app, by ID or name. Repeatable, or comma-separated.")
+	flags.Var(&opts.includeTypes, "include-type", "Only export resource types matching this glob, e.g. 'ably_rule_*'. Repeatable.")
+	flags.Var(&opts.excludeTypes, "exclude-type", "Leave out resource types matching this glob. Repeatable.")
+	flags.Var(&opts.keyNames, "key-name",
+		"Only export API keys whose name matches this glob, or regular expression wrapped in slashes ('/^ci-/'). Repeatable.")
+	flags.Var(&opts.namespaceNames, "namespace",
+		"Only export namespaces whose ID matches this glob, or regular expression wrapped in slashes. Repeatable.")
+	flags.Var(&opts.naming, "naming",
+		"Label template, 'TYPE_GLOB=TEMPLATE' or a bare template for every type, e.g. 'ably_rule_*={{.App}}_{{.Target.url | host}}'. Repeatable; the first match applies.")
 
 	flags.SetOutput(os.Stderr)
 	flags.Usage = func() { fmt.Fprint(os.Stderr, usageText(flags)) }
@@ -90,8 +135,7 @@ func registerFlags(apps *appList) (*flag.FlagSet, *options) {
 }
 
 func run() error {
-	var apps appList
-	flags, opts := registerFlags(&apps)
+	flags, opts := registerFlags()
 
 	if err := flags.Parse(os.Args[1:]); err != nil {
 		if err == flag.ErrHelp {
@@ -109,6 +153,11 @@ func run() error {
 	if err != nil {
 		return err
 	}
+	// Inline already writes the values, and omit is asked for so they are not
+	// written anywhere.
+	if *opts.secretsOut != "" && (secretMode == exporter.SecretsInline || secretMode == exporter.SecretsOmit) {
+		return fmt.Errorf("-secrets-out needs a -secrets mode that replaces values, not %s", secretMode)
+	}
 
 	accountToken := *opts.token
 	if accountToken == "" {
@@ -123,14 +172,37 @@ func run() error {
 		controlURL = os.Getenv("ABLY_URL")
 	}
 
+	var labelOverrides map[string]string
+	if *opts.labelOverrides != "" {
+		if labelOverrides, err = exporter.ReadLabelOverrides(*opts.labelOverrides); err != nil {
+			return err
+		}
+	}
+
+	var priorManifest *exporter.Manifest
+	if *opts.priorManifest != "" {
+		if priorManifest, err = exporter.ReadManifest(*opts.priorManifest); err != nil {
+			return err
+		}
+	}
+
 	result, err := exporter.Run(context.Background(), exporter.Config{
 		Token:           accountToken,
 		URL:             controlURL,
-		Apps:            apps,
+		Apps:            opts.apps,
+		ExcludeApps:     opts.excludeApps,
+		IncludeTypes:    opts.includeTypes,
+		ExcludeTypes:    opts.excludeTypes,
+		KeyNames:        opts.keyNames,
+		NamespaceNames:  opts.namespaceNames,
+		IncludeRevoked:  !*opts.excludeRevoked,
 		Secrets:         secretMode,
 		Imports:         *opts.imports,
 		SingleFile:      *opts.singleFile,
 		ProviderVersion: *opts.providerVersion,
+		Naming:          opts.naming,
+		LabelOverrides:  labelOverrides,
+		PriorManifest:   priorManifest,
 		Version:         VERSION,
 	})
 	if err != nil {
@@ -140,6 +212,12 @@ func run() error {
 	if err := exporter.WriteFiles(*opts.out, result, *opts.force); err != nil {
 		return err
 	}
+	if *opts.secretsOut != "" {
+		if err := exporter.WriteSecrets(*opts.secretsOut, result); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Sensitive values written to %s. Load it into your secret manager, then delete it.\n", *opts.secretsOut)
+	}
 
 	report(result, *opts.out, secretMode)
 	return nil
@@ -186,6 +264,27 @@ func report(result *exporter.Result, out string, secrets exporter.SecretMode) {
 		}
 	}
 
+	if len(result.Revoked) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d revoked API keys are listed for review but not exported:\n", len(result.Revoked))
+		for _, revoked := range result.Revoked {
+			fmt.Fprintf(os.Stderr, "  %s\n", revoked)
+		}
+	}
+
+	if len(result.Moved) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d resources changed address since the previous export; moved.tf carries their state over:\n", len(result.Moved))
+		for _, moved := range result.Moved {
+			fmt.Fprintf(os.Stderr, "  %s\n", moved)
+		}
+	}
+
+	if len(result.Removed) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d resources have left the account since the previous export; moved.tf removes them from state:\n", len(result.Removed))
+		for _, removed := range result.Removed {
+			fmt.Fprintf(os.Stderr, "  %s\n", removed)
+		}
+	}
+
 	if len(result.Missing) > 0 {
 		fmt.Fprintf(os.Stderr, "\n%d required values are missing because the Control API does not return them:\n", len(result.Missing))
 		for _, missing := range result.Missing {
@@ -198,14 +297,26 @@ func report(result *exporter.Result, out string, secrets exporter.SecretMode) {
 		case exporter.SecretsInline:
 			fmt.Fprintf(os.Stderr, "\n%d sensitive values were written into the output. Treat these files as secrets.\n",
 				len(result.Sensitive))
-		case exporter.SecretsVars:
+		case exporter.SecretsVars, exporter.SecretsEnv:
 			if result.Variables {
-				fmt.Fprintf(os.Stderr, "\n%d sensitive values were replaced with variables. Fill in variables.tf before applying.\n",
-					len(result.Sensitive))
+				where := "Fill in variables.tf"
+				if secrets == exporter.SecretsEnv {
+					where = "Set them from secrets.env.example"
+				}
+				fmt.Fprintf(os.Stderr, "\n%d sensitive values were replaced with variables. %s before applying.\n",
+					len(result.Sensitive), where)
 			} else {
 				fmt.Fprintf(os.Stderr, "\n%d sensitive values were left out: none could be replaced with a variable. See the comments in the output.\n",
 					len(result.Sensitive))
 			}
+		case exporter.SecretsVault, exporter.SecretsAWSSecretsManager:
+			if result.Variables {
+				fmt.Fprintf(os.Stderr, "\n%d sensitive values are read from the secret manager. See secrets.tf for where, and seed it before applying.\n",
+					len(result.Sensitive))
+			} else {
+				fmt.Fprintf(os.Stderr, "\n%d sensitive values were left out: none could be read from a secret manager. See the comments in the output.\n",
+					len(result.Sensitive))
+			}
 		case exporter.SecretsOmit:
 			fmt.Fprintf(os.Stderr, "\n%d sensitive values were left out. The config will not apply cleanly until you add them.\n",
 				len(result.Sensitive))
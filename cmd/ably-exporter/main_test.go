@@ -37,6 +37,19 @@ func TestHiddenFlagsAreRegistered(t *testing.T) {
 	}
 }
 
+func TestPatternListKeepsCommas(t *testing.T) {
+	var patterns patternList
+	if err := patterns.Set(" "); err == nil {
+		t.Error("an empty pattern should fail")
+	}
+	if err := patterns.Set("/^ci-[a-z]{1,3}$/"); err != nil {
+		t.Fatalf("Set: %s", err)
+	}
+	if len(patterns) != 1 || patterns[0] != "/^ci-[a-z]{1,3}$/" {
+		t.Errorf("patterns = %q, want the regular expression kept whole", patterns)
+	}
+}
+
 func TestAppListRejectsEmptyValues(t *testing.T) {
 	var apps appList
 	if err := apps.Set(""); err == nil {
@@ -55,7 +68,6 @@ func TestAppListRejectsEmptyValues(t *testing.T) {
 
 // exporterFlags builds the flag set the way run does, for tests that inspect it.
 func exporterFlags() *flag.FlagSet {
-	var apps appList
-	flags, _ := registerFlags(&apps)
+	flags, _ := registerFlags()
 	return flags
 }
@@ -11,18 +11,31 @@
 // Structure (the attribute tree, types, optionality, sensitivity) comes from
 // reflecting each rule's XxxRulePost struct. Field descriptions come from the
 // vendored OpenAPI spec (codegen/control-api.yaml), looked up by JSON property
-// name, because the Go structs carry no documentation. The result is a Provider
+// name, because the Go structs carry no documentation. Metadata neither carries
+// (validators, defaults, plan modifiers, write-only and deprecation) comes from
+// the overrides table in codegen/rule_overrides.yml. The result is a Provider
 // Code Spec JSON that tfplugingen-framework turns into schema and model code.
-// Run via `make generate`.
+// Run via `make generate`, which runs it again with -patch-generated after
+// tfplugingen-framework to mark the write-only attributes.
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"maps"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -49,6 +62,43 @@ var rules = []rule{
 	{"rule_hive_dashboard", control.HiveDashboardRulePost{}, "hive_dashboard_rule_post"},
 	{"rule_before_publish_webhook", control.BeforePublishWebhookRulePost{}, "before_publish_webhook_rule_post"},
 	{"rule_before_publish_lambda", control.BeforePublishAWSLambdaRulePost{}, "before_publish_aws_lambda_rule_post"},
+	{"rule_kinesis", control.AWSKinesisRulePost{}, "aws_kinesis_rule_post"},
+	{"rule_sqs", control.AWSSQSRulePost{}, "aws_sqs_rule_post"},
+	{"rule_lambda", control.AWSLambdaRulePost{}, "aws_lambda_rule_post"},
+	{"rule_pulsar", control.PulsarRulePost{}, "pulsar_rule_post"},
+	{"rule_zapier", control.ZapierRulePost{}, "zapier_rule_post"},
+	{"rule_google_function", control.GoogleCloudFunctionRulePost{}, "google_cloud_function_rule_post"},
+	{"rule_ifttt", control.IFTTTRulePost{}, "ifttt_rule_post"},
+	{"rule_cloudflare_worker", control.CloudflareWorkerRulePost{}, "cloudflare_worker_rule_post"},
+	{"rule_azure_function", control.AzureFunctionRulePost{}, "azure_function_rule_post"},
+	{"rule_http", control.HTTPRulePost{}, "http_rule_post"},
+	{"rule_kafka", control.KafkaRulePost{}, "kafka_rule_post"},
+	{"rule_amqp", control.AMQPRulePost{}, "amqp_rule_post"},
+	{"rule_amqp_external", control.AMQPExternalRulePost{}, "amqp_external_rule_post"},
+	{"ingress_rule_mongodb", control.IngressMongoDBRulePost{}, "ingress_mongodb_changestream_rule_post"},
+	{"ingress_rule_postgres_outbox", control.IngressPostgresOutboxRulePost{}, "ingress_postgres_outbox_rule_post"},
+}
+
+// allowEmpty lists the rule resources whose string attributes don't get the
+// LengthAtLeast(1) validator. Their schemas were published by hand before the
+// generator adopted them and accepted "", so rejecting it would break
+// configurations that validated under v1.0.0.
+var allowEmpty = map[string]bool{
+	"rule_kinesis":                 true,
+	"rule_sqs":                     true,
+	"rule_lambda":                  true,
+	"rule_pulsar":                  true,
+	"rule_zapier":                  true,
+	"rule_google_function":         true,
+	"rule_ifttt":                   true,
+	"rule_cloudflare_worker":       true,
+	"rule_azure_function":          true,
+	"rule_http":                    true,
+	"rule_kafka":                   true,
+	"rule_amqp":                    true,
+	"rule_amqp_external":           true,
+	"ingress_rule_mongodb":         true,
+	"ingress_rule_postgres_outbox": true,
 }
 
 // sensitive field names (by snake_case) that should be marked Sensitive, in
@@ -79,67 +129,303 @@ type customExpr struct {
 	expr    string
 }
 
+const (
+	pkgStringValidator = "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	pkgInt64Validator  = "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	pkgPlanModifiers   = "github.com/ably/terraform-provider-ably/internal/provider/planmodifiers"
+	pkgValidators      = "github.com/ably/terraform-provider-ably/internal/provider/validators"
+	pkgPath            = "github.com/hashicorp/terraform-plugin-framework/path"
+	pkgRegexp          = "regexp"
+)
+
+// planModifierPkgs maps a Provider Code Spec attribute kind to the framework's
+// plan modifier package for it.
+var planModifierPkgs = map[string]string{
+	"string":        "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier",
+	"bool":          "github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier",
+	"int64":         "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier",
+	"single_nested": "github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier",
+	"list":          "github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier",
+	"list_nested":   "github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier",
+	"map":           "github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier",
+}
+
+// overridesPath is the declarative table of metadata the Go types and the
+// spec can't express. Its header documents the format.
+const overridesPath = "codegen/rule_overrides.yml"
+
 // override augments a generated attribute with metadata the Go types can't
-// express. Keyed by snake_case attribute name (top-level or nested).
+// express, as one entry of overridesPath.
 type override struct {
-	mode          string // overrides computed_optional_required when set
-	staticDefault any    // sets a static default when non-nil
-	allowEmpty    bool   // suppresses the LengthAtLeast(1) validator
-	validators    []customExpr
-	planModifiers []customExpr
+	Name               string              `yaml:"name"`             // the Terraform attribute name, when not the field's
+	Description        string              `yaml:"description"`      // replaces the spec's description when set
+	Mode               string              `yaml:"mode"`             // overrides computed_optional_required when set
+	Sensitive          bool                `yaml:"sensitive"`        // marks it sensitive beyond the sensitive names
+	Default            any                 `yaml:"default"`          // sets a static default when non-nil
+	OneOf              []any               `yaml:"one_of"`           // emits a OneOf validator
+	AllowEmpty         bool                `yaml:"allow_empty"`      // suppresses the LengthAtLeast(1) validator
+	IgnoreSpecEnum     bool                `yaml:"ignore_spec_enum"` // skips the enum the spec declares
+	Validators         []string            `yaml:"validators"`       // see validatorExpr
+	RequiredWhen       map[string][]string `yaml:"required_when"`    // value -> sibling attributes it requires
+	PlanModifiers      []string            `yaml:"plan_modifiers"`   // see planModifierExpr
+	WriteOnly          bool                `yaml:"write_only"`       // see patchGenerated
+	DeprecationMessage string              `yaml:"deprecation_message"`
 }
 
-const (
-	pkgStringValidator    = "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	pkgInt64Validator     = "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	pkgStringPlanModifier = "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	pkgPlanModifiers      = "github.com/ably/terraform-provider-ably/internal/provider/planmodifiers"
-	pkgRegexp             = "regexp"
-)
+// overrideTable is overridesPath parsed. All applies to every rule resource
+// and Resources to the one named; both are keyed by attribute name or dotted
+// path.
+type overrideTable struct {
+	All       map[string]override            `yaml:"all"`
+	Resources map[string]map[string]override `yaml:"resources"`
+
+	// used records the entries that matched an attribute, as scope:key.
+	used map[string]bool
+}
+
+// overrides is the table the generator applies, loaded by main.
+var overrides overrideTable
+
+// writeOnly collects the write-only attribute paths of each resource as the
+// attributes are built, for patchGenerated.
+var writeOnly = map[string][]string{}
+
+// loadOverrides reads and checks the overrides table, failing on unknown
+// keys, resources, modes and plan modifiers so a typo can't silently drop
+// metadata.
+func loadOverrides(path string) overrideTable {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var t overrideTable
+	if err := dec.Decode(&t); err != nil {
+		panic(fmt.Sprintf("%s: %v", path, err))
+	}
+	t.used = map[string]bool{}
+
+	check := func(scope string, entries map[string]override) {
+		for key, ov := range entries {
+			switch ov.Mode {
+			case "", "required", "optional", "computed", "computed_optional":
+			default:
+				panic(fmt.Sprintf("%s: %s.%s: unknown mode %q", path, scope, key, ov.Mode))
+			}
+			for _, pm := range ov.PlanModifiers {
+				switch pm {
+				case "requires_replace", "use_state_for_unknown", "requires_replace_when_cleared":
+				default:
+					panic(fmt.Sprintf("%s: %s.%s: unknown plan modifier %q", path, scope, key, pm))
+				}
+			}
+			for _, v := range ov.Validators {
+				switch v {
+				case "channel_filter", "header_name", "header_value", "unenveloped_in_batch":
+				default:
+					panic(fmt.Sprintf("%s: %s.%s: unknown validator %q", path, scope, key, v))
+				}
+			}
+		}
+	}
+	check("all", t.All)
+	for name, entries := range t.Resources {
+		if !slices.ContainsFunc(rules, func(r rule) bool { return r.name == name }) {
+			panic(fmt.Sprintf("%s: resources.%s is not a rule resource ruletypesgen generates", path, name))
+		}
+		check("resources."+name, entries)
+	}
+	return t
+}
+
+// lookup returns the override for the attribute at path in a resource. The
+// most specific entry wins whole: the resource's before the shared ones, and
+// a full path before a bare name.
+func (t *overrideTable) lookup(resource, path string) (override, bool) {
+	name := path[strings.LastIndex(path, ".")+1:]
+	scope := "resources." + resource
+	for _, c := range []struct {
+		scope   string
+		entries map[string]override
+		key     string
+	}{
+		{scope, t.Resources[resource], path},
+		{scope, t.Resources[resource], name},
+		{"all", t.All, path},
+		{"all", t.All, name},
+	} {
+		if ov, ok := c.entries[c.key]; ok {
+			t.used[c.scope+"."+c.key] = true
+			return ov, true
+		}
+	}
+	return override{}, false
+}
+
+// checkUsed fails generation on entries that matched no attribute: a renamed
+// field or a mistyped path would otherwise drop its metadata silently.
+func (t *overrideTable) checkUsed() {
+	var unused []string
+	for key := range t.All {
+		if !t.used["all."+key] {
+			unused = append(unused, "all."+key)
+		}
+	}
+	for name, entries := range t.Resources {
+		for key := range entries {
+			if !t.used["resources."+name+"."+key] {
+				unused = append(unused, "resources."+name+"."+key)
+			}
+		}
+	}
+	if len(unused) > 0 {
+		slices.Sort(unused)
+		panic(fmt.Sprintf("%s: entries match no attribute: %s", overridesPath, strings.Join(unused, ", ")))
+	}
+}
 
-// attrOverrides supplies the generic, spec-absent metadata: the envelope plan
-// modifiers and the rule status field (which the spec doesn't enumerate).
-// Field-level enums, patterns and their defaults are sourced from the spec
-// per rule instead (see the string case in attrsFromStruct), so they stay
-// correct per family, e.g. moderation rules enforce invocation_mode
-// BEFORE_PUBLISH while hive/dashboard enforces AFTER_PUBLISH.
-var attrOverrides = map[string]override{
-	"id":     {planModifiers: []customExpr{{[]string{pkgStringPlanModifier}, "stringplanmodifier.UseStateForUnknown()"}}},
-	"app_id": {planModifiers: []customExpr{{[]string{pkgStringPlanModifier}, "stringplanmodifier.RequiresReplace()"}}},
-	"status": {
-		mode:          "computed_optional",
-		staticDefault: "enabled",
-		validators:    []customExpr{{[]string{pkgStringValidator}, `stringvalidator.OneOf("enabled", "disabled")`}},
-	},
-	// The rule PATCH schemas neither accept null nor let the pattern-bound
-	// chatRoomFilter be "", so an in-place update can never unset it (verified
-	// against the live API, 2026-07-08): removing it must recreate the rule.
-	"chat_room_filter": {planModifiers: []customExpr{{[]string{pkgPlanModifiers}, "planmodifiers.RequiresReplaceWhenCleared()"}}},
-	// "" is a meaningful value for a source channel filter: the spec documents
-	// it as "apply to all channels", and unlike chatRoomFilter it round-trips
-	// (verified against the live API, 2026-07-20: create with "" and update
-	// non-empty -> "" both persist and read back as "").
-	"channel_filter": {allowEmpty: true},
-}
-
-// applyOverride mutates an attribute's type map with any configured metadata.
-func applyOverride(name string, m map[string]any) {
-	ov, ok := attrOverrides[name]
+// applyOverride mutates the type map of the attribute at path, of the given
+// Provider Code Spec kind, with any metadata the overrides table has for it.
+// It returns the override applied, the zero override when there is none.
+func applyOverride(resource, path, kind string, m map[string]any) override {
+	ov, ok := overrides.lookup(resource, path)
 	if !ok {
-		return
+		return ov
+	}
+	where := resource + "." + path
+	if ov.Mode != "" {
+		m["computed_optional_required"] = ov.Mode
+	}
+	if ov.Default != nil {
+		if kind != "string" && kind != "bool" && kind != "int64" {
+			panic(fmt.Sprintf("%s: a static default needs a string, bool or int64 attribute, not %s", where, kind))
+		}
+		m["default"] = map[string]any{"static": ov.Default}
+	}
+	if ov.Description != "" {
+		m["description"] = ov.Description
+	}
+	if ov.Sensitive {
+		m["sensitive"] = true
+	}
+	if ov.DeprecationMessage != "" {
+		m["deprecation_message"] = ov.DeprecationMessage
+	}
+	var vals []customExpr
+	if len(ov.OneOf) > 0 {
+		vals = append(vals, oneOfOverride(where, kind, ov.OneOf))
+	}
+	for _, v := range ov.Validators {
+		vals = append(vals, validatorExpr(where, kind, v))
+	}
+	if len(ov.RequiredWhen) > 0 {
+		vals = append(vals, requiredWhenExprs(where, kind, ov.RequiredWhen)...)
+	}
+	if len(vals) > 0 {
+		m["validators"] = customList(vals)
+	}
+	if len(ov.PlanModifiers) > 0 {
+		exprs := make([]customExpr, 0, len(ov.PlanModifiers))
+		for _, pm := range ov.PlanModifiers {
+			exprs = append(exprs, planModifierExpr(where, kind, pm))
+		}
+		m["plan_modifiers"] = customList(exprs)
+	}
+	if ov.WriteOnly {
+		// Terraform refuses write-only attributes that are computed, and a
+		// default would be one.
+		if mode := m["computed_optional_required"]; mode == "computed" || mode == "computed_optional" || m["default"] != nil {
+			panic(fmt.Sprintf("%s: a write-only attribute can't be computed or have a default", where))
+		}
+		writeOnly[resource] = append(writeOnly[resource], path)
+	}
+	return ov
+}
+
+// oneOfOverride builds the OneOf validator for an override's allowed values.
+func oneOfOverride(where, kind string, vals []any) customExpr {
+	switch kind {
+	case "string":
+		strs := make([]string, len(vals))
+		for i, v := range vals {
+			s, ok := v.(string)
+			if !ok {
+				panic(fmt.Sprintf("%s: one_of value %v is not a string", where, v))
+			}
+			strs[i] = s
+		}
+		return customExpr{[]string{pkgStringValidator}, oneOfExpr(strs)}
+	case "int64":
+		ints := make([]string, len(vals))
+		for i, v := range vals {
+			n, ok := v.(int)
+			if !ok {
+				panic(fmt.Sprintf("%s: one_of value %v is not an integer", where, v))
+			}
+			ints[i] = strconv.Itoa(n)
+		}
+		return customExpr{[]string{pkgInt64Validator}, "int64validator.OneOf(" + strings.Join(ints, ", ") + ")"}
 	}
-	if ov.mode != "" {
-		m["computed_optional_required"] = ov.mode
+	panic(fmt.Sprintf("%s: one_of needs a string or int64 attribute, not %s", where, kind))
+}
+
+// validatorExpr builds a named validator from the validators package for an
+// attribute kind.
+func validatorExpr(where, kind, name string) customExpr {
+	want, fn := "string", ""
+	switch name {
+	case "channel_filter":
+		fn = "ChannelFilter()"
+	case "header_name":
+		fn = "HeaderName()"
+	case "header_value":
+		fn = "HeaderValue()"
+	case "unenveloped_in_batch":
+		want, fn = "bool", "UnenvelopedInBatch()"
+	default:
+		panic(fmt.Sprintf("%s: unknown validator %q", where, name))
 	}
-	if ov.staticDefault != nil {
-		m["default"] = map[string]any{"static": ov.staticDefault}
+	if kind != want {
+		panic(fmt.Sprintf("%s: the %s validator needs a %s attribute, not %s", where, name, want, kind))
 	}
-	if len(ov.validators) > 0 {
-		m["validators"] = customList(ov.validators)
+	return customExpr{[]string{pkgValidators}, "validators." + fn}
+}
+
+// requiredWhenExprs builds a RequiredWhen validator per value, in value order,
+// each requiring the sibling attributes named for it.
+func requiredWhenExprs(where, kind string, when map[string][]string) []customExpr {
+	if kind != "string" {
+		panic(fmt.Sprintf("%s: required_when needs a string attribute, not %s", where, kind))
 	}
-	if len(ov.planModifiers) > 0 {
-		m["plan_modifiers"] = customList(ov.planModifiers)
+	exprs := make([]customExpr, 0, len(when))
+	for _, value := range slices.Sorted(maps.Keys(when)) {
+		args := []string{strconv.Quote(value)}
+		for _, sibling := range when[value] {
+			args = append(args, fmt.Sprintf("path.MatchRelative().AtParent().AtName(%q)", sibling))
+		}
+		exprs = append(exprs, customExpr{[]string{pkgValidators, pkgPath}, "validators.RequiredWhen(" + strings.Join(args, ", ") + ")"})
 	}
+	return exprs
+}
+
+// planModifierExpr builds a named plan modifier for an attribute kind.
+func planModifierExpr(where, kind, name string) customExpr {
+	switch name {
+	case "requires_replace_when_cleared":
+		if kind != "string" {
+			panic(fmt.Sprintf("%s: requires_replace_when_cleared needs a string attribute, not %s", where, kind))
+		}
+		return customExpr{[]string{pkgPlanModifiers}, "planmodifiers.RequiresReplaceWhenCleared()"}
+	case "requires_replace", "use_state_for_unknown":
+		pkg := planModifierPkgs[kind]
+		fn := "RequiresReplace()"
+		if name == "use_state_for_unknown" {
+			fn = "UseStateForUnknown()"
+		}
+		return customExpr{[]string{pkg}, pkg[strings.LastIndex(pkg, "/")+1:] + "." + fn}
+	}
+	panic(fmt.Sprintf("%s: unknown plan modifier %q", where, name))
 }
 
 func customList(exprs []customExpr) []map[string]any {
@@ -164,19 +450,51 @@ func customList(exprs []customExpr) []map[string]any {
 var specSchemas map[string]any
 
 func main() {
-	schemas := loadSpecSchemas("codegen/control-api.yaml")
-	specSchemas = schemas
+	patch := flag.Bool("patch-generated", false, "patch the code tfplugingen-framework generated from rules_spec.json with the overrides the Provider Code Spec can't express, instead of writing the specs")
+	flag.Parse()
+
+	specSchemas = loadSpecSchemas("codegen/control-api.yaml")
+	overrides = loadOverrides(overridesPath)
+	resources := ruleResources()
+	overrides.checkUsed()
+
+	if *patch {
+		patchGenerated("internal/provider/codegen")
+		return
+	}
+
+	spec := map[string]any{
+		"provider":  map[string]any{"name": "ably"},
+		"resources": resources,
+		"version":   "0.1",
+	}
 
+	out, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("codegen/rules_spec.json", append(out, '\n'), 0o644); err != nil {
+		panic(err)
+	}
+
+	patchSpecJSON("codegen/spec.json")
+	models := ruleModels(resources)
+	writeModels(modelsPath, models)
+	writeShims(shimsPath, models)
+}
+
+// ruleResources builds the Provider Code Spec resources for the rules table.
+func ruleResources() []map[string]any {
 	resources := make([]map[string]any, 0, len(rules))
 	for _, r := range rules {
-		props := schemaProps(schemas, r.specSchema)
-		attrs := attrsFromStruct(reflect.TypeOf(r.post), props)
+		props := schemaProps(specSchemas, r.specSchema)
+		attrs := attrsFromStruct(r.name, "", reflect.TypeOf(r.post), props)
 		// Every rule resource carries the same envelope: a computed id and the
 		// required parent app_id. These are not on the create body.
 		idMap := map[string]any{"computed_optional_required": "computed", "description": "The rule ID."}
-		applyOverride("id", idMap)
+		applyOverride(r.name, "id", "string", idMap)
 		appIDMap := map[string]any{"computed_optional_required": "required", "description": "The Ably application ID."}
-		applyOverride("app_id", appIDMap)
+		applyOverride(r.name, "app_id", "string", appIDMap)
 		envelope := []map[string]any{
 			{"name": "id", "string": idMap},
 			{"name": "app_id", "string": appIDMap},
@@ -187,22 +505,124 @@ func main() {
 			"schema": map[string]any{"attributes": attrs},
 		})
 	}
+	return resources
+}
 
-	spec := map[string]any{
-		"provider":  map[string]any{"name": "ably"},
-		"resources": resources,
-		"version":   "0.1",
-	}
+// patchGenerated marks the write-only attributes in the schema code
+// tfplugingen-framework generated under dir, which it can't do itself: the
+// Provider Code Spec predates write-only attributes. The generate target runs
+// it after the framework generator. Attributes already marked are left alone.
+func patchGenerated(dir string) {
+	for _, r := range rules {
+		paths := writeOnly[r.name]
+		if len(paths) == 0 {
+			continue
+		}
+		file := filepath.Join(dir, "resource_"+r.name, r.name+"_resource_gen.go")
+		src, err := os.ReadFile(file)
+		if err != nil {
+			panic(fmt.Sprintf("patch %s: %v (run tfplugingen-framework first; see the generate target)", file, err))
+		}
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+		if err != nil {
+			panic(fmt.Sprintf("patch %s: %v", file, err))
+		}
+		root := schemaAttributes(f)
+		if root == nil {
+			panic(fmt.Sprintf("patch %s: no schema.Schema literal with Attributes", file))
+		}
 
-	out, err := json.MarshalIndent(spec, "", "  ")
-	if err != nil {
-		panic(err)
+		var offsets []int
+		for _, p := range paths {
+			lit := findAttribute(root, strings.Split(p, "."))
+			if lit == nil {
+				panic(fmt.Sprintf("patch %s: no attribute %s", file, p))
+			}
+			if fieldValue(lit, "WriteOnly") == nil {
+				offsets = append(offsets, fset.Position(lit.Lbrace).Offset+1)
+			}
+		}
+		// Insert from the end so earlier offsets stay valid.
+		sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+		for _, off := range offsets {
+			src = append(src[:off], append([]byte("\nWriteOnly: true,"), src[off:]...)...)
+		}
+		out, err := format.Source(src)
+		if err != nil {
+			panic(fmt.Sprintf("patch %s: %v", file, err))
+		}
+		if err := os.WriteFile(file, out, 0o644); err != nil {
+			panic(err)
+		}
 	}
-	if err := os.WriteFile("codegen/rules_spec.json", append(out, '\n'), 0o644); err != nil {
-		panic(err)
+}
+
+// schemaAttributes returns the Attributes map literal of the file's
+// schema.Schema literal.
+func schemaAttributes(f *ast.File) *ast.CompositeLit {
+	var attrs *ast.CompositeLit
+	ast.Inspect(f, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || attrs != nil {
+			return attrs == nil
+		}
+		if sel, ok := lit.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Schema" {
+			attrs = fieldValue(lit, "Attributes")
+		}
+		return attrs == nil
+	})
+	return attrs
+}
+
+// findAttribute returns the literal of the attribute at path in an Attributes
+// map literal, descending through nested attributes and nested objects.
+func findAttribute(attrs *ast.CompositeLit, path []string) *ast.CompositeLit {
+	for _, e := range attrs.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.BasicLit)
+		if !ok || key.Value != strconv.Quote(path[0]) {
+			continue
+		}
+		lit, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			return nil
+		}
+		if len(path) == 1 {
+			return lit
+		}
+		children := fieldValue(lit, "Attributes")
+		if obj := fieldValue(lit, "NestedObject"); obj != nil {
+			children = fieldValue(obj, "Attributes")
+		}
+		if children == nil {
+			return nil
+		}
+		return findAttribute(children, path[1:])
 	}
+	return nil
+}
 
-	patchSpecJSON("codegen/spec.json")
+// fieldValue returns the composite literal set for a field of a struct
+// literal, or nil. A field set to something else, such as WriteOnly: true,
+// is returned as an empty literal so callers can tell it is present.
+func fieldValue(lit *ast.CompositeLit, name string) *ast.CompositeLit {
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if id, ok := kv.Key.(*ast.Ident); ok && id.Name == name {
+			if v, ok := kv.Value.(*ast.CompositeLit); ok {
+				return v
+			}
+			return &ast.CompositeLit{}
+		}
+	}
+	return nil
 }
 
 // patchSpecJSON applies metadata to the Track A Provider Code Spec that
@@ -276,21 +696,19 @@ func schemaProps(schemas map[string]any, name string) map[string]any {
 }
 
 // attrsFromStruct reflects a struct type into Provider Code Spec attributes,
-// pulling each field's description from the matching OpenAPI properties map.
-func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
+// pulling each field's description from the matching OpenAPI properties map
+// and applying the overrides for the resource's attributes under parent, the
+// dotted path of the struct (empty at the top level).
+func attrsFromStruct(resource, parent string, t reflect.Type, props map[string]any) []map[string]any {
 	var attrs []map[string]any
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		if !f.IsExported() {
-			continue
-		}
+	for _, f := range attrFields(t) {
 		jsonName, omitempty := parseJSONTag(f)
 		name := snake(jsonName)
-		// ruleType is the discriminator, fixed per resource and not user facing.
-		if name == "rule_type" {
-			continue
-		}
 		desc := description(props, jsonName)
+		path := name
+		if parent != "" {
+			path = parent + "." + name
+		}
 
 		ft := f.Type
 		optional := omitempty
@@ -313,14 +731,21 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 			if sensitive[name] {
 				s["sensitive"] = true
 			}
-			applyOverride(name, s)
+			ov := applyOverride(resource, path, "string", s)
 			// Source enum and pattern constraints from the spec so they stay
 			// correct per rule. A single-value enum (e.g. invocation_mode) is
 			// also made computed_optional with that value as the default.
 			var specVals []customExpr
-			if enums := specEnum(props, jsonName); len(enums) > 0 {
+			enums := specEnum(props, jsonName)
+			if ov.IgnoreSpecEnum {
+				if len(enums) == 0 {
+					panic(fmt.Sprintf("%s: %s: ignore_spec_enum, but the spec declares no enum", resource, path))
+				}
+				enums = nil
+			}
+			if len(enums) > 0 {
 				specVals = append(specVals, customExpr{[]string{pkgStringValidator}, oneOfExpr(enums)})
-				if len(enums) == 1 {
+				if len(enums) == 1 && ov.Mode == "" {
 					s["computed_optional_required"] = "computed_optional"
 					s["default"] = map[string]any{"static": enums[0]}
 				}
@@ -333,8 +758,9 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 			// back as null, aborting the apply with an opaque "inconsistent
 			// values" error. A plan-time validator turns that into a clear
 			// message. Enum-valued attributes already exclude "" via OneOf, and
-			// attributes for which "" is a real value opt out via allowEmpty.
-			if len(specEnum(props, jsonName)) == 0 && len(attrOverrides[name].validators) == 0 && !attrOverrides[name].allowEmpty {
+			// attributes for which "" is a real value opt out via allow_empty,
+			// as do all of a rule's in allowEmpty.
+			if len(enums) == 0 && len(ov.OneOf) == 0 && !ov.AllowEmpty && !allowEmpty[resource] {
 				specVals = append(specVals, customExpr{[]string{pkgStringValidator}, "stringvalidator.LengthAtLeast(1)"})
 			}
 			if len(specVals) > 0 {
@@ -347,12 +773,14 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 			if desc != "" {
 				b["description"] = desc
 			}
+			applyOverride(resource, path, "bool", b)
 			attr["bool"] = b
 		case reflect.Int, reflect.Int64, reflect.Int32:
 			n := map[string]any{"computed_optional_required": mode}
 			if desc != "" {
 				n["description"] = desc
 			}
+			applyOverride(resource, path, "int64", n)
 			// Source minimum/maximum bounds from the spec, mirroring what the
 			// string case does for enums and patterns, so out-of-range values
 			// fail at plan time instead of only at the real API (the echoing
@@ -369,30 +797,42 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 				expr = fmt.Sprintf("int64validator.AtMost(%d)", maxV)
 			}
 			if expr != "" {
-				n["validators"] = customList([]customExpr{{[]string{pkgInt64Validator}, expr}})
+				existing, _ := n["validators"].([]map[string]any)
+				n["validators"] = append(existing, customList([]customExpr{{[]string{pkgInt64Validator}, expr}})...)
 			}
 			attr["int64"] = n
 		case reflect.Struct:
 			sn := map[string]any{
 				"computed_optional_required": mode,
-				"attributes":                 attrsFromStruct(ft, childProps(props, jsonName)),
+				"attributes":                 attrsFromStruct(resource, path, ft, childProps(props, jsonName)),
 			}
 			if desc != "" {
 				sn["description"] = desc
 			}
+			applyOverride(resource, path, "single_nested", sn)
 			attr["single_nested"] = sn
 		case reflect.Slice:
 			elem := ft.Elem()
 			if elem.Kind() == reflect.Struct {
-				attr["list_nested"] = map[string]any{
+				ln := map[string]any{
 					"computed_optional_required": mode,
-					"nested_object":              map[string]any{"attributes": attrsFromStruct(elem, itemProps(props, jsonName))},
+					"nested_object":              map[string]any{"attributes": attrsFromStruct(resource, path, elem, itemProps(props, jsonName))},
+				}
+				if desc != "" {
+					ln["description"] = desc
 				}
+				applyOverride(resource, path, "list_nested", ln)
+				attr["list_nested"] = ln
 			} else {
-				attr["list"] = map[string]any{
+				l := map[string]any{
 					"computed_optional_required": mode,
 					"element_type":               map[string]any{elementType(elem): map[string]any{}},
 				}
+				if desc != "" {
+					l["description"] = desc
+				}
+				applyOverride(resource, path, "list", l)
+				attr["list"] = l
 			}
 		case reflect.Map:
 			if ft.Key().Kind() != reflect.String {
@@ -405,6 +845,7 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 			if desc != "" {
 				m["description"] = desc
 			}
+			applyOverride(resource, path, "map", m)
 			attr["map"] = m
 		default:
 			// Fail loudly rather than emitting an incomplete schema: a silent
@@ -413,11 +854,33 @@ func attrsFromStruct(t reflect.Type, props map[string]any) []map[string]any {
 			// moderation thresholds maps).
 			panic(fmt.Sprintf("ruletypesgen: %s.%s (%s) has kind %s which the generator cannot emit; add support or exclude it explicitly", t.Name(), f.Name, jsonName, ft.Kind()))
 		}
+		if ov, ok := overrides.lookup(resource, path); ok && ov.Name != "" {
+			attr["name"] = ov.Name
+		}
 		attrs = append(attrs, attr)
 	}
 	return attrs
 }
 
+// attrFields returns the fields of a struct type that become attributes, in
+// order: the exported ones, except the ruleType discriminator, which is fixed
+// per resource and not user facing. The models are zipped with the attributes
+// through it.
+func attrFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if jsonName, _ := parseJSONTag(f); snake(jsonName) == "rule_type" {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
 // --- OpenAPI properties helpers --------------------------------------------
 
 func asMap(v any) map[string]any {
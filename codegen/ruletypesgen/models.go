@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// modelsPath is where the generated rule target models are written.
+const modelsPath = "internal/provider/rule_models_gen.go"
+
+// model is a plain-struct model the generic rule CRUD decodes a rule
+// resource's target (or source) block into.
+type model struct {
+	Name   string
+	Doc    string // completes "<Name> models "
+	Fields []modelField
+}
+
+// modelField is one attribute of a model.
+type modelField struct {
+	Name string // the control field's name
+	Type string
+	Tag  string // the Terraform attribute name
+}
+
+// field returns the field called name, or nil.
+func (m *model) field(name string) *modelField {
+	for i := range m.Fields {
+		if m.Fields[i].Name == name {
+			return &m.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ruleModels builds the target model of every shim with one, and the models
+// of the nested blocks and rule source they refer to, from the rule's
+// create-body type and the attributes generated for it. Fields are named
+// after the control fields, tagged with the final attribute names, and a
+// nested block is a pointer unless it is required. Nested models are named
+// after their control type, so one shared by several rules, such as
+// AWSAuthentication, is one model.
+func ruleModels(resources []map[string]any) map[string]*model {
+	models := map[string]*model{}
+	for _, s := range shims {
+		if s.target == "" {
+			continue
+		}
+		i := slices.IndexFunc(rules, func(r rule) bool { return "ably_"+r.name == s.typeName })
+		if i < 0 {
+			panic(fmt.Sprintf("models: %s has a target model but is not in the rules table", s.typeName))
+		}
+		post := reflect.TypeOf(rules[i].post)
+		attrs := resourceAttrs(resources, rules[i].name)
+		for _, f := range attrFields(post) {
+			jsonName, _ := parseJSONTag(f)
+			switch jsonName {
+			case "target":
+				t := derefType(f.Type)
+				addModel(models, s.target, "the target block of "+s.typeName+", mirroring control."+t.Name(), t, nestedAttrs(attrs, "target"))
+			case "source":
+				buildModelType(models, derefType(f.Type), nestedAttrs(attrs, "source"))
+			}
+		}
+		if models[s.target] == nil {
+			panic(fmt.Sprintf("models: %s: %s has no target field", s.typeName, post.Name()))
+		}
+	}
+	return models
+}
+
+// resourceAttrs returns the top-level attributes generated for a resource.
+func resourceAttrs(resources []map[string]any, name string) []map[string]any {
+	for _, r := range resources {
+		if r["name"] == name {
+			attrs, _ := asMap(r["schema"])["attributes"].([]map[string]any)
+			return attrs
+		}
+	}
+	panic(fmt.Sprintf("models: no resource %s", name))
+}
+
+// nestedAttrs returns the attributes of the nested block called name.
+func nestedAttrs(attrs []map[string]any, name string) []map[string]any {
+	for _, a := range attrs {
+		if a["name"] != name {
+			continue
+		}
+		if sn := asMap(a["single_nested"]); sn != nil {
+			children, _ := sn["attributes"].([]map[string]any)
+			return children
+		}
+		if ln := asMap(a["list_nested"]); ln != nil {
+			children, _ := asMap(ln["nested_object"])["attributes"].([]map[string]any)
+			return children
+		}
+	}
+	panic(fmt.Sprintf("models: no nested attribute %s", name))
+}
+
+// addModel adds the model called name for the struct type t, zipping its
+// attribute fields with the attributes generated from them. A name already
+// taken must have been built to the same shape.
+func addModel(models map[string]*model, name, doc string, t reflect.Type, attrs []map[string]any) {
+	fields := attrFields(t)
+	if len(fields) != len(attrs) {
+		panic(fmt.Sprintf("models: %s has %d attribute fields but %d attributes", t.Name(), len(fields), len(attrs)))
+	}
+	m := &model{Name: name, Doc: doc}
+	for i, f := range fields {
+		a := attrs[i]
+		kind, spec := attrKind(a)
+		mf := modelField{Name: f.Name, Tag: a["name"].(string)}
+		switch kind {
+		case "string", "bool", "int64":
+			mf.Type = "types." + goKind(kind)
+		case "list":
+			elem, _ := attrKind(asMap(spec["element_type"]))
+			mf.Type = "[]types." + goKind(elem)
+		case "single_nested":
+			mf.Type = buildModelType(models, derefType(f.Type), nestedAttrs(attrs, mf.Tag))
+			if spec["computed_optional_required"] != "required" {
+				mf.Type = "*" + mf.Type
+			}
+		case "list_nested":
+			mf.Type = "[]" + buildModelType(models, derefType(f.Type).Elem(), nestedAttrs(attrs, mf.Tag))
+		default:
+			panic(fmt.Sprintf("models: %s.%s is a %s attribute, which no model supports yet", t.Name(), f.Name, kind))
+		}
+		m.Fields = append(m.Fields, mf)
+	}
+	if prev, ok := models[name]; ok {
+		if !reflect.DeepEqual(prev.Fields, m.Fields) {
+			panic(fmt.Sprintf("models: %s is generated with different attributes by different rules; give the differing rules their own control types", name))
+		}
+		return
+	}
+	models[name] = m
+}
+
+// buildModelType adds the model of a nested block of control type t and
+// returns its name.
+func buildModelType(models map[string]*model, t reflect.Type, attrs []map[string]any) string {
+	name := "Ably" + t.Name()
+	addModel(models, name, "a control."+t.Name()+" block of the rule resources", t, attrs)
+	return name
+}
+
+// attrKind returns the Provider Code Spec kind of an attribute and its type
+// map.
+func attrKind(a map[string]any) (string, map[string]any) {
+	for k, v := range a {
+		if k != "name" {
+			return k, asMap(v)
+		}
+	}
+	panic(fmt.Sprintf("models: attribute %v has no type", a["name"]))
+}
+
+func goKind(kind string) string {
+	switch kind {
+	case "string":
+		return "String"
+	case "bool":
+		return "Bool"
+	case "int64":
+		return "Int64"
+	}
+	panic(fmt.Sprintf("models: no framework type for %s", kind))
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// writeOnlyField is a write-only selector path the generated
+// preserveWriteOnly copies, with the nil checks its pointer blocks need.
+type writeOnlyField struct {
+	Path  string
+	Guard string
+}
+
+// resolveWriteOnly checks a shim's write-only selector path against its
+// target model, returning it with a guard for each optional block on the way.
+func resolveWriteOnly(models map[string]*model, s shim, path string) writeOnlyField {
+	m := models[s.target]
+	var guards []string
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		f := m.field(part)
+		if f == nil {
+			panic(fmt.Sprintf("shims: %s: writeOnly %s: %s has no field %s", s.typeName, path, m.Name, part))
+		}
+		if i == len(parts)-1 {
+			break
+		}
+		typ := strings.TrimPrefix(f.Type, "*")
+		if typ != f.Type {
+			sel := strings.Join(parts[:i+1], ".")
+			guards = append(guards, "t."+sel+" != nil", "p."+sel+" != nil")
+		}
+		if m = models[typ]; m == nil {
+			panic(fmt.Sprintf("shims: %s: writeOnly %s: %s is not a nested block", s.typeName, path, part))
+		}
+	}
+	return writeOnlyField{Path: path, Guard: strings.Join(guards, " && ")}
+}
+
+var modelsTemplate = template.Must(template.New("models").Parse(`// Code generated by ruletypesgen from the rules and shims tables in codegen/ruletypesgen. DO NOT EDIT.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+{{range .}}
+// {{.Name}} models {{.Doc}}.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `tfsdk:"{{.Tag}}"` + "`" + `
+{{- end}}
+}
+{{end -}}
+`))
+
+// writeModels generates the rule models into path: the target models in shims
+// order, then the nested models by name.
+func writeModels(path string, models map[string]*model) {
+	var ordered []*model
+	for _, s := range shims {
+		if s.target != "" {
+			ordered = append(ordered, models[s.target])
+		}
+	}
+	var nested []*model
+	for _, m := range models {
+		if !slices.Contains(ordered, m) {
+			nested = append(nested, m)
+		}
+	}
+	slices.SortFunc(nested, func(a, b *model) int { return strings.Compare(a.Name, b.Name) })
+	ordered = append(ordered, nested...)
+
+	var buf bytes.Buffer
+	if err := modelsTemplate.Execute(&buf, ordered); err != nil {
+		panic(err)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(fmt.Sprintf("models: formatting generated code: %v\n%s", err, buf.Bytes()))
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"text/template"
+)
+
+// shim declares a rule resource whose boilerplate is generated into
+// shimsPath: the resource type, its Metadata, Provider, Name and RuleType,
+// the CRUD delegating to the generic rule plumbing, identity and import, its
+// entry in the provider's resource list and in RuleTypeResources. The resource's
+// Schema, adopting the schema generated from the rules list, and anything
+// behind a hook stay hand-written in internal/provider/resource_ably_<...>.go.
+// The target model is generated too, into modelsPath.
+type shim struct {
+	ruleType string // the Control API ruleType
+	typeName string // the Terraform resource type
+	goType   string // the resource's Go type
+	name     string // how diagnostics name the resource
+	target   string // the generated target model the generic CRUD is instantiated with
+
+	// crud picks the CRUD: "rule" delegates to CreateRule[T] and friends,
+	// "ingress" to CreateIngressRule[T] and friends, and "custom" leaves
+	// Create, Read, Update and Delete to the hand-written file.
+	crud string
+
+	// Hook points for the special cases. awsAuth gives the target an
+	// awsAuth method, which GetPlanAwsAuth and GetAwsAuth use to reach its
+	// Authentication block. writeOnly names target model fields, as Go
+	// selector paths, that the Control API accepts but never returns:
+	// GetRuleResponse keeps the plan's value for them. configValidators are
+	// Go expressions returned by a generated ConfigValidators.
+	awsAuth          bool
+	writeOnly        []string
+	configValidators []string
+}
+
+// shimsPath is where the generated rule resource shims are written.
+const shimsPath = "internal/provider/rule_resources_gen.go"
+
+// shims is every rule resource the provider registers, in registration order.
+// Adding a rule resource means adding it to rules and a row here, writing its
+// Schema by hand, and running `make generate`.
+var shims = []shim{
+	{ruleType: "aws/kinesis", typeName: "ably_rule_kinesis", goType: "ResourceRuleKinesis", name: "AWS Kinesis", target: "AblyRuleTargetKinesis", crud: "rule",
+		awsAuth: true, configValidators: []string{`SingleRequestModeOnly("aws/kinesis")`}},
+	{ruleType: "aws/sqs", typeName: "ably_rule_sqs", goType: "ResourceRuleSqs", name: "AWS Sqs", target: "AblyRuleTargetSqs", crud: "rule",
+		awsAuth: true, configValidators: []string{`SingleRequestModeOnly("aws/sqs")`}},
+	{ruleType: "aws/lambda", typeName: "ably_rule_lambda", goType: "ResourceRuleLambda", name: "AWS Lambda", target: "AblyRuleTargetLambda", crud: "rule",
+		awsAuth: true},
+	{ruleType: "pulsar", typeName: "ably_rule_pulsar", goType: "ResourceRulePulsar", name: "Pulsar", target: "AblyRuleTargetPulsar", crud: "rule",
+		writeOnly: []string{"TLSTrustCerts"}},
+	{ruleType: "http/zapier", typeName: "ably_rule_zapier", goType: "ResourceRuleZapier", name: "Zapier", target: "AblyRuleTargetZapier", crud: "rule"},
+	{ruleType: "http/google-cloud-function", typeName: "ably_rule_google_function", goType: "ResourceRuleGoogleFunction", name: "Google Cloud Function", target: "AblyRuleTargetGoogleFunction", crud: "rule"},
+	{ruleType: "http/ifttt", typeName: "ably_rule_ifttt", goType: "ResourceRuleIFTTT", name: "IFTTT", target: "AblyRuleTargetIFTTT", crud: "rule"},
+	{ruleType: "http/cloudflare-worker", typeName: "ably_rule_cloudflare_worker", goType: "ResourceRuleCloudflareWorker", name: "Cloudflare Worker", target: "AblyRuleTargetCloudflareWorker", crud: "rule"},
+	{ruleType: "http/azure-function", typeName: "ably_rule_azure_function", goType: "ResourceRuleAzureFunction", name: "Azure Function", target: "AblyRuleTargetAzureFunction", crud: "rule"},
+	{ruleType: "http", typeName: "ably_rule_http", goType: "ResourceRuleHTTP", name: "HTTP", target: "AblyRuleTargetHTTP", crud: "rule"},
+	{ruleType: "kafka", typeName: "ably_rule_kafka", goType: "ResourceRuleKafka", name: "Kafka", target: "AblyRuleTargetKafka", crud: "rule",
+		writeOnly: []string{"Auth.SASL.Password"}},
+	{ruleType: "amqp", typeName: "ably_rule_amqp", goType: "ResourceRuleAMQP", name: "AMQP", target: "AblyRuleTargetAMQP", crud: "rule"},
+	{ruleType: "amqp/external", typeName: "ably_rule_amqp_external", goType: "ResourceRuleAMQPExternal", name: "AMQP External", target: "AblyRuleTargetAMQPExternal", crud: "rule"},
+	{ruleType: "bodyguard/text-moderation", typeName: "ably_rule_bodyguard", goType: "ResourceRuleBodyguard", name: "Bodyguard", crud: "custom"},
+	{ruleType: "ingress/mongodb", typeName: "ably_ingress_rule_mongodb", goType: "ResourceIngressRuleMongo", name: "MongoDB", target: "AblyIngressRuleTargetMongo", crud: "ingress"},
+	{ruleType: "ingress-postgres-outbox", typeName: "ably_ingress_rule_postgres_outbox", goType: "ResourceIngressRulePostgresOutbox", name: "PostgresOutbox", target: "AblyIngressRuleTargetPostgresOutbox", crud: "ingress"},
+}
+
+// checkShims fails generation on a table that can't produce working shims.
+func checkShims() {
+	seen := map[string]bool{}
+	for _, s := range shims {
+		for _, key := range []string{"ruleType " + s.ruleType, "typeName " + s.typeName, "goType " + s.goType} {
+			if seen[key] {
+				panic(fmt.Sprintf("shims: duplicate %s", key))
+			}
+			seen[key] = true
+		}
+		switch s.crud {
+		case "rule", "ingress":
+			if s.target == "" {
+				panic(fmt.Sprintf("shims: %s: %s CRUD needs a target model type", s.typeName, s.crud))
+			}
+		case "custom":
+		default:
+			panic(fmt.Sprintf("shims: %s: unknown crud %q", s.typeName, s.crud))
+		}
+		if (s.awsAuth || len(s.writeOnly) > 0) && s.crud != "rule" {
+			panic(fmt.Sprintf("shims: %s: the awsAuth and writeOnly hooks are for rule CRUD only", s.typeName))
+		}
+	}
+}
+
+var shimsTemplate = template.Must(template.New("shims").Funcs(template.FuncMap{
+	"quote": func(s string) string { return fmt.Sprintf("%q", s) },
+}).Parse(`// Code generated by ruletypesgen from the shims table in codegen/ruletypesgen/shims.go. DO NOT EDIT.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// RuleTypeResources maps a Control API ruleType onto the Terraform resource type
+// that manages it.
+//
+// Every integration rule comes back from GET /apps/{id}/rules regardless of
+// family: webhooks, firehoses, ingress and moderation rules all arrive in one
+// list, told apart only by ruleType. Anything walking an account needs this
+// mapping to know which resource owns a rule.
+var RuleTypeResources = map[string]string{
+{{- range .}}
+	{{quote .RuleType}}: {{quote .TypeName}},
+{{- end}}
+}
+
+// ruleResources returns the rule resources, for [AblyProvider.Resources].
+func ruleResources(p *AblyProvider) []func() resource.Resource {
+	return []func() resource.Resource{
+{{- range .}}
+		func() resource.Resource { return {{.GoType}}{p} },
+{{- end}}
+	}
+}
+{{range .}}
+// ---- {{.TypeName}} ----
+
+type {{.GoType}} struct {
+	p *AblyProvider
+}
+
+var _ resource.Resource = &{{.GoType}}{}
+var _ resource.ResourceWithImportState = &{{.GoType}}{}
+var _ resource.ResourceWithIdentity = &{{.GoType}}{}
+{{- if .ConfigValidators}}
+var _ resource.ResourceWithConfigValidators = &{{.GoType}}{}
+{{- end}}
+
+func (r {{.GoType}}) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = {{quote .TypeName}}
+}
+
+func (r *{{.GoType}}) Provider() *AblyProvider {
+	return r.p
+}
+
+func (r *{{.GoType}}) Name() string {
+	return {{quote .Name}}
+}
+
+func (r *{{.GoType}}) RuleType() string {
+	return {{quote .RuleType}}
+}
+{{- if .ConfigValidators}}
+
+// ConfigValidators validates the configuration as a whole.
+func (r {{.GoType}}) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+{{- range .ConfigValidators}}
+		{{.}},
+{{- end}}
+	}
+}
+{{- end}}
+{{- if .Generic}}
+
+// Create creates a new resource.
+func (r {{.GoType}}) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	Create{{.Generic}}[{{.Target}}](&r, ctx, req, resp)
+}
+
+// Read resource
+func (r {{.GoType}}) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	Read{{.Generic}}[{{.Target}}](&r, ctx, req, resp)
+}
+
+// Update updates an existing resource.
+func (r {{.GoType}}) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	Update{{.Generic}}[{{.Target}}](&r, ctx, req, resp)
+}
+
+// Delete deletes the resource.
+func (r {{.GoType}}) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	Delete{{.Generic}}[{{.Target}}](&r, ctx, req, resp)
+}
+{{- end}}
+
+// IdentitySchema defines the identity of the resource.
+func (r {{.GoType}}) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppScopedIdentitySchema()
+}
+
+// ImportState handles the import state functionality.
+func (r {{.GoType}}) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ImportResource(ctx, r.p, req, resp, nil)
+}
+{{- if .AwsAuth}}
+
+var _ awsAuthTarget = &{{.Target}}{}
+
+func (t *{{.Target}}) awsAuth() AblyAWSAuthentication {
+	if t == nil {
+		return AblyAWSAuthentication{}
+	}
+	return t.Authentication
+}
+{{- end}}
+{{- if .WriteOnly}}
+
+var _ writeOnlyTarget = &{{.Target}}{}
+
+func (t *{{.Target}}) preserveWriteOnly(plan any) {
+	p, ok := plan.(*{{.Target}})
+	if !ok || p == nil {
+		return
+	}
+{{- range .WriteOnly}}
+{{- if .Guard}}
+	if {{.Guard}} {
+		preserveFromPlan(&t.{{.Path}}, p.{{.Path}})
+	}
+{{- else}}
+	preserveFromPlan(&t.{{.Path}}, p.{{.Path}})
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{end -}}
+`))
+
+// shimData is a shim as the template sees it.
+type shimData struct {
+	RuleType, TypeName, GoType, Name, Target string
+	Generic                                  string
+	AwsAuth                                  bool
+	WriteOnly                                []writeOnlyField
+	ConfigValidators                         []string
+}
+
+// writeShims generates the rule resource shims into path, checking the hooks
+// against the generated target models.
+func writeShims(path string, models map[string]*model) {
+	checkShims()
+	data := make([]shimData, 0, len(shims))
+	for _, s := range shims {
+		d := shimData{
+			RuleType:         s.ruleType,
+			TypeName:         s.typeName,
+			GoType:           s.goType,
+			Name:             s.name,
+			Target:           s.target,
+			AwsAuth:          s.awsAuth,
+			ConfigValidators: s.configValidators,
+		}
+		if s.awsAuth {
+			if f := models[s.target].field("Authentication"); f == nil || f.Type != "AblyAWSAuthentication" {
+				panic(fmt.Sprintf("shims: %s: the awsAuth hook needs a required Authentication block of control.AWSAuthentication", s.typeName))
+			}
+		}
+		for _, p := range s.writeOnly {
+			d.WriteOnly = append(d.WriteOnly, resolveWriteOnly(models, s, p))
+		}
+		switch s.crud {
+		case "rule":
+			d.Generic = "Rule"
+		case "ingress":
+			d.Generic = "IngressRule"
+		}
+		data = append(data, d)
+	}
+	var buf bytes.Buffer
+	if err := shimsTemplate.Execute(&buf, data); err != nil {
+		panic(err)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(fmt.Sprintf("shims: formatting generated code: %v\n%s", err, buf.Bytes()))
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,604 @@
+// Command specdrift reports where the control package has drifted from the
+// vendored Control API spec (codegen/control-api.yaml).
+//
+// `make refresh-spec` pulls in whatever the spec has grown, but the generators
+// only look at the parts of it they already know about, so a new field or rule
+// type arrives silently. specdrift walks every schema in components.schemas and
+// every ruleType discriminator, reflects the control struct modelling each
+// schema by JSON tag, and reports:
+//
+//   - fields the spec has and the control type lacks (missing),
+//   - fields the control type has and the spec lacks (extra),
+//   - schemas no control type models (unmapped), and
+//   - ruleTypes whose schemas no control type models (unimplemented).
+//
+// The mappings table pairs schemas with control types. Drift the control
+// package carries on purpose is listed, with the reason, in accepted; it is
+// reported but does not fail the run. An accepted entry that no longer matches
+// any drift fails the run, so the table can't go stale.
+//
+// Run from the repository root via `make spec-drift`. It prints text, or JSON
+// with -json, and exits 1 when there is drift and 2 when it can't run.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"gopkg.in/yaml.v3"
+)
+
+// mapping pairs a spec schema with a zero value of the control type that
+// models it. target, for the rule responses, is the type the provider decodes
+// the response's untyped target into (see unmarshalTarget in
+// internal/provider/rules.go), and stands in for RuleResponse.Target.
+type mapping struct {
+	schema string
+	goType any
+	target any
+}
+
+// mappings covers every schema the control package models. The rule PATCH
+// schemas without a XxxRulePatch type map onto the create body, which is what
+// the provider sends for an update.
+var mappings = []mapping{
+	{"error", control.Error{}, nil},
+	{"me", control.Me{}, nil},
+	{"app_post", control.AppPost{}, nil},
+	{"app_patch", control.AppPatch{}, nil},
+	{"app_response", control.AppResponse{}, nil},
+	{"account_stats_response", control.StatsResponse{}, nil},
+	{"app_stats_response", control.StatsResponse{}, nil},
+	{"key_post", control.KeyPost{}, nil},
+	{"key_patch", control.KeyPatch{}, nil},
+	{"key_response", control.KeyResponse{}, nil},
+	{"namespace_post", control.NamespacePost{}, nil},
+	{"namespace_patch", control.NamespacePatch{}, nil},
+	{"namespace_response", control.NamespaceResponse{}, nil},
+	{"queue", control.Queue{}, nil},
+	{"queue_response", control.QueueResponse{}, nil},
+	{"rule_source", control.RuleSource{}, nil},
+	{"rule_source_patch", control.RuleSourcePatch{}, nil},
+
+	{"http_rule_post", control.HTTPRulePost{}, nil},
+	{"http_rule_patch", control.HTTPRulePatch{}, nil},
+	{"http_rule_response", control.RuleResponse{}, control.HTTPRuleTarget{}},
+	{"ifttt_rule_post", control.IFTTTRulePost{}, nil},
+	{"ifttt_rule_patch", control.IFTTTRulePatch{}, nil},
+	{"ifttt_rule_response", control.RuleResponse{}, control.IFTTTRuleTarget{}},
+	{"zapier_rule_post", control.ZapierRulePost{}, nil},
+	{"zapier_rule_patch", control.ZapierRulePatch{}, nil},
+	{"zapier_rule_response", control.RuleResponse{}, control.ZapierRuleTarget{}},
+	{"cloudflare_worker_rule_post", control.CloudflareWorkerRulePost{}, nil},
+	{"cloudflare_worker_rule_patch", control.CloudflareWorkerRulePatch{}, nil},
+	{"cloudflare_worker_rule_response", control.RuleResponse{}, control.CloudflareWorkerRuleTarget{}},
+	{"azure_function_rule_post", control.AzureFunctionRulePost{}, nil},
+	{"azure_function_rule_patch", control.AzureFunctionRulePatch{}, nil},
+	{"azure_function_rule_response", control.RuleResponse{}, control.AzureFunctionRuleTarget{}},
+	{"google_cloud_function_rule_post", control.GoogleCloudFunctionRulePost{}, nil},
+	{"google_cloud_function_rule_patch", control.GoogleCloudFunctionRulePatch{}, nil},
+	{"google_cloud_function_rule_response", control.RuleResponse{}, control.GoogleCloudFunctionRuleTarget{}},
+	{"aws_lambda_rule_post", control.AWSLambdaRulePost{}, nil},
+	{"aws_lambda_rule_patch", control.AWSLambdaRulePost{}, nil},
+	{"aws_lambda_rule_response", control.RuleResponse{}, control.AWSLambdaTarget{}},
+	{"aws_kinesis_rule_post", control.AWSKinesisRulePost{}, nil},
+	{"aws_kinesis_rule_patch", control.AWSKinesisRulePost{}, nil},
+	{"aws_kinesis_rule_response", control.RuleResponse{}, control.AWSKinesisTarget{}},
+	{"aws_sqs_rule_post", control.AWSSQSRulePost{}, nil},
+	{"aws_sqs_rule_patch", control.AWSSQSRulePost{}, nil},
+	{"aws_sqs_rule_response", control.RuleResponse{}, control.AWSSQSTarget{}},
+	{"amqp_rule_post", control.AMQPRulePost{}, nil},
+	{"amqp_rule_patch", control.AMQPRulePost{}, nil},
+	{"amqp_rule_response", control.RuleResponse{}, control.AMQPRuleTarget{}},
+	{"amqp_external_rule_post", control.AMQPExternalRulePost{}, nil},
+	{"amqp_external_rule_patch", control.AMQPExternalRulePost{}, nil},
+	{"amqp_external_rule_response", control.RuleResponse{}, control.AMQPExternalRuleTarget{}},
+	{"kafka_rule_post", control.KafkaRulePost{}, nil},
+	{"kafka_rule_patch", control.KafkaRulePost{}, nil},
+	{"kafka_rule_response", control.RuleResponse{}, control.KafkaRuleTarget{}},
+	{"pulsar_rule_post", control.PulsarRulePost{}, nil},
+	{"pulsar_rule_patch", control.PulsarRulePost{}, nil},
+	{"pulsar_rule_response", control.RuleResponse{}, control.PulsarRuleTarget{}},
+	{"ingress_postgres_outbox_rule_post", control.IngressPostgresOutboxRulePost{}, nil},
+	{"ingress_postgres_outbox_rule_patch", control.IngressPostgresOutboxRulePatch{}, nil},
+	{"ingress_postgres_outbox_rule_response", control.RuleResponse{}, control.IngressPostgresOutboxTarget{}},
+	{"ingress_mongodb_changestream_rule_post", control.IngressMongoDBRulePost{}, nil},
+	{"ingress_mongodb_changestream_rule_patch", control.IngressMongoDBRulePatch{}, nil},
+	{"ingress_mongodb_changestream_rule_response", control.RuleResponse{}, control.IngressMongoDBTarget{}},
+	{"before_publish_aws_lambda_rule_post", control.BeforePublishAWSLambdaRulePost{}, nil},
+	{"before_publish_aws_lambda_rule_patch", control.BeforePublishAWSLambdaRulePatch{}, nil},
+	{"before_publish_aws_lambda_rule_response", control.RuleResponse{}, control.BeforePublishAWSLambdaTarget{}},
+	{"before_publish_webhook_rule_post", control.BeforePublishWebhookRulePost{}, nil},
+	{"before_publish_webhook_rule_patch", control.BeforePublishWebhookRulePatch{}, nil},
+	{"before_publish_webhook_rule_response", control.RuleResponse{}, control.BeforePublishWebhookTarget{}},
+	{"hive_text_model_only_rule_post", control.HiveTextModelOnlyRulePost{}, nil},
+	{"hive_text_model_only_rule_patch", control.HiveTextModelOnlyRulePatch{}, nil},
+	{"hive_text_model_only_rule_response", control.RuleResponse{}, control.HiveTextModelOnlyTarget{}},
+	{"hive_dashboard_rule_post", control.HiveDashboardRulePost{}, nil},
+	{"hive_dashboard_rule_patch", control.HiveDashboardRulePatch{}, nil},
+	{"hive_dashboard_rule_response", control.RuleResponse{}, control.HiveDashboardTarget{}},
+	{"bodyguard_text_moderation_rule_post", control.BodyguardTextModerationRulePost{}, nil},
+	{"bodyguard_text_moderation_rule_patch", control.BodyguardTextModerationRulePatch{}, nil},
+	{"bodyguard_text_moderation_rule_response", control.RuleResponse{}, control.BodyguardTextModerationTarget{}},
+	{"tisane_text_moderation_rule_post", control.TisaneTextModerationRulePost{}, nil},
+	{"tisane_text_moderation_rule_patch", control.TisaneTextModerationRulePatch{}, nil},
+	{"tisane_text_moderation_rule_response", control.RuleResponse{}, control.TisaneTextModerationTarget{}},
+	{"azure_text_moderation_rule_post", control.AzureTextModerationRulePost{}, nil},
+	{"azure_text_moderation_rule_patch", control.AzureTextModerationRulePatch{}, nil},
+	{"azure_text_moderation_rule_response", control.RuleResponse{}, control.AzureTextModerationTarget{}},
+	{"unsupported_rule_response", control.RuleResponse{}, nil},
+}
+
+// ignored names the schemas no control type models on purpose, with the
+// reason. Schemas reached through a $ref or a oneOf from a mapped schema, and
+// the discriminated unions themselves, need no entry.
+var ignored = map[string]string{
+	"app_pkcs12": "a multipart upload, sent by UpdateAppPKCS12 without a body type",
+}
+
+// Reasons shared by several accepted entries.
+const (
+	writeOnlySecret = "write-only: the API takes it and never returns it, and one control type models the request and the response"
+	notInSpec       = "the provider sends it and the spec does not list it yet"
+	responseOnly    = "the API returns it but the rule's create body does not take it, and the provider never reads it"
+)
+
+// accepted is drift the control package carries on purpose, keyed as the
+// report prints it (schema.path for a field, schema:<name> for a schema,
+// ruleType:<value> for a rule type), with the reason. Entries that are gaps in
+// the spec rather than choices of ours are worth raising on ably/docs.
+var accepted = map[string]string{
+	"me.token.expires_at":   "deliberately not modelled: the provider reads only the account from /me (see CODEGEN_STRATEGY.md)",
+	"me.token.last_used_at": "deliberately not modelled: the provider reads only the account from /me (see CODEGEN_STRATEGY.md)",
+
+	"account_stats_response.inProgress": "decoded when the API sends it; the spec does not list it",
+	"app_stats_response.inProgress":     "decoded when the API sends it; the spec does not list it",
+
+	// identified is the canonical spelling; authenticated is its legacy alias.
+	"namespace_post.identified":     notInSpec,
+	"namespace_patch.identified":    notInSpec,
+	"namespace_response.identified": notInSpec,
+
+	"google_cloud_function_rule_post.status": "every other rule create body takes status; the spec leaves it out of this one",
+
+	"aws_lambda_rule_response.target.format":             responseOnly,
+	"before_publish_webhook_rule_response.target.format": responseOnly,
+
+	"aws_lambda_rule_response.target.authentication.secretAccessKey":                writeOnlySecret,
+	"aws_kinesis_rule_response.target.authentication.secretAccessKey":               writeOnlySecret,
+	"aws_sqs_rule_response.target.authentication.secretAccessKey":                   writeOnlySecret,
+	"before_publish_aws_lambda_rule_response.target.authentication.secretAccessKey": writeOnlySecret,
+
+	"amqp_external_rule_post.target.exchange":     notInSpec,
+	"amqp_external_rule_patch.target.exchange":    notInSpec,
+	"amqp_external_rule_response.target.exchange": notInSpec,
+
+	// The spec lists primarySite for MongoDB ingress only.
+	"ingress_postgres_outbox_rule_post.target.primarySite":     notInSpec,
+	"ingress_postgres_outbox_rule_patch.target.primarySite":    notInSpec,
+	"ingress_postgres_outbox_rule_response.target.primarySite": notInSpec,
+}
+
+// Report is what specdrift found.
+type Report struct {
+	Spec                   string     `json:"spec"`
+	Missing                []Field    `json:"missing"`
+	Extra                  []Field    `json:"extra"`
+	UnmappedSchemas        []string   `json:"unmapped_schemas"`
+	UnimplementedRuleTypes []RuleType `json:"unimplemented_rule_types"`
+	Accepted               []Accepted `json:"accepted"`
+	StaleAccepted          []string   `json:"stale_accepted"`
+}
+
+// Field is a field one side has and the other lacks. Path is the JSON path
+// below the schema, dotted, with [] for an array's items.
+type Field struct {
+	Schema string `json:"schema"`
+	Path   string `json:"path"`
+	GoType string `json:"go_type"`
+}
+
+func (f Field) key() string { return f.Schema + "." + f.Path }
+
+// RuleType is a ruleType discriminator value some of whose schemas no control
+// type models.
+type RuleType struct {
+	RuleType string   `json:"rule_type"`
+	Schemas  []string `json:"schemas"`
+}
+
+func (r RuleType) key() string { return "ruleType:" + r.RuleType }
+
+// Accepted is drift matched by an accepted entry.
+type Accepted struct {
+	Key    string `json:"key"`
+	Reason string `json:"reason"`
+}
+
+// Drift reports whether the run should fail.
+func (r *Report) Drift() bool {
+	return len(r.Missing)+len(r.Extra)+len(r.UnmappedSchemas)+len(r.UnimplementedRuleTypes)+len(r.StaleAccepted) > 0
+}
+
+func main() {
+	specPath := flag.String("spec", "codegen/control-api.yaml", "the OpenAPI spec to check the control package against")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "specdrift:", err)
+		os.Exit(2)
+	}
+	report, err := check(data, mappings, ignored, accepted)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "specdrift:", err)
+		os.Exit(2)
+	}
+	report.Spec = *specPath
+
+	if *asJSON {
+		err = writeJSON(os.Stdout, report)
+	} else {
+		err = writeText(os.Stdout, report)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "specdrift:", err)
+		os.Exit(2)
+	}
+	if report.Drift() {
+		os.Exit(1)
+	}
+}
+
+// checker walks the spec's schemas against the control types.
+type checker struct {
+	schemas map[string]any
+	covered map[string]bool
+	report  *Report
+}
+
+// check compares the spec in data with the control types in mappings.
+func check(data []byte, mappings []mapping, ignored, accepted map[string]string) (*Report, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	schemas := asMap(asMap(doc["components"])["schemas"])
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("the spec has no components.schemas")
+	}
+
+	c := &checker{schemas: schemas, covered: map[string]bool{}, report: &Report{}}
+
+	// A control type modelling several schemas, like RuleResponse, has the
+	// fields of all of them, so a field is extra only if none has it.
+	union := map[reflect.Type]map[string]bool{}
+	mapped := map[string]bool{}
+	for _, m := range mappings {
+		node, ok := schemas[m.schema]
+		if !ok {
+			return nil, fmt.Errorf("mappings names schema %q, which the spec does not have", m.schema)
+		}
+		if mapped[m.schema] {
+			return nil, fmt.Errorf("mappings names schema %q twice", m.schema)
+		}
+		mapped[m.schema] = true
+		t := reflect.TypeOf(m.goType)
+		if union[t] == nil {
+			union[t] = map[string]bool{}
+		}
+		for name := range c.properties(node) {
+			union[t][name] = true
+		}
+	}
+	for name := range ignored {
+		if _, ok := schemas[name]; !ok {
+			return nil, fmt.Errorf("ignored names schema %q, which the spec does not have", name)
+		}
+	}
+
+	for _, m := range mappings {
+		t := reflect.TypeOf(m.goType)
+		var target reflect.Type
+		if m.target != nil {
+			target = reflect.TypeOf(m.target)
+		}
+		c.compare(m.schema, "", schemas[m.schema], t, target, union[t])
+	}
+
+	for _, name := range sortedKeys(schemas) {
+		node := asMap(schemas[name])
+		if mapped[name] || ignored[name] != "" || c.covered[name] || node["oneOf"] != nil {
+			continue
+		}
+		c.report.UnmappedSchemas = append(c.report.UnmappedSchemas, name)
+	}
+
+	// Every ruleType a discriminated union names needs its schema modelled.
+	unimplemented := map[string][]string{}
+	for _, name := range sortedKeys(schemas) {
+		discriminator := asMap(asMap(schemas[name])["discriminator"])
+		if discriminator["propertyName"] != "ruleType" {
+			continue
+		}
+		for value, ref := range asMap(discriminator["mapping"]) {
+			schema := refName(fmt.Sprint(ref))
+			if !mapped[schema] && ignored[schema] == "" {
+				unimplemented[value] = append(unimplemented[value], schema)
+			}
+		}
+	}
+	for _, value := range sortedKeys(unimplemented) {
+		schemas := unimplemented[value]
+		sort.Strings(schemas)
+		c.report.UnimplementedRuleTypes = append(c.report.UnimplementedRuleTypes, RuleType{RuleType: value, Schemas: schemas})
+	}
+
+	c.report.applyAccepted(accepted)
+	return c.report, nil
+}
+
+// compare checks the spec node at path in schema against the Go type t,
+// recursing into nested objects and arrays. target, at the top level, stands
+// in for an untyped target field; union, at the top level, is every field any
+// schema modelled by t has.
+func (c *checker) compare(schema, path string, node any, t reflect.Type, target reflect.Type, union map[string]bool) {
+	node = c.resolve(node)
+	if items := asMap(node)["items"]; items != nil {
+		c.compare(schema, join(path, "[]"), items, elem(t), nil, nil)
+		return
+	}
+
+	t = elem(t)
+	props := c.properties(node)
+	if t.Kind() != reflect.Struct || len(props) == 0 {
+		// A map, an untyped value or a free-form object: nothing to line up.
+		return
+	}
+	fields := jsonFields(t)
+
+	for _, name := range sortedKeys(props) {
+		field, ok := fields[name]
+		if !ok {
+			c.report.Missing = append(c.report.Missing, Field{Schema: schema, Path: join(path, name), GoType: t.String()})
+			continue
+		}
+		ft := field.Type
+		if path == "" && name == "target" && target != nil && elem(ft).Kind() == reflect.Interface {
+			ft = target
+		}
+		c.compare(schema, join(path, name), props[name], ft, nil, nil)
+	}
+	for _, name := range sortedKeys(fields) {
+		if _, ok := props[name]; ok || union[name] {
+			continue
+		}
+		c.report.Extra = append(c.report.Extra, Field{Schema: schema, Path: join(path, name), GoType: t.String()})
+	}
+}
+
+// resolve follows $ref, marking each schema it reaches as covered.
+func (c *checker) resolve(node any) any {
+	for range 16 {
+		ref, ok := asMap(node)["$ref"].(string)
+		if !ok {
+			return node
+		}
+		name := refName(ref)
+		c.covered[name] = true
+		node = c.schemas[name]
+	}
+	return node
+}
+
+// properties returns a schema's properties, merging a oneOf's variants, as
+// the control types model a union such as the AWS authentication modes with
+// one struct.
+func (c *checker) properties(node any) map[string]any {
+	node = c.resolve(node)
+	props := map[string]any{}
+	for name, prop := range asMap(asMap(node)["properties"]) {
+		props[name] = prop
+	}
+	if variants, ok := asMap(node)["oneOf"].([]any); ok {
+		for _, variant := range variants {
+			for name, prop := range c.properties(variant) {
+				if _, seen := props[name]; !seen {
+					props[name] = prop
+				}
+			}
+		}
+	}
+	return props
+}
+
+// applyAccepted moves drift matching an accepted entry into Accepted, and
+// records entries that matched nothing as stale.
+func (r *Report) applyAccepted(accepted map[string]string) {
+	used := map[string]bool{}
+	take := func(key string) bool {
+		reason, ok := accepted[key]
+		if ok {
+			used[key] = true
+			r.Accepted = append(r.Accepted, Accepted{Key: key, Reason: reason})
+		}
+		return ok
+	}
+	keepFields := func(fields []Field) []Field {
+		var kept []Field
+		for _, f := range fields {
+			if !take(f.key()) {
+				kept = append(kept, f)
+			}
+		}
+		return kept
+	}
+	r.Missing = keepFields(r.Missing)
+	r.Extra = keepFields(r.Extra)
+
+	var schemas []string
+	for _, name := range r.UnmappedSchemas {
+		if !take("schema:" + name) {
+			schemas = append(schemas, name)
+		}
+	}
+	r.UnmappedSchemas = schemas
+
+	var ruleTypes []RuleType
+	for _, rt := range r.UnimplementedRuleTypes {
+		if !take(rt.key()) {
+			ruleTypes = append(ruleTypes, rt)
+		}
+	}
+	r.UnimplementedRuleTypes = ruleTypes
+
+	sort.Slice(r.Accepted, func(i, j int) bool { return r.Accepted[i].Key < r.Accepted[j].Key })
+	for _, key := range sortedKeys(accepted) {
+		if !used[key] {
+			r.StaleAccepted = append(r.StaleAccepted, key)
+		}
+	}
+}
+
+// writeText prints the report for a person.
+func writeText(w io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "specdrift: %s against the control package\n", r.Spec)
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	fieldLines := func(fields []Field) []string {
+		var lines []string
+		for _, f := range fields {
+			lines = append(lines, fmt.Sprintf("%s (%s)", f.key(), f.GoType))
+		}
+		return lines
+	}
+	var ruleTypes, accepted []string
+	for _, rt := range r.UnimplementedRuleTypes {
+		ruleTypes = append(ruleTypes, fmt.Sprintf("%s (%s)", rt.RuleType, strings.Join(rt.Schemas, ", ")))
+	}
+	for _, a := range r.Accepted {
+		accepted = append(accepted, fmt.Sprintf("%s: %s", a.Key, a.Reason))
+	}
+
+	section("In the spec, missing from the control types", fieldLines(r.Missing))
+	section("In the control types, not in the spec", fieldLines(r.Extra))
+	section("Schemas no control type models (add them to mappings or ignored)", r.UnmappedSchemas)
+	section("Rule types the control package does not implement", ruleTypes)
+	section("Accepted entries that no longer match any drift (remove them)", r.StaleAccepted)
+	section("Accepted drift", accepted)
+
+	if r.Drift() {
+		fmt.Fprintf(&b, "\ndrift found: update the control package, or accept it in codegen/specdrift/main.go with the reason\n")
+	} else {
+		fmt.Fprintf(&b, "\nno drift\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeJSON prints the report for a machine. Empty lists print as [], not
+// null, so a consumer can iterate without checking.
+func writeJSON(w io.Writer, r *Report) error {
+	out := *r
+	out.Missing = nonNil(out.Missing)
+	out.Extra = nonNil(out.Extra)
+	out.UnmappedSchemas = nonNil(out.UnmappedSchemas)
+	out.UnimplementedRuleTypes = nonNil(out.UnimplementedRuleTypes)
+	out.Accepted = nonNil(out.Accepted)
+	out.StaleAccepted = nonNil(out.StaleAccepted)
+	r = &out
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// jsonFields returns a struct's fields by JSON name, flattening embedded
+// structs the way encoding/json does.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && elem(f.Type).Kind() == reflect.Struct {
+			for embeddedName, embedded := range jsonFields(elem(f.Type)) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embedded
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// elem strips pointers and slices down to the element type.
+func elem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+func join(path, name string) string {
+	switch {
+	case path == "":
+		return name
+	case name == "[]":
+		return path + name
+	}
+	return path + "." + name
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
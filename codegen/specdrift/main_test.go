@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestVendoredSpecHasNoDrift runs the real tables against the vendored spec,
+// so refreshing it onto new fields or rule types fails here until the control
+// package catches up or the drift is accepted.
+func TestVendoredSpecHasNoDrift(t *testing.T) {
+	data, err := os.ReadFile("../control-api.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := check(data, mappings, ignored, accepted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Drift() {
+		var out bytes.Buffer
+		if err := writeText(&out, report); err != nil {
+			t.Fatal(err)
+		}
+		t.Errorf("the control package has drifted from codegen/control-api.yaml:\n%s", out.String())
+	}
+}
+
+const driftSpec = `
+components:
+  schemas:
+    widget_post:
+      type: object
+      properties:
+        name: {type: string}
+        colour: {type: string}
+        target:
+          type: object
+          properties:
+            url: {type: string}
+            auth:
+              oneOf:
+                - $ref: '#/components/schemas/auth_key'
+                - $ref: '#/components/schemas/auth_role'
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              key: {type: string}
+              value: {type: string}
+    auth_key:
+      type: object
+      properties:
+        mode: {type: string}
+        key: {type: string}
+    auth_role:
+      type: object
+      properties:
+        mode: {type: string}
+        role: {type: string}
+    gadget_post:
+      type: object
+      properties:
+        name: {type: string}
+    gizmo_response:
+      type: object
+      properties:
+        id: {type: string}
+    rule_post:
+      oneOf:
+        - $ref: '#/components/schemas/widget_post'
+        - $ref: '#/components/schemas/gadget_post'
+      discriminator:
+        propertyName: ruleType
+        mapping:
+          widget: '#/components/schemas/widget_post'
+          gadget: '#/components/schemas/gadget_post'
+`
+
+type driftAuth struct {
+	Mode string `json:"mode"`
+	Key  string `json:"key,omitempty"`
+	Role string `json:"role,omitempty"`
+}
+
+type driftTag struct {
+	Key string `json:"key"`
+}
+
+type driftCommon struct {
+	Name string `json:"name"`
+}
+
+type driftWidget struct {
+	driftCommon
+	Legacy string `json:"legacy,omitempty"`
+	Target *struct {
+		URL  string    `json:"url"`
+		Auth driftAuth `json:"auth"`
+	} `json:"target"`
+	Tags     []driftTag `json:"tags"`
+	internal string
+	Ignored  string `json:"-"`
+}
+
+func TestCheckReportsDrift(t *testing.T) {
+	report, err := check([]byte(driftSpec), []mapping{{"widget_post", driftWidget{}, nil}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The merged oneOf variants, the embedded struct and the array items all
+	// line up; colour and the tag value don't.
+	if got, want := fieldKeys(report.Missing), []string{"widget_post.colour", "widget_post.tags[].value"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("missing = %q, want %q", got, want)
+	}
+	if got, want := fieldKeys(report.Extra), []string{"widget_post.legacy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("extra = %q, want %q", got, want)
+	}
+	// auth_key and auth_role are reached from widget_post, and rule_post is a union.
+	if got, want := report.UnmappedSchemas, []string{"gadget_post", "gizmo_response"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unmapped = %q, want %q", got, want)
+	}
+	if got, want := report.UnimplementedRuleTypes, []RuleType{{RuleType: "gadget", Schemas: []string{"gadget_post"}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unimplemented = %+v, want %+v", got, want)
+	}
+	if !report.Drift() {
+		t.Error("Drift() = false with drift reported")
+	}
+}
+
+func TestCheckAcceptsAndIgnores(t *testing.T) {
+	accepted := map[string]string{
+		"widget_post.colour":         "the provider has no use for it",
+		"widget_post.tags[].value":   "ditto",
+		"widget_post.legacy":         "sent for old servers",
+		"ruleType:gadget":            "not yet",
+		"widget_post.gone":           "matches nothing",
+		"schema:gizmo_response":      "response-only",
+		"ruleType:never-in-the-spec": "matches nothing",
+	}
+	ignored := map[string]string{"gadget_post": "modelled elsewhere"}
+
+	report, err := check([]byte(driftSpec), []mapping{{"widget_post", driftWidget{}, nil}}, ignored, accepted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Missing)+len(report.Extra)+len(report.UnmappedSchemas) > 0 {
+		t.Errorf("accepted or ignored drift was still reported: %+v", report)
+	}
+	// gadget_post is ignored, so the gadget ruleType is implemented as far as
+	// specdrift can tell, and its accepted entry is stale.
+	if got, want := report.StaleAccepted, []string{"ruleType:gadget", "ruleType:never-in-the-spec", "widget_post.gone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stale = %q, want %q", got, want)
+	}
+	if len(report.Accepted) != 4 {
+		t.Errorf("accepted = %+v, want the four entries that matched", report.Accepted)
+	}
+	if !report.Drift() {
+		t.Error("stale accepted entries should fail the run")
+	}
+}
+
+// TestCheckUnionTypes checks a control type modelling several schemas is only
+// charged with an extra field no schema has, and that a rule response's
+// untyped target is compared as the target type.
+func TestCheckUnionTypes(t *testing.T) {
+	spec := `
+components:
+  schemas:
+    one_response:
+      type: object
+      properties:
+        id: {type: string}
+        target:
+          type: object
+          properties:
+            url: {type: string}
+    two_response:
+      type: object
+      properties:
+        id: {type: string}
+        mode: {type: string}
+`
+	type response struct {
+		ID     string `json:"id"`
+		Mode   string `json:"mode,omitempty"`
+		Stray  string `json:"stray,omitempty"`
+		Target any    `json:"target,omitempty"`
+	}
+	type target struct {
+		URL    string `json:"url"`
+		Format string `json:"format"`
+	}
+
+	report, err := check([]byte(spec), []mapping{
+		{"one_response", response{}, target{}},
+		{"two_response", response{}, nil},
+	}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// two_response has no target, but one_response does, so the shared type may.
+	want := []string{"one_response.target.format", "one_response.stray", "two_response.stray"}
+	if got := fieldKeys(report.Extra); !reflect.DeepEqual(got, want) {
+		t.Errorf("extra = %q, want %q", got, want)
+	}
+}
+
+func TestCheckRejectsBadTables(t *testing.T) {
+	for name, mappings := range map[string][]mapping{
+		"unknown schema":   {{"nope", driftWidget{}, nil}},
+		"duplicate schema": {{"widget_post", driftWidget{}, nil}, {"widget_post", driftWidget{}, nil}},
+	} {
+		if _, err := check([]byte(driftSpec), mappings, nil, nil); err == nil {
+			t.Errorf("%s: check succeeded", name)
+		}
+	}
+	if _, err := check([]byte(driftSpec), nil, map[string]string{"nope": "?"}, nil); err == nil {
+		t.Error("an ignored schema the spec lacks should fail")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeJSON(&out, &Report{Spec: "spec.yaml", Extra: []Field{{Schema: "s", Path: "p", GoType: "T"}}}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("not JSON: %v\n%s", err, out.String())
+	}
+	if missing, ok := decoded["missing"].([]any); !ok || len(missing) != 0 {
+		t.Errorf("missing = %v, want []", decoded["missing"])
+	}
+	if !strings.Contains(out.String(), `"path": "p"`) {
+		t.Errorf("the extra field is not in the output:\n%s", out.String())
+	}
+}
+
+func fieldKeys(fields []Field) []string {
+	var keys []string
+	for _, f := range fields {
+		keys = append(keys, f.key())
+	}
+	return keys
+}
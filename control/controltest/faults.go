@@ -0,0 +1,185 @@
+package controltest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Fault describes how the fake misbehaves for the requests an
+// [Server.InjectFault] rule matches, so a client's retry, not-found and
+// partial-failure paths can be exercised. The zero Fault serves requests
+// normally.
+type Fault struct {
+	// Status, when set, answers the request with this status and an error
+	// body instead of serving it, for 5xx and 429 responses.
+	Status int
+
+	// RetryAfter sets the Retry-After header of a Status response, rounded
+	// up to whole seconds.
+	RetryAfter time.Duration
+
+	// Delay holds the request this long before answering it, or until the
+	// client gives up.
+	Delay time.Duration
+
+	// Drop closes the connection without answering, as a network failure or
+	// a load balancer timing out would.
+	Drop bool
+
+	// Apply serves the request before the Status response or dropped
+	// connection, so the change lands even though the client is told it
+	// failed: the create whose response is lost.
+	Apply bool
+
+	// Then changes the state after each matching request, under the
+	// server's lock, for changes made behind the client's back such as
+	// deleting a resource out of band between an apply and the refresh
+	// that follows it. It must not call the server.
+	Then func(state *State)
+
+	// Times is how many matching requests the fault applies to before it is
+	// removed. Zero applies it until the function InjectFault returns is
+	// called.
+	Times int
+}
+
+// faultRule is a Fault with the requests it applies to.
+type faultRule struct {
+	method  string
+	pattern string
+	fault   Fault
+}
+
+// InjectFault makes the fake misbehave as f describes for requests with the
+// method, or any method when it is empty, and a path matching pattern, using
+// [path.Match] syntax so that "/apps/*/rules" matches the rules of every app.
+// Rules are tried in the order they were injected, and the first match
+// applies. It returns a function that removes the rule.
+//
+//	fake.InjectFault("POST", "/apps/*/rules", controltest.Fault{Status: 503, Times: 2})
+func (s *Server) InjectFault(method, pattern string, f Fault) (remove func()) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		panic(fmt.Sprintf("controltest: bad fault pattern %q: %s", pattern, err))
+	}
+	rule := &faultRule{method: method, pattern: pattern, fault: f}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, rule)
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.faults = slices.DeleteFunc(s.faults, func(r *faultRule) bool { return r == rule })
+	}
+}
+
+// SetListLag makes resources created through the API after the call missing
+// from list responses until they are d old, as in an eventually consistent
+// store where a read straight after a write can miss it. Reads of a single
+// rule still find it. Zero turns the lag off.
+func (s *Server) SetListLag(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listLag = d
+	if d == 0 {
+		s.born = nil
+	}
+}
+
+// matchFault returns the fault for r, counting it against its Times, or nil.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, rule := range s.faults {
+		if rule.method != "" && rule.method != r.Method {
+			continue
+		}
+		if ok, _ := path.Match(rule.pattern, r.URL.Path); !ok {
+			continue
+		}
+		f := rule.fault
+		if rule.fault.Times > 0 {
+			rule.fault.Times--
+			if rule.fault.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return &f
+	}
+	return nil
+}
+
+// injectFaults applies the injected faults in front of next.
+func (s *Server) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f := s.matchFault(r)
+		if f == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if f.Delay > 0 {
+			select {
+			case <-time.After(f.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		failing := f.Status != 0 || f.Drop
+		switch {
+		case !failing:
+			next.ServeHTTP(w, r)
+		case f.Apply:
+			next.ServeHTTP(httptest.NewRecorder(), r)
+		}
+		if f.Then != nil {
+			s.Inspect(f.Then)
+		}
+
+		switch {
+		case f.Drop:
+			if s.log != nil {
+				fmt.Fprintf(s.log, "[controltest] %s %s -> connection dropped\n", r.Method, r.URL.Path)
+			}
+			// net/http closes the connection without a response, and
+			// without logging, when a handler panics with this.
+			panic(http.ErrAbortHandler)
+		case f.Status != 0:
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, f.Status, "controltest: injected fault")
+		}
+	})
+}
+
+// noteCreated records that a resource was created through the API, so list
+// responses can lag behind it. The caller holds s.mu.
+func (s *Server) noteCreated(kind, appID string, r Record) {
+	if s.listLag == 0 {
+		return
+	}
+	if s.born == nil {
+		s.born = map[string]time.Time{}
+	}
+	s.born[kind+"/"+appID+"/"+r["id"].(string)] = time.Now()
+}
+
+// listed returns the records in a sub-store that a list response shows,
+// leaving out those created within the list lag. The caller holds s.mu.
+func (s *Server) listed(kind, appID string, m map[string]Record) []Record {
+	out := values(m)
+	if len(s.born) == 0 {
+		return out
+	}
+	return slices.DeleteFunc(out, func(r Record) bool {
+		born, ok := s.born[kind+"/"+appID+"/"+r["id"].(string)]
+		return ok && time.Since(born) < s.listLag
+	})
+}
@@ -0,0 +1,146 @@
+package controltest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// retryingClient is a client that retries quickly, so fault bursts can be
+// ridden out without slowing the tests.
+func retryingClient(t *testing.T, fake *controltest.Server) *control.Client {
+	t.Helper()
+	t.Cleanup(fake.Close)
+	c := control.NewClient("any-token",
+		control.WithRetryMax(3),
+		control.WithRetryWaitMin(time.Millisecond),
+		control.WithRetryWaitMax(time.Millisecond))
+	c.BaseURL = fake.URL
+	return c
+}
+
+func TestFault_ServerErrorBurst(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := retryingClient(t, fake)
+	fake.InjectFault(http.MethodPost, "/accounts/*/apps", controltest.Fault{Status: http.StatusServiceUnavailable, Times: 2})
+
+	_, err := c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "chat"})
+	require.NoError(t, err, "the client rides out a burst shorter than its retries")
+
+	fake.InjectFault(http.MethodPost, "/accounts/*/apps", controltest.Fault{Status: http.StatusInternalServerError, Times: 4})
+	_, err = c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "events"})
+	var apiErr *control.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+
+	apps, err := c.ListApps(ctx, controltest.AccountID)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1, "a failed request changes nothing")
+}
+
+func TestFault_RateLimited(t *testing.T) {
+	fake := controltest.NewServer()
+	t.Cleanup(fake.Close)
+	remove := fake.InjectFault("", "/me", controltest.Fault{Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond})
+
+	resp, err := http.Get(fake.URL + "/me")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+
+	// Without Times the fault stays until it is removed.
+	resp, err = http.Get(fake.URL + "/me")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	remove()
+	resp, err = http.Get(fake.URL + "/me")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestFault_Delay(t *testing.T) {
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+	fake.InjectFault(http.MethodGet, "/me", controltest.Fault{Delay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Me(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFault_Drop(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+
+	fake.InjectFault(http.MethodPost, "/accounts/*/apps", controltest.Fault{Drop: true, Times: 1})
+	_, err := c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "chat"})
+	require.Error(t, err)
+	apps, err := c.ListApps(ctx, controltest.AccountID)
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+
+	// With Apply the create lands though its response is lost, so a client
+	// that retries it makes a second app.
+	c = retryingClient(t, fake)
+	fake.InjectFault(http.MethodPost, "/accounts/*/apps", controltest.Fault{Drop: true, Apply: true, Times: 1})
+	_, err = c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "chat"})
+	require.NoError(t, err)
+	apps, err = c.ListApps(ctx, controltest.AccountID)
+	require.NoError(t, err)
+	assert.Len(t, apps, 2)
+}
+
+func TestFault_OutOfBandDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+	app := fake.SeedApp(controltest.Record{"id": "app1", "name": "chat"})
+
+	fake.InjectFault(http.MethodPost, "/apps/app1/rules", controltest.Fault{
+		Times: 1,
+		Then:  func(state *controltest.State) { clear(state.Rules["app1"]) },
+	})
+	rule, err := c.CreateRule(ctx, app["id"].(string), control.HTTPRulePost{
+		RuleType:    "http",
+		RequestMode: "single",
+		Source:      control.RuleSource{ChannelFilter: "^chat", Type: "channel.message"},
+		Target:      control.HTTPRuleTarget{URL: "https://example.com/hook"},
+	})
+	require.NoError(t, err, "the create itself succeeds")
+
+	_, err = c.GetRule(ctx, "app1", rule.ID)
+	assert.True(t, isNotFound(err), "the rule is gone by the next read, got %v", err)
+}
+
+func TestServer_SetListLag(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+	fake.SeedNamespace("app1", controltest.Record{"id": "old"})
+	fake.SetListLag(100 * time.Millisecond)
+
+	_, err := c.CreateNamespace(ctx, "app1", control.NamespacePost{ID: "new"})
+	require.NoError(t, err)
+	namespaces, err := c.ListNamespaces(ctx, "app1")
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1, "a namespace just created is missing from the list")
+	assert.Equal(t, "old", namespaces[0].ID)
+
+	assert.Eventually(t, func() bool {
+		namespaces, err := c.ListNamespaces(ctx, "app1")
+		return err == nil && len(namespaces) == 2
+	}, time.Second, 10*time.Millisecond, "it shows once the lag has passed")
+}
@@ -0,0 +1,642 @@
+package controltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"message":    message,
+		"statusCode": status,
+		"code":       status * 100,
+		"href":       fmt.Sprintf("https://help.ably.io/error/%d", status*100),
+	})
+}
+
+func decodeBody(r *http.Request) Record {
+	m := Record{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&m)
+	}
+	return m
+}
+
+// --- /me -------------------------------------------------------------------
+
+func (s *Server) handleMe(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"account": map[string]any{"id": s.accountID, "name": "Fake Account"},
+		"user":    map[string]any{"id": 1, "email": "fake@ably.invalid"},
+		"token": map[string]any{
+			"id":           "fake",
+			"name":         "fake",
+			"capabilities": []string{},
+			"expires_at":   nil,
+			"last_used_at": nil,
+		},
+	})
+}
+
+// --- apps ------------------------------------------------------------------
+
+// appSecrets are the push credentials the real API accepts but never returns,
+// each with the flag it reports instead, if any. The fake stores them, so a
+// test can check what was sent, but leaves them out of its responses.
+var appSecrets = map[string]string{
+	"fcmKey":            "",
+	"fcmServiceAccount": "fcmServiceAccountConfigured",
+	"apnsCertificate":   "apnsCertificateConfigured",
+	"apnsPrivateKey":    "",
+	"apnsSigningKey":    "apnsSigningKeyConfigured",
+}
+
+// stampApp fills in the fields the server assigns to an app, and the defaults
+// for those the client left out. The caller holds s.mu.
+func (s *Server) stampApp(app Record) {
+	ts := now()
+	setDefault(app, "id", s.nextID("app"))
+	setDefault(app, "accountId", s.accountID)
+	setDefault(app, "status", "enabled")
+	setDefault(app, "tlsOnly", false)
+	setDefault(app, "apnsUseSandboxEndpoint", false)
+	setDefault(app, "created", ts)
+	setDefault(app, "modified", ts)
+	for _, flag := range appSecrets {
+		if flag != "" {
+			setDefault(app, flag, false)
+		}
+	}
+	flagAppSecrets(app)
+}
+
+// flagAppSecrets sets the flags for the push credentials app holds.
+func flagAppSecrets(app Record) {
+	for field, flag := range appSecrets {
+		if v, _ := app[field].(string); v != "" && flag != "" {
+			app[flag] = true
+		}
+	}
+}
+
+// appView is app as the API returns it, without its secrets.
+func appView(app Record) Record {
+	view := maps.Clone(app)
+	for field := range appSecrets {
+		delete(view, field)
+	}
+	return view
+}
+
+func (s *Server) createApp(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, field := range []string{"id", "accountId", "created", "modified"} {
+		delete(body, field)
+	}
+	s.stampApp(body)
+	s.state.Apps[body["id"].(string)] = body
+	s.noteCreated("apps", "", body)
+	writeJSON(w, http.StatusCreated, appView(body))
+}
+
+func (s *Server) listApps(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	apps := s.listed("apps", "", s.state.Apps)
+	for i, app := range apps {
+		apps[i] = appView(app)
+	}
+	writeJSON(w, http.StatusOK, apps)
+}
+
+func (s *Server) updateApp(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Apps[appID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "App not found")
+		return
+	}
+	maps.Copy(rec, body)
+	flagAppSecrets(rec)
+	rec["modified"] = now()
+	writeJSON(w, http.StatusOK, appView(rec))
+}
+
+func (s *Server) deleteApp(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Apps[appID]; !ok {
+		writeError(w, http.StatusNotFound, "App not found")
+		return
+	}
+	delete(s.state.Apps, appID)
+	delete(s.state.Keys, appID)
+	delete(s.state.Namespaces, appID)
+	delete(s.state.Queues, appID)
+	delete(s.state.Rules, appID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// uploadAppPKCS12 accepts a multipart APNs certificate upload. The bundle is
+// not parsed, only required to be present, and the app then reports a
+// certificate as configured.
+func (s *Server) uploadAppPKCS12(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	file, _, err := r.FormFile("p12File")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "p12File is required")
+		return
+	}
+	_ = file.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Apps[appID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "App not found")
+		return
+	}
+	rec["apnsCertificateConfigured"] = true
+	rec["modified"] = now()
+	writeJSON(w, http.StatusOK, appView(rec))
+}
+
+// --- keys ------------------------------------------------------------------
+
+// stampKey fills in the fields the server assigns to a key. The caller holds
+// s.mu.
+func (s *Server) stampKey(appID string, key Record) {
+	ts := now()
+	setDefault(key, "id", s.nextID("key"))
+	key["appId"] = appID
+	setDefault(key, "status", 0)
+	setDefault(key, "revocableTokens", false)
+	// The full key string is only ever returned by the create endpoint in the
+	// real API; the resource preserves it from state thereafter.
+	setDefault(key, "key", fmt.Sprintf("%s.%s:%s", appID, key["id"], s.nextID("secret")))
+	setDefault(key, "created", ts)
+	setDefault(key, "modified", ts)
+}
+
+func (s *Server) createKey(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, field := range []string{"id", "status", "key", "created", "modified"} {
+		delete(body, field)
+	}
+	s.stampKey(appID, body)
+	store(s.state.Keys, appID, body)
+	s.noteCreated("keys", appID, body)
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (s *Server) listKeys(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.listed("keys", appID, s.state.Keys[appID]))
+}
+
+func (s *Server) updateKey(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	keyID := r.PathValue("keyID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Keys[appID][keyID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Key not found")
+		return
+	}
+	maps.Copy(rec, body)
+	rec["modified"] = now()
+	writeJSON(w, http.StatusOK, rec)
+}
+
+func (s *Server) revokeKey(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	keyID := r.PathValue("keyID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Keys[appID][keyID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Key not found")
+		return
+	}
+	// Like Ably, keep a revoked key, listed with status 1. Revoking cannot be
+	// undone, so readers treat it as gone.
+	rec["status"] = 1
+	rec["modified"] = now()
+	w.WriteHeader(http.StatusOK)
+}
+
+// --- namespaces ------------------------------------------------------------
+
+// namespaceDefaults are the values the real API gives the namespace settings
+// a create leaves out, from the spec's namespace_post. It always returns them,
+// so omitting them would make a client record null, which then drifts on the
+// next read.
+var namespaceDefaults = Record{
+	"identified":              false,
+	"authenticated":           false,
+	"persisted":               false,
+	"persistLast":             false,
+	"pushEnabled":             false,
+	"tlsOnly":                 false,
+	"exposeTimeserial":        false,
+	"mutableMessages":         false,
+	"populateChannelRegistry": false,
+	"batchingEnabled":         false,
+	"batchingInterval":        20,
+	"conflationEnabled":       false,
+}
+
+// stampNamespace fills in the fields the server assigns to a namespace, and
+// the defaults for those the client left out. Its ID is client-supplied (the
+// channel namespace prefix).
+func stampNamespace(appID string, namespace Record) {
+	ts := now()
+	namespace["appId"] = appID
+	setDefault(namespace, "created", ts)
+	setDefault(namespace, "modified", ts)
+	if v, ok := namespace["authenticated"]; ok {
+		setDefault(namespace, "identified", v)
+	}
+	if v, ok := namespace["identified"]; ok {
+		setDefault(namespace, "authenticated", v)
+	}
+	for field, value := range namespaceDefaults {
+		setDefault(namespace, field, value)
+	}
+}
+
+// pairIdentified makes identified and its legacy alias authenticated agree in
+// a namespace body, as the real API does, setting whichever the body leaves
+// out to the other. It returns an error message if the body sets both to
+// different values.
+func pairIdentified(body Record) string {
+	identified, hasIdentified := body["identified"]
+	authenticated, hasAuthenticated := body["authenticated"]
+	switch {
+	case hasIdentified && hasAuthenticated && identified != authenticated:
+		return "identified and authenticated are the same setting and cannot differ"
+	case hasIdentified:
+		body["authenticated"] = identified
+	case hasAuthenticated:
+		body["identified"] = authenticated
+	}
+	return ""
+}
+
+func (s *Server) createNamespace(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := body["id"].(string)
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+	if _, ok := s.state.Namespaces[appID][id]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Namespace %s already exists", id))
+		return
+	}
+	if msg := pairIdentified(body); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	delete(body, "created")
+	delete(body, "modified")
+	stampNamespace(appID, body)
+	store(s.state.Namespaces, appID, body)
+	s.noteCreated("namespaces", appID, body)
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (s *Server) listNamespaces(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.listed("namespaces", appID, s.state.Namespaces[appID]))
+}
+
+func (s *Server) updateNamespace(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	nsID := r.PathValue("nsID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Namespaces[appID][nsID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Namespace not found")
+		return
+	}
+	if msg := pairIdentified(body); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	maps.Copy(rec, body)
+	rec["modified"] = now()
+	writeJSON(w, http.StatusOK, rec)
+}
+
+func (s *Server) deleteNamespace(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	nsID := r.PathValue("nsID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Namespaces[appID][nsID]; !ok {
+		writeError(w, http.StatusNotFound, "Namespace not found")
+		return
+	}
+	delete(s.state.Namespaces[appID], nsID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- queues ----------------------------------------------------------------
+
+// stampQueue fills in the fields the server assigns to a queue. The caller
+// holds s.mu.
+func (s *Server) stampQueue(appID string, queue Record) {
+	setDefault(queue, "id", s.nextID("queue"))
+	name, _ := queue["name"].(string)
+	queue["appId"] = appID
+	setDefault(queue, "state", "running")
+	setDefault(queue, "deadletter", false)
+	setDefault(queue, "amqp", map[string]any{
+		"uri":       "amqps://fake.ably.invalid",
+		"queueName": fmt.Sprintf("%s:%s", appID, name),
+	})
+	setDefault(queue, "stomp", map[string]any{
+		"uri":         "stomp://fake.ably.invalid",
+		"host":        "shared.ably.invalid",
+		"destination": fmt.Sprintf("/amq/queue/%s:%s", appID, name),
+	})
+	setDefault(queue, "messages", map[string]any{})
+	setDefault(queue, "stats", map[string]any{})
+}
+
+// addQueue stores a new queue built from body, as the create endpoint does.
+// The caller holds s.mu.
+func (s *Server) addQueue(appID string, body Record) Record {
+	for _, field := range []string{"id", "state", "amqp", "stomp", "messages", "stats", "deadletterId"} {
+		delete(body, field)
+	}
+	s.stampQueue(appID, body)
+	store(s.state.Queues, appID, body)
+	s.noteCreated("queues", appID, body)
+	return body
+}
+
+func (s *Server) createQueue(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(body, "deadletter")
+	// Queue names are unique within an app: the AMQP queue is named after
+	// the app and the queue.
+	for _, q := range s.state.Queues[appID] {
+		if q["name"] == body["name"] {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Queue %v already exists", body["name"]))
+			return
+		}
+	}
+	body = s.addQueue(appID, body)
+	// Like Ably, keep one dead letter queue per app, created with its first
+	// queue.
+	deadletterID := ""
+	for id, q := range s.state.Queues[appID] {
+		if q["deadletter"] == true {
+			deadletterID = id
+		}
+	}
+	if deadletterID == "" {
+		deadletterID = s.addQueue(appID, Record{
+			"name":       "deadletter",
+			"ttl":        body["ttl"],
+			"maxLength":  body["maxLength"],
+			"region":     body["region"],
+			"deadletter": true,
+		})["id"].(string)
+	}
+	body["deadletterId"] = deadletterID
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (s *Server) listQueues(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.listed("queues", appID, s.state.Queues[appID]))
+}
+
+func (s *Server) deleteQueue(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	queueID := r.PathValue("queueID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Queues[appID][queueID]; !ok {
+		writeError(w, http.StatusNotFound, "Queue not found")
+		return
+	}
+	delete(s.state.Queues[appID], queueID)
+	// The dead letter queue goes with the app's last queue.
+	if !slices.ContainsFunc(values(s.state.Queues[appID]), func(q Record) bool { return q["deadletter"] != true }) {
+		clear(s.state.Queues[appID])
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- rules -----------------------------------------------------------------
+//
+// All rule variants (http, aws/*, moderation, ingress, ...) share these
+// handlers. The fake stores the posted body verbatim and echoes it, so the
+// polymorphic target round-trips without the fake needing to know the rule
+// type.
+
+// stampRule fills in the fields the server assigns to a rule. The caller
+// holds s.mu.
+func (s *Server) stampRule(appID string, rule Record) {
+	ts := now()
+	setDefault(rule, "id", s.nextID("rule"))
+	rule["appId"] = appID
+	setDefault(rule, "version", "1")
+	setDefault(rule, "created", ts)
+	setDefault(rule, "modified", ts)
+	setDefault(rule, "status", "enabled")
+	defaultRuleFormat(rule)
+}
+
+// defaultRuleFormat mirrors the real API, where HTTP-family rule targets
+// default their message format to "json" when not specified, so computed
+// `target.format` attributes are populated.
+func defaultRuleFormat(rule Record) {
+	if t, ok := rule["target"].(map[string]any); ok {
+		setDefault(t, "format", "json")
+	}
+}
+
+// ruleSecrets are the target fields, as paths from the target, that the real
+// API accepts for each rule type but never returns. The fake stores them, so
+// a test can check what was sent, but leaves them out of its responses.
+// Bodyguard is missing on purpose: its API key was seen returned by the live
+// API.
+var ruleSecrets = map[string][][]string{
+	"aws/kinesis":               {{"authentication", "secretAccessKey"}},
+	"aws/sqs":                   {{"authentication", "secretAccessKey"}},
+	"aws/lambda":                {{"authentication", "secretAccessKey"}},
+	"aws/lambda/before-publish": {{"authentication", "secretAccessKey"}},
+	"kafka":                     {{"auth", "sasl", "password"}},
+	"pulsar":                    {{"tlsTrustCerts"}},
+	"hive/text-model-only":      {{"apiKey"}},
+	"hive/dashboard":            {{"apiKey"}},
+	"tisane/text-moderation":    {{"apiKey"}},
+	"azure/text-moderation":     {{"apiKey"}},
+}
+
+// ruleView is rule as the API returns it, without its secrets.
+func ruleView(rule Record) Record {
+	secrets := ruleSecrets[fmt.Sprint(rule["ruleType"])]
+	if len(secrets) == 0 {
+		return rule
+	}
+	view := deepClone(rule).(Record)
+	for _, secret := range secrets {
+		obj, _ := view["target"].(map[string]any)
+		for _, field := range secret[:len(secret)-1] {
+			obj, _ = obj[field].(map[string]any)
+		}
+		delete(obj, secret[len(secret)-1])
+	}
+	return view
+}
+
+// deepClone copies decoded JSON, so a view can be cut down without changing
+// the stored record.
+func deepClone(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = deepClone(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = deepClone(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (s *Server) createRule(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, field := range []string{"id", "version", "created", "modified"} {
+		delete(body, field)
+	}
+	s.stampRule(appID, body)
+	store(s.state.Rules, appID, body)
+	s.noteCreated("rules", appID, body)
+	writeJSON(w, http.StatusCreated, ruleView(body))
+}
+
+func (s *Server) getRule(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	ruleID := r.PathValue("ruleID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Rules[appID][ruleID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Rule not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ruleView(rec))
+}
+
+func (s *Server) listRules(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules := s.listed("rules", appID, s.state.Rules[appID])
+	for i, rule := range rules {
+		rules[i] = ruleView(rule)
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) updateRule(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	ruleID := r.PathValue("ruleID")
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.Rules[appID][ruleID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Rule not found")
+		return
+	}
+	maps.Copy(rec, body)
+	defaultRuleFormat(rec)
+	rec["modified"] = now()
+	writeJSON(w, http.StatusOK, ruleView(rec))
+}
+
+func (s *Server) deleteRule(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appID")
+	ruleID := r.PathValue("ruleID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.state.Rules[appID][ruleID]; !ok {
+		writeError(w, http.StatusNotFound, "Rule not found")
+		return
+	}
+	delete(s.state.Rules[appID], ruleID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- misc ------------------------------------------------------------------
+
+func (s *Server) emptyArray(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, []any{})
+}
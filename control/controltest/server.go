@@ -0,0 +1,396 @@
+// Package controltest provides an in-process, stateful fake of the Ably
+// Control API, so code built on the control package, and Terraform
+// configurations using the provider, can be tested with no credentials and no
+// network access.
+//
+//	fake := controltest.NewServer()
+//	defer fake.Close()
+//	app := fake.SeedApp(controltest.Record{"name": "Chat"})
+//
+//	client := control.NewClient("any-token")
+//	client.BaseURL = fake.URL
+//	apps, err := client.ListApps(ctx, controltest.AccountID)
+//
+// The fake stores whatever JSON body it is sent, stamps the fields the server
+// assigns (id, appId, created, modified and so on), fills in the defaults the
+// real API gives fields left out, and echoes the record back on read. It keeps
+// the real API's business rules where clients depend on them: revoked keys
+// stay listed with status 1, namespace IDs and queue names are unique within
+// an app, a namespace's identified and authenticated settings are one, and
+// write-only secrets such as push credentials, AWS secret keys and SASL
+// passwords are stored but never returned. It does not validate
+// rule-type-specific fields, so it shows that a client is internally
+// consistent, not that it matches production. Any token is accepted.
+//
+// The fake never fails on its own. [Server.InjectFault] and
+// [Server.SetListLag] make it fail chosen requests, slowly or partially, so
+// that a client's error handling can be tested too.
+package controltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AccountID is the ID of the single account the fake serves unless
+// [WithAccountID] names another. GET /me reports it, and apps are created in it
+// whatever account the request names.
+const AccountID = "fakeAccId"
+
+// Record is a single resource, stored as decoded JSON.
+type Record = map[string]any
+
+// State is everything the fake holds, scoped by app so that tests each
+// working in their own app do not interfere with one another.
+type State struct {
+	Apps       map[string]Record            `json:"apps"`       // app ID -> app
+	Keys       map[string]map[string]Record `json:"keys"`       // app ID -> key ID -> key
+	Namespaces map[string]map[string]Record `json:"namespaces"` // app ID -> namespace ID -> namespace
+	Queues     map[string]map[string]Record `json:"queues"`     // app ID -> queue ID -> queue
+	Rules      map[string]map[string]Record `json:"rules"`      // app ID -> rule ID -> rule
+}
+
+// emptyState returns a State with every map allocated.
+func emptyState() State {
+	return State{
+		Apps:       map[string]Record{},
+		Keys:       map[string]map[string]Record{},
+		Namespaces: map[string]map[string]Record{},
+		Queues:     map[string]map[string]Record{},
+		Rules:      map[string]map[string]Record{},
+	}
+}
+
+// Server is a running fake Control API. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL to give a control.Client, with no trailing slash.
+	// It is empty for a fake made by [NewHandler].
+	URL string
+
+	server    *httptest.Server
+	handler   http.Handler
+	log       io.Writer
+	accountID string
+	token     string
+	spec      *Spec
+
+	mu      sync.Mutex
+	seq     int64
+	state   State
+	faults  []*faultRule
+	listLag time.Duration
+	born    map[string]time.Time // kind/appID/ID -> when created, while there is a list lag
+}
+
+// Option configures a [Server].
+type Option func(*Server)
+
+// WithLog writes a line to w for every request the server handles, with the
+// response status and body.
+func WithLog(w io.Writer) Option {
+	return func(s *Server) {
+		s.log = w
+	}
+}
+
+// WithAccountID serves the account id rather than [AccountID].
+func WithAccountID(id string) Option {
+	return func(s *Server) {
+		s.accountID = id
+	}
+}
+
+// WithToken rejects requests that do not carry token as their bearer token,
+// as the real API rejects a bad one. By default any token is accepted.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithSpec turns on strict mode: every request body is validated against
+// spec, and one with unknown fields, missing required ones or values of the
+// wrong type is refused with a 400 listing the problems, as the real API
+// refuses it. Rule bodies are checked against the rule type their ruleType
+// names. Requests for paths the spec does not have are refused with a 404.
+//
+// Responses are cut down to the fields spec says the real API returns, so a
+// client relying on one the real API never sends fails here too.
+func WithSpec(spec *Spec) Option {
+	return func(s *Server) {
+		s.spec = spec
+	}
+}
+
+// NewServer starts a fake Control API with no apps in it. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := NewHandler(opts...)
+	s.server = httptest.NewServer(s.handler)
+	s.URL = s.server.URL
+	return s
+}
+
+// NewHandler returns a fake Control API with no apps in it that is not
+// listening, for serving through ServeHTTP from an [http.Server] of your own.
+func NewHandler(opts ...Option) *Server {
+	s := &Server{
+		accountID: AccountID,
+		state:     emptyState(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /me", s.handleMe)
+
+	mux.HandleFunc("GET /accounts/{accountID}/apps", s.listApps)
+	mux.HandleFunc("POST /accounts/{accountID}/apps", s.createApp)
+	mux.HandleFunc("PATCH /apps/{appID}", s.updateApp)
+	mux.HandleFunc("DELETE /apps/{appID}", s.deleteApp)
+	mux.HandleFunc("POST /apps/{appID}/pkcs12", s.uploadAppPKCS12)
+
+	mux.HandleFunc("GET /apps/{appID}/keys", s.listKeys)
+	mux.HandleFunc("POST /apps/{appID}/keys", s.createKey)
+	mux.HandleFunc("PATCH /apps/{appID}/keys/{keyID}", s.updateKey)
+	mux.HandleFunc("POST /apps/{appID}/keys/{keyID}/revoke", s.revokeKey)
+
+	mux.HandleFunc("GET /apps/{appID}/namespaces", s.listNamespaces)
+	mux.HandleFunc("POST /apps/{appID}/namespaces", s.createNamespace)
+	mux.HandleFunc("PATCH /apps/{appID}/namespaces/{nsID}", s.updateNamespace)
+	mux.HandleFunc("DELETE /apps/{appID}/namespaces/{nsID}", s.deleteNamespace)
+
+	mux.HandleFunc("GET /apps/{appID}/queues", s.listQueues)
+	mux.HandleFunc("POST /apps/{appID}/queues", s.createQueue)
+	mux.HandleFunc("DELETE /apps/{appID}/queues/{queueID}", s.deleteQueue)
+
+	mux.HandleFunc("GET /apps/{appID}/rules", s.listRules)
+	mux.HandleFunc("POST /apps/{appID}/rules", s.createRule)
+	mux.HandleFunc("GET /apps/{appID}/rules/{ruleID}", s.getRule)
+	mux.HandleFunc("PATCH /apps/{appID}/rules/{ruleID}", s.updateRule)
+	mux.HandleFunc("DELETE /apps/{appID}/rules/{ruleID}", s.deleteRule)
+
+	// Stats are not modelled. Return them empty so they never 404.
+	mux.HandleFunc("GET /apps/{appID}/stats", s.emptyArray)
+	mux.HandleFunc("GET /accounts/{accountID}/stats", s.emptyArray)
+
+	s.handler = mux
+	if s.spec != nil {
+		s.handler = s.strict(s.handler)
+	}
+	s.handler = s.injectFaults(s.handler)
+	if s.token != "" {
+		next := s.handler
+		s.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+s.token {
+				writeError(w, http.StatusUnauthorized, "Invalid token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	if s.log != nil {
+		next := s.handler
+		s.handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &logRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			fmt.Fprintf(s.log, "[controltest] %s %s -> %d %s\n", r.Method, r.URL.Path, rec.status, strings.TrimSpace(rec.body.String()))
+		})
+	}
+	return s
+}
+
+// Close shuts the server down. It does nothing for a fake made by [NewHandler].
+func (s *Server) Close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// ServeHTTP serves a Control API request, so a test can wrap the fake in its
+// own handler, for instance to fail chosen requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Inspect calls fn with the fake's state while holding its lock, so fn may
+// read or change it. fn must not call the server.
+func (s *Server) Inspect(fn func(state *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.state)
+}
+
+// Reset deletes every app and everything in them. Injected faults and the
+// list lag are kept.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = emptyState()
+	s.born = nil
+}
+
+// snapshot is the form Save writes. It carries the ID sequence along with the
+// state, so a fake loaded from it never hands out an ID already in use.
+type snapshot struct {
+	Seq int64 `json:"seq"`
+	State
+}
+
+// Save writes the fake's state to w as JSON, for Load to read back.
+func (s *Server) Save(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot{Seq: atomic.LoadInt64(&s.seq), State: s.state})
+}
+
+// Load replaces the fake's state with one Save wrote.
+func (s *Server) Load(r io.Reader) error {
+	snap := snapshot{State: emptyState()}
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("controltest: reading saved state: %w", err)
+	}
+	for _, m := range []*map[string]map[string]Record{&snap.Keys, &snap.Namespaces, &snap.Queues, &snap.Rules} {
+		if *m == nil {
+			*m = map[string]map[string]Record{}
+		}
+	}
+	if snap.Apps == nil {
+		snap.Apps = map[string]Record{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = snap.State
+	atomic.StoreInt64(&s.seq, snap.Seq)
+	return nil
+}
+
+type logRecorder struct {
+	http.ResponseWriter
+	status int
+	body   strings.Builder
+}
+
+func (l *logRecorder) WriteHeader(status int) {
+	l.status = status
+	l.ResponseWriter.WriteHeader(status)
+}
+
+func (l *logRecorder) Write(b []byte) (int, error) {
+	l.body.Write(b)
+	return l.ResponseWriter.Write(b)
+}
+
+// --- seeding ---------------------------------------------------------------
+//
+// The Seed methods add a resource directly, as if it had been created earlier.
+// Fields the server would assign are filled in where the record leaves them
+// out, and an id the record gives is kept, so tests can use readable IDs. Each
+// returns a copy of the stored record.
+
+// SeedApp adds an app.
+func (s *Server) SeedApp(app Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app = maps.Clone(app)
+	s.stampApp(app)
+	s.state.Apps[app["id"].(string)] = app
+	return maps.Clone(app)
+}
+
+// SeedKey adds an API key to the app appID.
+func (s *Server) SeedKey(appID string, key Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key = maps.Clone(key)
+	s.stampKey(appID, key)
+	store(s.state.Keys, appID, key)
+	return maps.Clone(key)
+}
+
+// SeedNamespace adds a namespace to the app appID. Namespace IDs are chosen
+// by the client, so the record must have one.
+func (s *Server) SeedNamespace(appID string, namespace Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	namespace = maps.Clone(namespace)
+	if _, ok := namespace["id"].(string); !ok {
+		panic("controltest: SeedNamespace needs an id")
+	}
+	stampNamespace(appID, namespace)
+	store(s.state.Namespaces, appID, namespace)
+	return maps.Clone(namespace)
+}
+
+// SeedQueue adds a queue to the app appID. Unlike creating one through the
+// API, seeding a queue does not create the app's dead letter queue; seed that
+// too, with "deadletter": true, if the test needs one.
+func (s *Server) SeedQueue(appID string, queue Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue = maps.Clone(queue)
+	s.stampQueue(appID, queue)
+	store(s.state.Queues, appID, queue)
+	return maps.Clone(queue)
+}
+
+// SeedRule adds a rule of any type to the app appID.
+func (s *Server) SeedRule(appID string, rule Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule = maps.Clone(rule)
+	s.stampRule(appID, rule)
+	store(s.state.Rules, appID, rule)
+	return maps.Clone(rule)
+}
+
+// --- helpers ---------------------------------------------------------------
+
+func (s *Server) nextID(prefix string) string {
+	n := atomic.AddInt64(&s.seq, 1)
+	return fmt.Sprintf("%s%05d", prefix, n)
+}
+
+func now() int64 { return time.Now().UnixMilli() }
+
+// setDefault sets r[key] to value unless r already has key.
+func setDefault(r Record, key string, value any) {
+	if _, ok := r[key]; !ok {
+		r[key] = value
+	}
+}
+
+// store adds r to the app-scoped sub-store m under its id.
+func store(m map[string]map[string]Record, appID string, r Record) {
+	if m[appID] == nil {
+		m[appID] = map[string]Record{}
+	}
+	m[appID][r["id"].(string)] = r
+}
+
+// values returns the records in a sub-store as a slice, for list endpoints.
+func values(m map[string]Record) []Record {
+	out := make([]Record, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,296 @@
+package controltest_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, fake *controltest.Server) *control.Client {
+	t.Helper()
+	t.Cleanup(fake.Close)
+	c := control.NewClient("any-token", control.WithRetryMax(0))
+	c.BaseURL = fake.URL
+	return c
+}
+
+func isNotFound(err error) bool {
+	var apiErr *control.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func TestServer_CRUD(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, controltest.NewServer())
+
+	me, err := c.Me(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, controltest.AccountID, me.Account.ID)
+
+	app, err := c.CreateApp(ctx, me.Account.ID, control.AppPost{Name: "chat"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, app.ID)
+	assert.Equal(t, controltest.AccountID, app.AccountID)
+
+	app, err = c.UpdateApp(ctx, app.ID, control.AppPatch{Name: "chat-v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "chat-v2", app.Name)
+
+	key, err := c.CreateKey(ctx, app.ID, control.KeyPost{Name: "server", Capability: map[string][]string{"*": {"publish"}}})
+	require.NoError(t, err)
+	assert.Contains(t, key.Key, app.ID+"."+key.ID+":")
+	require.NoError(t, c.RevokeKey(ctx, app.ID, key.ID))
+	keys, err := c.ListKeys(ctx, app.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1, "a revoked key is still listed")
+	assert.Equal(t, 1, keys[0].Status)
+
+	ns, err := c.CreateNamespace(ctx, app.ID, control.NamespacePost{ID: "rooms"})
+	require.NoError(t, err)
+	assert.Equal(t, "rooms", ns.ID)
+
+	queue, err := c.CreateQueue(ctx, app.ID, control.Queue{Name: "orders", TTL: 60, MaxLength: 100, Region: "us-east-1-a"})
+	require.NoError(t, err)
+	require.NotNil(t, queue.DeadletterID)
+	queues, err := c.ListQueues(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Len(t, queues, 2, "the first queue brings the app's dead letter queue")
+	require.NoError(t, c.DeleteQueue(ctx, app.ID, queue.ID))
+	queues, err = c.ListQueues(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Empty(t, queues, "the dead letter queue goes with the last queue")
+
+	rule, err := c.CreateRule(ctx, app.ID, control.HTTPRulePost{
+		RuleType:    "http",
+		RequestMode: "single",
+		Source:      control.RuleSource{ChannelFilter: "^chat", Type: "channel.message"},
+		Target:      control.HTTPRuleTarget{URL: "https://example.com/hook"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "enabled", rule.Status)
+
+	require.NoError(t, c.DeleteApp(ctx, app.ID))
+	_, err = c.GetRule(ctx, app.ID, rule.ID)
+	assert.True(t, isNotFound(err), "deleting an app deletes its rules, got %v", err)
+}
+
+func TestServer_Seed(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+
+	app := fake.SeedApp(controltest.Record{"id": "app1", "name": "chat"})
+	assert.Equal(t, "app1", app["id"])
+	fake.SeedKey("app1", controltest.Record{"id": "key1", "name": "server", "status": 1})
+	fake.SeedNamespace("app1", controltest.Record{"id": "rooms"})
+	fake.SeedQueue("app1", controltest.Record{"id": "q1", "name": "orders", "messages": map[string]any{"ready": 3}})
+	fake.SeedRule("app1", controltest.Record{"id": "r1", "ruleType": "http", "target": map[string]any{"url": "https://example.com"}})
+
+	apps, err := c.ListApps(ctx, controltest.AccountID)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "chat", apps[0].Name)
+
+	keys, err := c.ListKeys(ctx, "app1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, 1, keys[0].Status)
+
+	namespaces, err := c.ListNamespaces(ctx, "app1")
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+
+	queues, err := c.ListQueues(ctx, "app1")
+	require.NoError(t, err)
+	require.Len(t, queues, 1, "seeding a queue does not add a dead letter queue")
+	assert.Equal(t, 3, *queues[0].Messages.Ready)
+	assert.Equal(t, "running", queues[0].State)
+
+	rule, err := c.GetRule(ctx, "app1", "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "json", rule.Target.(map[string]any)["format"])
+
+	fake.Inspect(func(state *controltest.State) {
+		state.Queues["app1"]["q1"]["state"] = "stopped"
+	})
+	queues, err = c.ListQueues(ctx, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, "stopped", queues[0].State)
+}
+
+func TestServer_NotFound(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, controltest.NewServer())
+
+	_, err := c.UpdateApp(ctx, "missing", control.AppPatch{Name: "x"})
+	assert.True(t, isNotFound(err), "got %v", err)
+	assert.True(t, isNotFound(c.DeleteNamespace(ctx, "missing", "ns")))
+	assert.True(t, isNotFound(c.DeleteQueue(ctx, "missing", "q")))
+}
+
+func TestServer_WithLog(t *testing.T) {
+	var log bytes.Buffer
+	c := newClient(t, controltest.NewServer(controltest.WithLog(&log)))
+
+	_, err := c.Me(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, log.String(), "GET /me -> 200")
+}
+
+func TestServer_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer(controltest.WithAccountID("acc1"))
+	c := newClient(t, fake)
+
+	app, err := c.CreateApp(ctx, "acc1", control.AppPost{Name: "chat"})
+	require.NoError(t, err)
+	assert.Equal(t, "acc1", app.AccountID)
+	_, err = c.CreateQueue(ctx, app.ID, control.Queue{Name: "orders", TTL: 60, MaxLength: 100, Region: "us-east-1-a"})
+	require.NoError(t, err)
+
+	var saved bytes.Buffer
+	require.NoError(t, fake.Save(&saved))
+
+	restored := controltest.NewServer()
+	c = newClient(t, restored)
+	require.NoError(t, restored.Load(&saved))
+	queues, err := c.ListQueues(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Len(t, queues, 2)
+
+	// IDs carry on from where the saved fake left off.
+	second, err := c.CreateApp(ctx, "acc1", control.AppPost{Name: "events"})
+	require.NoError(t, err)
+	assert.NotEqual(t, app.ID, second.ID)
+
+	restored.Reset()
+	apps, err := c.ListApps(ctx, "acc1")
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+}
+
+func TestServer_WithToken(t *testing.T) {
+	fake := controltest.NewServer(controltest.WithToken("secret"))
+	t.Cleanup(fake.Close)
+
+	for token, wantOK := range map[string]bool{"secret": true, "wrong": false} {
+		c := control.NewClient(token, control.WithRetryMax(0))
+		c.BaseURL = fake.URL
+		_, err := c.Me(context.Background())
+		if wantOK {
+			assert.NoError(t, err)
+			continue
+		}
+		var apiErr *control.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	}
+}
+
+func TestServer_Uniqueness(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, controltest.NewServer())
+
+	_, err := c.CreateNamespace(ctx, "app1", control.NamespacePost{ID: "rooms"})
+	require.NoError(t, err)
+	_, err = c.CreateNamespace(ctx, "app1", control.NamespacePost{ID: "rooms"})
+	var apiErr *control.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	_, err = c.CreateNamespace(ctx, "app2", control.NamespacePost{ID: "rooms"})
+	assert.NoError(t, err, "namespace IDs are unique per app")
+
+	orders := control.Queue{Name: "orders", TTL: 60, MaxLength: 100, Region: "us-east-1-a"}
+	_, err = c.CreateQueue(ctx, "app1", orders)
+	require.NoError(t, err)
+	_, err = c.CreateQueue(ctx, "app1", orders)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	_, err = c.CreateQueue(ctx, "app2", orders)
+	assert.NoError(t, err, "queue names are unique per app")
+}
+
+func TestServer_Defaults(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, controltest.NewServer())
+
+	app, err := c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "chat"})
+	require.NoError(t, err)
+	assert.Equal(t, "enabled", app.Status)
+
+	ns, err := c.CreateNamespace(ctx, app.ID, control.NamespacePost{ID: "rooms", BatchingEnabled: ptr(true)})
+	require.NoError(t, err)
+	require.NotNil(t, ns.BatchingInterval)
+	assert.Equal(t, 20, *ns.BatchingInterval)
+	require.NotNil(t, ns.ConflationEnabled)
+	assert.False(t, *ns.ConflationEnabled)
+
+	key, err := c.CreateKey(ctx, app.ID, control.KeyPost{Name: "server", Capability: map[string][]string{"*": {"publish"}}})
+	require.NoError(t, err)
+	require.NotNil(t, key.RevocableTokens)
+	assert.False(t, *key.RevocableTokens)
+}
+
+func TestServer_IdentifiedAlias(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, controltest.NewServer())
+
+	ns, err := c.CreateNamespace(ctx, "app1", control.NamespacePost{ID: "rooms", Authenticated: ptr(true)})
+	require.NoError(t, err)
+	require.NotNil(t, ns.Identified)
+	assert.True(t, *ns.Identified, "setting the alias sets identified")
+
+	ns, err = c.UpdateNamespace(ctx, "app1", "rooms", control.NamespacePatch{Identified: ptr(false)})
+	require.NoError(t, err)
+	assert.False(t, ns.Authenticated, "setting identified sets the alias")
+
+	_, err = c.UpdateNamespace(ctx, "app1", "rooms", control.NamespacePatch{Identified: ptr(true), Authenticated: ptr(false)})
+	var apiErr *control.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestServer_WriteOnlySecrets(t *testing.T) {
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	c := newClient(t, fake)
+
+	app, err := c.CreateApp(ctx, controltest.AccountID, control.AppPost{Name: "chat", FCMServiceAccount: ptr(`{"type":"service_account"}`)})
+	require.NoError(t, err)
+	require.NotNil(t, app.FCMServiceAccountConfigured)
+	assert.True(t, *app.FCMServiceAccountConfigured)
+
+	rule, err := c.CreateRule(ctx, app.ID, control.KafkaRulePost{
+		RuleType:    "kafka",
+		RequestMode: "single",
+		Source:      control.RuleSource{ChannelFilter: "^chat", Type: "channel.message"},
+		Target: control.KafkaRuleTarget{
+			RoutingKey: "topic:key",
+			Brokers:    []string{"kafka.example.com:9092"},
+			Auth:       &control.KafkaAuth{SASL: &control.KafkaSASL{Mechanism: "scram-sha-256", Username: "user", Password: "secret"}},
+		},
+	})
+	require.NoError(t, err)
+	read, err := c.GetRule(ctx, app.ID, rule.ID)
+	require.NoError(t, err)
+	for _, got := range []any{rule.Target, read.Target} {
+		sasl := got.(map[string]any)["auth"].(map[string]any)["sasl"].(map[string]any)
+		assert.Equal(t, "user", sasl["username"])
+		assert.NotContains(t, sasl, "password")
+	}
+
+	fake.Inspect(func(state *controltest.State) {
+		assert.NotEmpty(t, state.Apps[app.ID]["fcmServiceAccount"], "secrets are stored though not returned")
+		sasl := state.Rules[app.ID][rule.ID]["target"].(map[string]any)["auth"].(map[string]any)["sasl"].(map[string]any)
+		assert.Equal(t, "secret", sasl["password"])
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
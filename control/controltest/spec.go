@@ -0,0 +1,541 @@
+package controltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Spec is a Control API OpenAPI document, parsed for [WithSpec] to validate
+// requests and responses against. It understands the parts of OpenAPI 3.0 the
+// Control API spec uses: types, properties, required, additionalProperties,
+// enum, nullable, minimum, maximum, pattern, items, $ref and oneOf with a
+// discriminator.
+type Spec struct {
+	schemas    map[string]*schema
+	operations []operation
+}
+
+// operation is one method on one path, with the JSON bodies it takes and
+// returns.
+type operation struct {
+	method    string
+	segments  []string // "{...}" matches any one segment
+	request   *schema
+	responses map[int]*schema
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Enum                 []any              `json:"enum"`
+	Nullable             bool               `json:"nullable"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Pattern              string             `json:"pattern"`
+	Items                *schema            `json:"items"`
+	OneOf                []*schema          `json:"oneOf"`
+	Discriminator        *struct {
+		PropertyName string            `json:"propertyName"`
+		Mapping      map[string]string `json:"mapping"`
+	} `json:"discriminator"`
+
+	pattern *regexp.Regexp
+}
+
+// additional is an additionalProperties value, which is either a boolean or a
+// schema the extra properties must match.
+type additional struct {
+	allowed bool
+	schema  *schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// closed reports whether s rejects properties it does not list. OpenAPI allows
+// them unless additionalProperties says otherwise.
+func (s *schema) closed() bool {
+	return s.AdditionalProperties != nil && !s.AdditionalProperties.allowed
+}
+
+type mediaTypes map[string]struct {
+	Schema *schema `json:"schema"`
+}
+
+// ParseSpec parses an OpenAPI document in JSON. The vendored spec is YAML; the
+// provider module converts it, which keeps a YAML parser out of this module's
+// dependencies.
+func ParseSpec(data []byte) (*Spec, error) {
+	var doc struct {
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]*schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("controltest: parsing spec: %w", err)
+	}
+
+	spec := &Spec{schemas: doc.Components.Schemas}
+	for path, item := range doc.Paths {
+		for method, raw := range item {
+			method = strings.ToUpper(method)
+			if !slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}, method) {
+				continue
+			}
+			var op struct {
+				RequestBody struct {
+					Content mediaTypes `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content mediaTypes `json:"content"`
+				} `json:"responses"`
+			}
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("controltest: parsing spec: %s %s: %w", method, path, err)
+			}
+			o := operation{
+				method:    method,
+				segments:  strings.Split(strings.Trim(path, "/"), "/"),
+				request:   op.RequestBody.Content["application/json"].Schema,
+				responses: map[int]*schema{},
+			}
+			for code, response := range op.Responses {
+				status, err := strconv.Atoi(code)
+				if err != nil {
+					continue
+				}
+				if s := response.Content["application/json"].Schema; s != nil {
+					o.responses[status] = s
+				}
+			}
+			spec.operations = append(spec.operations, o)
+		}
+	}
+
+	// Resolve every reference and compile every pattern up front, so a broken
+	// spec fails here rather than on the request that first reaches it.
+	seen := map[*schema]bool{}
+	var prepare func(s *schema) error
+	prepare = func(s *schema) error {
+		if s == nil || seen[s] {
+			return nil
+		}
+		seen[s] = true
+		if s.Ref != "" {
+			if _, err := spec.resolve(s); err != nil {
+				return err
+			}
+		}
+		if s.Pattern != "" {
+			// Patterns are ECMA-262; one RE2 cannot compile goes unchecked.
+			s.pattern, _ = regexp.Compile(s.Pattern)
+		}
+		if s.Discriminator != nil {
+			for _, ref := range s.Discriminator.Mapping {
+				if _, err := spec.resolve(&schema{Ref: ref}); err != nil {
+					return err
+				}
+			}
+		}
+		children := append(slices.Collect(maps.Values(s.Properties)), s.OneOf...)
+		children = append(children, s.Items)
+		if s.AdditionalProperties != nil {
+			children = append(children, s.AdditionalProperties.schema)
+		}
+		for _, child := range children {
+			if err := prepare(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, s := range spec.schemas {
+		if err := prepare(s); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range spec.operations {
+		if err := prepare(o.request); err != nil {
+			return nil, err
+		}
+		for _, s := range o.responses {
+			if err := prepare(s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Literal segments win over parameters, so the most specific path matches.
+	sort.SliceStable(spec.operations, func(i, j int) bool {
+		return params(spec.operations[i].segments) < params(spec.operations[j].segments)
+	})
+	return spec, nil
+}
+
+func params(segments []string) int {
+	n := 0
+	for _, s := range segments {
+		if strings.HasPrefix(s, "{") {
+			n++
+		}
+	}
+	return n
+}
+
+// operation returns the operation serving method and path, or nil.
+func (sp *Spec) operation(method, path string) *operation {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range sp.operations {
+		o := &sp.operations[i]
+		if o.method != method || len(o.segments) != len(segments) {
+			continue
+		}
+		match := true
+		for k, seg := range o.segments {
+			if !strings.HasPrefix(seg, "{") && seg != segments[k] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return o
+		}
+	}
+	return nil
+}
+
+// resolve follows s's reference, if it has one.
+func (sp *Spec) resolve(s *schema) (*schema, error) {
+	for s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target := sp.schemas[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("controltest: spec refers to %s, which it does not define", s.Ref)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// mustResolve is resolve for schemas ParseSpec has already checked.
+func (sp *Spec) mustResolve(s *schema) *schema {
+	s, err := sp.resolve(s)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// branch returns the oneOf alternative v selects through s's discriminator,
+// or a problem describing why it selects none.
+func (sp *Spec) branch(s *schema, v any) (*schema, string) {
+	property := s.Discriminator.PropertyName
+	obj, _ := v.(map[string]any)
+	value, _ := obj[property].(string)
+	if ref, ok := s.Discriminator.Mapping[value]; ok {
+		return sp.mustResolve(&schema{Ref: ref}), ""
+	}
+	known := make([]string, 0, len(s.Discriminator.Mapping))
+	for k := range s.Discriminator.Mapping {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+	if value == "" {
+		return nil, fmt.Sprintf("missing %s, which must be one of %s", property, strings.Join(known, ", "))
+	}
+	return nil, fmt.Sprintf("%q is not a known %s; want one of %s", value, property, strings.Join(known, ", "))
+}
+
+// validate appends to problems every way v, decoded with json.Number numbers,
+// breaks s. at is v's location in the body, for the messages.
+func (sp *Spec) validate(s *schema, v any, at string, problems *[]string) {
+	if s == nil {
+		return
+	}
+	s = sp.mustResolve(s)
+	add := func(format string, args ...any) {
+		where := at
+		if where == "" {
+			where = "body"
+		}
+		*problems = append(*problems, where+": "+fmt.Sprintf(format, args...))
+	}
+
+	if v == nil {
+		if !s.Nullable && (s.Type != "" || s.Discriminator != nil) {
+			add("must not be null")
+		}
+		return
+	}
+
+	if len(s.OneOf) > 0 {
+		if s.Discriminator != nil {
+			chosen, problem := sp.branch(s, v)
+			if chosen == nil {
+				add("%s", problem)
+				return
+			}
+			sp.validate(chosen, v, at, problems)
+			return
+		}
+		matches := 0
+		for _, alt := range s.OneOf {
+			var altProblems []string
+			sp.validate(alt, v, at, &altProblems)
+			if len(altProblems) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			add("matches %d of the %d allowed shapes, want exactly one", matches, len(s.OneOf))
+		}
+		return
+	}
+
+	typ := s.Type
+	if typ == "" && s.Properties != nil {
+		typ = "object"
+	}
+	switch typ {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			add("want an object, got %s", jsonType(v))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				add("missing required field %s", name)
+			}
+		}
+		for _, name := range sortedKeys(obj) {
+			child := join(at, name)
+			if p, ok := s.Properties[name]; ok {
+				sp.validate(p, obj[name], child, problems)
+				continue
+			}
+			switch {
+			case s.closed():
+				*problems = append(*problems, child+": unknown field")
+			case s.AdditionalProperties != nil:
+				sp.validate(s.AdditionalProperties.schema, obj[name], child, problems)
+			}
+		}
+	case "array":
+		list, ok := v.([]any)
+		if !ok {
+			add("want an array, got %s", jsonType(v))
+			return
+		}
+		for i, item := range list {
+			sp.validate(s.Items, item, fmt.Sprintf("%s[%d]", at, i), problems)
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			add("want a string, got %s", jsonType(v))
+			return
+		}
+		if s.pattern != nil && !s.pattern.MatchString(str) {
+			add("%q does not match %s", str, s.Pattern)
+		}
+	case "integer", "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			add("want %s, got %s", article(typ), jsonType(v))
+			return
+		}
+		f, err := n.Float64()
+		if err != nil || (typ == "integer" && f != math.Trunc(f)) {
+			add("want %s, got %s", article(typ), n)
+			return
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			add("%s is below the minimum of %v", n, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			add("%s is above the maximum of %v", n, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			add("want a boolean, got %s", jsonType(v))
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		add("%v is not one of %v", v, s.Enum)
+	}
+}
+
+// trim removes from v the properties s does not list, where s does not allow
+// others, as the real API only ever returns what its response schemas list.
+func (sp *Spec) trim(s *schema, v any) {
+	if s == nil || v == nil {
+		return
+	}
+	s = sp.mustResolve(s)
+	if s.Discriminator != nil {
+		if chosen, _ := sp.branch(s, v); chosen != nil {
+			sp.trim(chosen, v)
+		}
+		return
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			p, ok := s.Properties[name]
+			switch {
+			case ok:
+				sp.trim(p, value)
+			case s.closed():
+				delete(v, name)
+			case s.AdditionalProperties != nil:
+				sp.trim(s.AdditionalProperties.schema, value)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			sp.trim(s.Items, item)
+		}
+	}
+}
+
+// strict wraps next so that requests breaking the spec are refused, as the
+// real API refuses them, and responses are cut down to what the spec says the
+// real API returns. A response that still does not match is the fake's own
+// bug, and is replaced with a 500 naming the problems.
+func (s *Server) strict(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op := s.spec.operation(r.Method, r.URL.Path)
+		if op == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not in the Control API spec", r.Method, r.URL.Path))
+			return
+		}
+
+		if op.request != nil {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Could not read the request body")
+				return
+			}
+			var problems []string
+			if v, err := decodeNumbers(body); err != nil {
+				problems = []string{"body: not valid JSON: " + err.Error()}
+			} else {
+				s.spec.validate(op.request, v, "", &problems)
+			}
+			if len(problems) > 0 {
+				writeProblems(w, http.StatusBadRequest, "Invalid request body", problems)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		body := rec.Body.Bytes()
+		if response := op.responses[rec.Code]; response != nil && len(bytes.TrimSpace(body)) > 0 {
+			var problems []string
+			v, err := decodeNumbers(body)
+			if err != nil {
+				problems = []string{"body: not valid JSON: " + err.Error()}
+			} else {
+				s.spec.trim(response, v)
+				s.spec.validate(response, v, "", &problems)
+			}
+			if len(problems) > 0 {
+				writeProblems(w, http.StatusInternalServerError,
+					fmt.Sprintf("controltest: the fake's response to %s %s does not match the Control API spec", r.Method, r.URL.Path), problems)
+				return
+			}
+			body, _ = json.Marshal(v)
+		}
+		maps.Copy(w.Header(), rec.Header())
+		w.Header().Del("Content-Length")
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(body)
+	})
+}
+
+// decodeNumbers decodes a JSON body, keeping numbers as json.Number so that
+// integers can be told from other numbers.
+func decodeNumbers(body []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// writeProblems writes an error whose message lists problems, so that they
+// reach the test output through the client's error, and whose details hold
+// them one by one.
+func writeProblems(w http.ResponseWriter, status int, message string, problems []string) {
+	writeJSON(w, status, map[string]any{
+		"message":    message + ": " + strings.Join(problems, "; "),
+		"statusCode": status,
+		"code":       status * 100,
+		"href":       fmt.Sprintf("https://help.ably.io/error/%d", status*100),
+		"details":    map[string]any{"problems": problems},
+	})
+}
+
+func join(at, name string) string {
+	if at == "" {
+		return name
+	}
+	return at + "." + name
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func article(typ string) string {
+	if typ == "integer" {
+		return "an integer"
+	}
+	return "a number"
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
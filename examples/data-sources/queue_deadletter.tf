@@ -0,0 +1,9 @@
+data "ably_queue_deadletter" "example_queue" {
+  app_id   = ably_app.app1.id
+  queue_id = ably_queue.example_queue.id
+}
+
+# Consume dead letters with the same AMQP client configuration as the queue.
+output "dead_letter_amqp_queue_name" {
+  value = data.ably_queue_deadletter.example_queue.amqp_queue_name
+}
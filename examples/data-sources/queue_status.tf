@@ -0,0 +1,11 @@
+data "ably_queue_status" "orders_dlq" {
+  app_id = ably_app.app1.id
+  id     = ably_queue.example_queue.deadletter_id
+}
+
+check "orders_dlq_empty" {
+  assert {
+    condition     = coalesce(data.ably_queue_status.orders_dlq.messages.total, 0) < 100
+    error_message = "The orders dead letter queue holds ${data.ably_queue_status.orders_dlq.messages.total} messages."
+  }
+}
@@ -0,0 +1,4 @@
+# Returns true: the filter is searched for anywhere in the channel name.
+output "lobby_matches" {
+  value = provider::ably::channel_filter_matches("^chat:", "chat:lobby")
+}
@@ -0,0 +1,12 @@
+resource "ably_app_bundle" "orders" {
+  name       = "orders"
+  namespaces = ["chat", "events"]
+  queue = {
+    name = "orders"
+  }
+}
+
+output "orders_client_key" {
+  value     = ably_app_bundle.orders.client_key
+  sensitive = true
+}
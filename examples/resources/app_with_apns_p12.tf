@@ -0,0 +1,7 @@
+resource "ably_app" "app2" {
+  name   = "ably-tf-provider-app-0002"
+  status = "enabled"
+  # Write-only: uploaded when the bundle changes, never stored in state.
+  apns_p12_base64   = filebase64("${path.module}/push.p12")
+  apns_p12_password = var.apns_p12_password
+}
@@ -0,0 +1,59 @@
+// Package controlspec loads the vendored Control API spec,
+// codegen/control-api.yaml, for the fake Control API's strict mode.
+//
+// The spec is YAML, and controltest parses JSON so that the control module
+// does not depend on a YAML parser. This package, in the provider's module,
+// does the conversion.
+package controlspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the OpenAPI document at path, in YAML or JSON, and parses it for
+// [controltest.WithSpec].
+func Load(path string) (*controltest.Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing spec %s: %w", path, err)
+	}
+	converted, err := json.Marshal(jsonValue(doc))
+	if err != nil {
+		return nil, fmt.Errorf("converting spec %s to JSON: %w", path, err)
+	}
+	return controltest.ParseSpec(converted)
+}
+
+// jsonValue makes a decoded YAML document encodable as JSON. YAML mapping keys
+// need not be strings, and unquoted response codes decode as integers.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, x := range v {
+			v[k] = jsonValue(x)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, x := range v {
+			m[fmt.Sprint(k)] = jsonValue(x)
+		}
+		return m
+	case []any:
+		for i, x := range v {
+			v[i] = jsonValue(x)
+		}
+		return v
+	}
+	return v
+}
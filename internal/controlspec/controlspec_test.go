@@ -0,0 +1,200 @@
+package controlspec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+)
+
+// vendoredSpec is the spec the provider is generated from.
+const vendoredSpec = "../../codegen/control-api.yaml"
+
+func strictClient(t *testing.T) (*control.Client, *controltest.Server) {
+	t.Helper()
+	spec, err := Load(vendoredSpec)
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	fake := controltest.NewServer(controltest.WithSpec(spec))
+	t.Cleanup(fake.Close)
+	client := control.NewClient("any-token", control.WithRetryMax(0))
+	client.BaseURL = fake.URL
+	return client, fake
+}
+
+// TestClientMatchesSpec runs the client's requests for every resource through
+// strict mode, so a request type that drifts from the spec fails here.
+func TestClientMatchesSpec(t *testing.T) {
+	ctx := context.Background()
+	c, _ := strictClient(t)
+	check := func(what string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %s", what, err)
+		}
+	}
+
+	me, err := c.Me(ctx)
+	check("Me", err)
+
+	app, err := c.CreateApp(ctx, me.Account.ID, control.AppPost{Name: "chat", TLSOnly: ptr(true)})
+	check("CreateApp", err)
+	_, err = c.UpdateApp(ctx, app.ID, control.AppPatch{Name: "chat-v2"})
+	check("UpdateApp", err)
+	_, err = c.ListApps(ctx, me.Account.ID)
+	check("ListApps", err)
+
+	key, err := c.CreateKey(ctx, app.ID, control.KeyPost{Name: "server", Capability: map[string][]string{"*": {"publish"}}})
+	check("CreateKey", err)
+	_, err = c.UpdateKey(ctx, app.ID, key.ID, control.KeyPatch{Name: "server-v2"})
+	check("UpdateKey", err)
+	check("RevokeKey", c.RevokeKey(ctx, app.ID, key.ID))
+
+	_, err = c.CreateNamespace(ctx, app.ID, control.NamespacePost{ID: "rooms", Persisted: true})
+	check("CreateNamespace", err)
+	_, err = c.UpdateNamespace(ctx, app.ID, "rooms", control.NamespacePatch{PushEnabled: ptr(true)})
+	check("UpdateNamespace", err)
+	_, err = c.ListNamespaces(ctx, app.ID)
+	check("ListNamespaces", err)
+
+	queue, err := c.CreateQueue(ctx, app.ID, control.Queue{Name: "orders", TTL: 60, MaxLength: 100, Region: "us-east-1-a"})
+	check("CreateQueue", err)
+	_, err = c.ListQueues(ctx, app.ID)
+	check("ListQueues", err)
+
+	rule, err := c.CreateRule(ctx, app.ID, control.AMQPRulePost{
+		RuleType:    "amqp",
+		RequestMode: "single",
+		Source:      control.RuleSource{ChannelFilter: "^orders", Type: "channel.message"},
+		Target:      control.AMQPRuleTarget{QueueID: queue.ID, Format: "json"},
+	})
+	check("CreateRule", err)
+	_, err = c.GetRule(ctx, app.ID, rule.ID)
+	check("GetRule", err)
+	_, err = c.ListRules(ctx, app.ID)
+	check("ListRules", err)
+	check("DeleteRule", c.DeleteRule(ctx, app.ID, rule.ID))
+	check("DeleteQueue", c.DeleteQueue(ctx, app.ID, queue.ID))
+	check("DeleteApp", c.DeleteApp(ctx, app.ID))
+}
+
+func TestStrictModeRejectsBadRequests(t *testing.T) {
+	ctx := context.Background()
+	c, fake := strictClient(t)
+	fake.SeedApp(controltest.Record{"id": "app1", "name": "chat"})
+
+	tests := []struct {
+		name string
+		body any
+		want []string
+	}{
+		{
+			name: "unknown field",
+			body: map[string]any{"name": "orders", "ttl": 60, "maxLength": 100, "region": "us-east-1-a", "maxLen": 5},
+			want: []string{"maxLen: unknown field"},
+		},
+		{
+			name: "missing and mistyped fields",
+			body: map[string]any{"name": "orders", "ttl": "60"},
+			want: []string{"missing required field maxLength", "missing required field region", "ttl: want an integer, got a string"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := send(t, fake, http.MethodPost, "/apps/app1/queues", tt.body)
+			if status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+			message, _ := body["message"].(string)
+			for _, want := range tt.want {
+				if !strings.Contains(message, want) {
+					t.Errorf("message = %q, want it to contain %q", message, want)
+				}
+			}
+			if _, ok := body["href"]; !ok {
+				t.Errorf("error body %v has no href, unlike the real API's", body)
+			}
+		})
+	}
+
+	// Rules are checked against the type their ruleType names.
+	var apiErr *control.Error
+	_, err := c.CreateRule(ctx, "app1", map[string]any{
+		"ruleType":    "amqp",
+		"requestMode": "single",
+		"source":      map[string]any{"channelFilter": "", "type": "channel.message"},
+		"target":      map[string]any{"queueId": "q1", "url": "https://example.com"},
+	})
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, "target.url: unknown field") {
+		t.Errorf("creating an AMQP rule with a url: error = %v, want target.url rejected", err)
+	}
+
+	_, err = c.CreateRule(ctx, "app1", map[string]any{"ruleType": "carrier-pigeon"})
+	if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, `"carrier-pigeon" is not a known ruleType`) {
+		t.Errorf("creating an unknown rule type: error = %v, want it rejected", err)
+	}
+
+	// So are paths.
+	if _, err := c.GetAppStats(ctx, "app1", nil); err != nil {
+		t.Errorf("GetAppStats: %s", err)
+	}
+	if status, _ := send(t, fake, http.MethodPost, "/apps/app1/queues/q1/purge", nil); status != http.StatusNotFound {
+		t.Errorf("a path the spec lacks: status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+// TestStrictModeTrimsResponses checks responses carry only what the real API
+// returns, whatever the fake was given.
+func TestStrictModeTrimsResponses(t *testing.T) {
+	_, fake := strictClient(t)
+	fake.SeedApp(controltest.Record{"id": "app1", "name": "chat", "fcmKey": "secret"})
+
+	status, body := send(t, fake, http.MethodPatch, "/apps/app1", map[string]any{"name": "chat-v2"})
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if body["name"] != "chat-v2" {
+		t.Errorf("name = %v, want chat-v2", body["name"])
+	}
+	if _, ok := body["fcmKey"]; ok {
+		t.Error("the response has the FCM key, which the real API never returns")
+	}
+}
+
+// send makes a raw request to fake, for bodies the client's types cannot
+// express, and returns the status and decoded response.
+func send(t *testing.T, fake *controltest.Server, method, path string, body any) (int, map[string]any) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, fake.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -3,6 +3,8 @@ package exporter
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
@@ -90,7 +92,8 @@ func configNested(nested *tfprotov6.SchemaObject, value tftypes.Value) (tftypes.
 }
 
 // configCollection applies transform to every element of a list, set, map or
-// single object, rebuilding the collection around the results.
+// single object, rebuilding the collection around the results. value itself is
+// left alone: As hands out the value's own slice or map, so it works on a copy.
 func configCollection(value tftypes.Value, transform func(tftypes.Value) (tftypes.Value, error)) (tftypes.Value, error) {
 	if value.IsNull() || !value.IsKnown() {
 		return value, nil
@@ -103,6 +106,7 @@ func configCollection(value tftypes.Value, transform func(tftypes.Value) (tftype
 		if err := value.As(&elements); err != nil {
 			return value, err
 		}
+		elements = slices.Clone(elements)
 		for index, element := range elements {
 			transformed, err := transform(element)
 			if err != nil {
@@ -121,6 +125,7 @@ func configCollection(value tftypes.Value, transform func(tftypes.Value) (tftype
 		if err := value.As(&elements); err != nil {
 			return value, err
 		}
+		elements = maps.Clone(elements)
 		for key, element := range elements {
 			transformed, err := transform(element)
 			if err != nil {
@@ -135,6 +140,52 @@ func configCollection(value tftypes.Value, transform func(tftypes.Value) (tftype
 	}
 }
 
+// validationConfig returns value as Terraform would see it once written:
+// every required value the Control API withheld is unknown. The renderer never
+// writes the empty value a read returns for those, it writes a TODO or a
+// lookup Terraform resolves only after validation, so validating the empty
+// value would fail the provider's length checks on a value the config never
+// holds.
+func validationConfig(block *tfprotov6.SchemaBlock, value tftypes.Value) (tftypes.Value, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return value, nil
+	}
+
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		return value, err
+	}
+	// As shares the value's own map, and value is what gets rendered.
+	attributes = maps.Clone(attributes)
+
+	for _, attribute := range block.Attributes {
+		if attribute == nil {
+			continue
+		}
+		current, ok := attributes[attribute.Name]
+		if !ok {
+			continue
+		}
+
+		if attribute.Required && withheld(attribute, current) {
+			attributes[attribute.Name] = tftypes.NewValue(current.Type(), tftypes.UnknownValue)
+			continue
+		}
+		if attribute.NestedType != nil {
+			object := &tfprotov6.SchemaBlock{Attributes: attribute.NestedType.Attributes}
+			nested, err := configCollection(current, func(element tftypes.Value) (tftypes.Value, error) {
+				return validationConfig(object, element)
+			})
+			if err != nil {
+				return value, err
+			}
+			attributes[attribute.Name] = nested
+		}
+	}
+
+	return tftypes.NewValue(value.Type(), attributes), nil
+}
+
 func isComputedOnly(attribute *tfprotov6.SchemaAttribute) bool {
 	return attribute.Computed && !attribute.Optional && !attribute.Required
 }
@@ -160,8 +211,16 @@ func isComputedOnly(attribute *tfprotov6.SchemaAttribute) bool {
 func repairConfig(ctx context.Context, bridge *bridge, resourceType, label string, schema *tfprotov6.Schema, config tftypes.Value) (tftypes.Value, []string, []string) {
 	var dropped, unresolved []string
 
+	validate := func(config tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+		config, err := validationConfig(schema.Block, config)
+		if err != nil {
+			return nil, err
+		}
+		return bridge.validate(ctx, resourceType, config)
+	}
+
 	for range maxRepairs {
-		diagnostics, err := bridge.validate(ctx, resourceType, config)
+		diagnostics, err := validate(config)
 		if err != nil {
 			return config, dropped, append(unresolved, fmt.Sprintf(
 				"could not validate the generated config for %s.%s: %s", resourceType, label, err))
@@ -192,7 +251,7 @@ func repairConfig(ctx context.Context, bridge *bridge, resourceType, label strin
 	// The last pass may have fixed the last problem, so check before declaring
 	// failure. Report the outstanding diagnostics when it didn't: a bare "gave
 	// up" leaves nothing to act on.
-	diagnostics, err := bridge.validate(ctx, resourceType, config)
+	diagnostics, err := validate(config)
 	if err == nil {
 		problems := errorDiagnostics(diagnostics)
 		if len(problems) == 0 {
@@ -2,6 +2,7 @@ package exporter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -20,6 +21,17 @@ const (
 	resourceTypeQueue     = "ably_queue"
 )
 
+// dataSourceTypeQueueDeadletter reads a dead letter queue, which the exporter
+// writes in place of an ably_queue for it.
+const dataSourceTypeQueueDeadletter = "ably_queue_deadletter"
+
+// compositeResourceTypes are provider resources that manage several Control
+// API resources at once. The exporter writes those resources out
+// individually, so it never exports the composites themselves.
+var compositeResourceTypes = map[string]bool{
+	"ably_app_bundle": true,
+}
+
 // SupportedResourceTypes returns every resource type the exporter can find.
 //
 // Rule types come from the provider's registry, so a new rule resource is
@@ -51,30 +63,55 @@ type Target struct {
 	AppName string
 	// Name is the resource's own name where it has one. Rules do not.
 	Name string
+	// Key is an API key's key string, so a rule using it as a credential can
+	// refer to the key instead. Empty for anything else.
+	Key string
 	// RuleType is the Control API ruleType for rule resources, empty otherwise.
 	RuleType string
+	// RuleTarget is a rule's Control API target, which rule labels are built
+	// from. Nil for anything but a rule.
+	RuleTarget map[string]any
+	// Revoked marks a revoked API key, found only when revoked keys were asked
+	// for. It is listed for review rather than exported: the provider reads a
+	// revoked key as gone.
+	Revoked bool
+	// DeadletterOf is, for a dead letter queue, the ID of a queue using it.
+	// Ably creates and deletes dead letter queues itself, so rather than
+	// export one as an ably_queue the exporter reads it through that queue's
+	// ably_queue_deadletter data source.
+	DeadletterOf string
 }
 
 // discovery is the result of walking an account.
 type discovery struct {
-	Targets  []Target
-	Warnings []string
+	Targets []Target
+	// AccountApps holds the ID of every app in the account, filtered out or
+	// not, so a resource missing from Targets can be told apart from one
+	// outside the filters.
+	AccountApps map[string]bool
+	Warnings    []string
 }
 
-// discover walks an account and returns every resource the exporter can
-// export. appFilter, when non-empty, keeps only apps whose ID or name matches.
-func discover(ctx context.Context, client *control.Client, accountID string, appFilter []string) (*discovery, error) {
-	result := &discovery{}
+// discover walks an account and returns every resource the filter keeps.
+//
+// Every kept app is returned even when ably_app itself is filtered out, because
+// its children are named after it; Run decides whether to export it. Collections
+// the filter rules out entirely are not listed at all.
+func discover(ctx context.Context, client *control.Client, accountID string, filter *filter) (*discovery, error) {
+	result := &discovery{AccountApps: map[string]bool{}}
 
 	apps, err := client.ListApps(ctx, accountID)
 	if err != nil {
 		return nil, fmt.Errorf("listing apps: %w", err)
 	}
+	for _, app := range apps {
+		result.AccountApps[app.ID] = true
+	}
 
-	apps, unmatched := filterApps(apps, appFilter)
+	apps, unmatched := filterApps(apps, filter.apps)
 	if len(apps) == 0 {
-		if len(appFilter) > 0 {
-			return nil, fmt.Errorf("no apps in the account match %s", strings.Join(appFilter, ", "))
+		if len(filter.apps) > 0 {
+			return nil, fmt.Errorf("no apps in the account match %s", strings.Join(filter.apps, ", "))
 		}
 		result.Warnings = append(result.Warnings, "the account has no apps")
 		return result, nil
@@ -86,6 +123,15 @@ func discover(ctx context.Context, client *control.Client, accountID string, app
 			"no app matched %s, so nothing was exported for it", strings.Join(unmatched, ", ")))
 	}
 
+	apps, unmatched = excludeApps(apps, filter.excludeApps)
+	if len(unmatched) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"no app matched -exclude-app %s, so nothing was excluded for it", strings.Join(unmatched, ", ")))
+	}
+	if len(apps) == 0 {
+		return nil, errors.New("every app in the account was excluded, so there is nothing to export")
+	}
+
 	// Sort apps by name so output ordering doesn't depend on API ordering.
 	sort.Slice(apps, func(i, j int) bool {
 		if apps[i].Name != apps[j].Name {
@@ -102,7 +148,7 @@ func discover(ctx context.Context, client *control.Client, accountID string, app
 			Name:         app.Name,
 		})
 
-		appTargets, warnings, err := discoverApp(ctx, client, app)
+		appTargets, warnings, err := discoverApp(ctx, client, app, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -113,8 +159,8 @@ func discover(ctx context.Context, client *control.Client, accountID string, app
 	return result, nil
 }
 
-// discoverApp lists everything that lives inside a single app.
-func discoverApp(ctx context.Context, client *control.Client, app control.AppResponse) ([]Target, []string, error) {
+// discoverApp lists everything inside a single app that the filter keeps.
+func discoverApp(ctx context.Context, client *control.Client, app control.AppResponse, filter *filter) ([]Target, []string, error) {
 	var targets []Target
 	var warnings []string
 
@@ -129,36 +175,82 @@ func discoverApp(ctx context.Context, client *control.Client, app control.AppRes
 		}
 	}
 
-	keys, err := client.ListKeys(ctx, app.ID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("listing keys for app %s: %w", app.ID, err)
-	}
-	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
-	for _, key := range keys {
-		// A non-zero status means revoked. The provider's Read treats those as
-		// gone, so exporting one gives config for a resource that isn't there.
-		if key.Status != 0 {
-			continue
+	if filter.wantsType(resourceTypeKey) {
+		keys, err := client.ListKeys(ctx, app.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing keys for app %s: %w", app.ID, err)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+		for _, key := range keys {
+			if !filter.wantsKey(key.Name) {
+				continue
+			}
+			// A non-zero status means revoked. The provider's Read treats those
+			// as gone, so exporting one gives config for a resource that isn't
+			// there. They are only listed, for review, when asked for.
+			if key.Status != 0 {
+				if filter.includeRevoked {
+					revoked := target(resourceTypeKey, key.ID, key.Name, "")
+					revoked.Revoked = true
+					targets = append(targets, revoked)
+				}
+				continue
+			}
+			active := target(resourceTypeKey, key.ID, key.Name, "")
+			active.Key = key.Key
+			targets = append(targets, active)
 		}
-		targets = append(targets, target(resourceTypeKey, key.ID, key.Name, ""))
 	}
 
-	namespaces, err := client.ListNamespaces(ctx, app.ID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("listing namespaces for app %s: %w", app.ID, err)
-	}
-	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].ID < namespaces[j].ID })
-	for _, namespace := range namespaces {
-		targets = append(targets, target(resourceTypeNamespace, namespace.ID, namespace.ID, ""))
+	if filter.wantsType(resourceTypeNamespace) {
+		namespaces, err := client.ListNamespaces(ctx, app.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing namespaces for app %s: %w", app.ID, err)
+		}
+		sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].ID < namespaces[j].ID })
+		for _, namespace := range namespaces {
+			if !filter.wantsNamespace(namespace.ID) {
+				continue
+			}
+			targets = append(targets, target(resourceTypeNamespace, namespace.ID, namespace.ID, ""))
+		}
 	}
 
-	queues, err := client.ListQueues(ctx, app.ID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("listing queues for app %s: %w", app.ID, err)
+	if filter.wantsType(resourceTypeQueue) {
+		queues, err := client.ListQueues(ctx, app.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing queues for app %s: %w", app.ID, err)
+		}
+		sort.Slice(queues, func(i, j int) bool { return queues[i].ID < queues[j].ID })
+		// Queues can share a dead letter queue, which is looked up through the
+		// first of them by ID.
+		users := map[string]string{}
+		for _, queue := range queues {
+			if queue.DeadletterID == nil || queue.Deadletter {
+				continue
+			}
+			if _, seen := users[*queue.DeadletterID]; !seen {
+				users[*queue.DeadletterID] = queue.ID
+			}
+		}
+		for _, queue := range queues {
+			queueTarget := target(resourceTypeQueue, queue.ID, queue.Name, "")
+			if queue.Deadletter {
+				user, ok := users[queue.ID]
+				if !ok {
+					warnings = append(warnings, fmt.Sprintf(
+						"skipped dead letter queue %s in app %s: no queue uses it, so there is nothing to look it up by",
+						queue.ID, app.ID))
+					continue
+				}
+				queueTarget.DeadletterOf = user
+			}
+			targets = append(targets, queueTarget)
+		}
 	}
-	sort.Slice(queues, func(i, j int) bool { return queues[i].ID < queues[j].ID })
-	for _, queue := range queues {
-		targets = append(targets, target(resourceTypeQueue, queue.ID, queue.Name, ""))
+
+	if !filter.wantsRules() {
+		return targets, warnings, nil
 	}
 
 	rules, err := client.ListRules(ctx, app.ID)
@@ -174,7 +266,12 @@ func discoverApp(ctx context.Context, client *control.Client, app control.AppRes
 				rule.ID, app.ID, rule.RuleType))
 			continue
 		}
-		targets = append(targets, target(resourceType, rule.ID, "", rule.RuleType))
+		if !filter.wantsType(resourceType) {
+			continue
+		}
+		ruleTarget := target(resourceType, rule.ID, "", rule.RuleType)
+		ruleTarget.RuleTarget, _ = rule.Target.(map[string]any)
+		targets = append(targets, ruleTarget)
 	}
 
 	return targets, warnings, nil
@@ -206,3 +303,33 @@ func filterApps(apps []control.AppResponse, appFilter []string) (kept []control.
 	}
 	return kept, unmatched
 }
+
+// excludeApps drops the apps matching one of the exclusions by ID or name. It
+// also returns the exclusions that matched nothing.
+func excludeApps(apps []control.AppResponse, exclusions []string) (kept []control.AppResponse, unmatched []string) {
+	if len(exclusions) == 0 {
+		return apps, nil
+	}
+
+	matched := make(map[string]bool, len(exclusions))
+	for _, app := range apps {
+		excluded := false
+		for _, exclusion := range exclusions {
+			unwanted := strings.ToLower(strings.TrimSpace(exclusion))
+			if unwanted == strings.ToLower(app.ID) || unwanted == strings.ToLower(app.Name) {
+				matched[exclusion] = true
+				excluded = true
+			}
+		}
+		if !excluded {
+			kept = append(kept, app)
+		}
+	}
+
+	for _, exclusion := range exclusions {
+		if !matched[exclusion] {
+			unmatched = append(unmatched, exclusion)
+		}
+	}
+	return kept, unmatched
+}
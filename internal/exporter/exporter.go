@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,6 +42,24 @@ type Config struct {
 	// Apps limits the export to apps matching these IDs or names. Empty
 	// exports every app in the account.
 	Apps []string
+	// ExcludeApps drops apps matching these IDs or names, after Apps is
+	// applied.
+	ExcludeApps []string
+	// IncludeTypes limits the export to resource types matching these globs,
+	// e.g. "ably_rule_*". Empty includes every supported type.
+	IncludeTypes []string
+	// ExcludeTypes drops resource types matching these globs, after
+	// IncludeTypes is applied.
+	ExcludeTypes []string
+	// KeyNames limits the API keys exported to those whose name matches one of
+	// these patterns: a glob, or a regular expression wrapped in slashes.
+	KeyNames []string
+	// NamespaceNames limits the namespaces exported to those whose ID matches
+	// one of these patterns, written as for KeyNames.
+	NamespaceNames []string
+	// IncludeRevoked lists revoked API keys in the output for review. They are
+	// never exported as resources.
+	IncludeRevoked bool
 	// Secrets decides what happens to sensitive values. Defaults to
 	// SecretsInline.
 	Secrets SecretMode
@@ -51,6 +70,17 @@ type Config struct {
 	// ProviderVersion is the version constraint for the generated
 	// required_providers block. Empty omits the constraint.
 	ProviderVersion string
+	// Naming holds label templates, each "TYPE_GLOB=TEMPLATE" or a bare
+	// template for every type, written in text/template against labelData.
+	// The first whose glob matches a resource's type applies.
+	Naming []string
+	// LabelOverrides maps Control API IDs, or "app_id,id" for namespaces, to
+	// labels chosen by hand. They win over Naming.
+	LabelOverrides map[string]string
+	// PriorManifest is the manifest a previous export wrote. When set, the
+	// export generates moved blocks for resources whose address changed and
+	// removed blocks for resources that have left the account.
+	PriorManifest *Manifest
 	// Version is the provider version the exporter reports to the Control API
 	// in its User-Agent.
 	Version string
@@ -79,10 +109,24 @@ type Result struct {
 	// SecretsInline reports whether any secret was written into the output as a
 	// literal, which decides how the files are permissioned.
 	SecretsInline bool
-	// Variables reports whether a variables.tf was generated.
+	// Variables reports whether sensitive values were replaced with lookups:
+	// variables in variables.tf, or reads from a secret manager in secrets.tf.
 	Variables bool
+	// Revoked lists the revoked API keys found when IncludeRevoked was set, as
+	// `name (id) in app`.
+	Revoked []string
+	// Moved lists the resources whose address changed since PriorManifest, as
+	// `old -> new`.
+	Moved []string
+	// Removed lists the addresses in PriorManifest whose resources have left
+	// the account.
+	Removed []string
 	// Warnings describes anything skipped or worth a second look.
 	Warnings []string
+
+	// captured holds the sensitive values replaced with lookups, for
+	// WriteSecrets. Unexported so it is never printed by accident.
+	captured map[string]string
 }
 
 // exported is a resource that has been read and rendered.
@@ -97,6 +141,18 @@ type exported struct {
 	// notes are written above the resource as comments, for anything the
 	// exporter could not resolve on its own.
 	notes []string
+	// reviewOnly marks an entry written as comments alone, with no resource
+	// block and so no import block. Revoked keys are the case.
+	reviewOnly bool
+	// lookup marks an entry written as a data block, which has no import
+	// block either. Dead letter queues are the case.
+	lookup bool
+}
+
+// managed reports whether the entry is a resource block, for Terraform to
+// import and manage.
+func (e exported) managed() bool {
+	return !e.reviewOnly && !e.lookup
 }
 
 // Run exports an account. It performs read-only Control API calls.
@@ -114,6 +170,15 @@ func Run(ctx context.Context, config Config) (*Result, error) {
 		config.Version = "dev"
 	}
 
+	filter, err := newFilter(config)
+	if err != nil {
+		return nil, err
+	}
+	namer, err := newNamer(config)
+	if err != nil {
+		return nil, err
+	}
+
 	client := control.NewClient(config.Token)
 	client.BaseURL = config.URL
 	client.UserAgent += " terraform-provider-ably-exporter/" + config.Version
@@ -135,7 +200,7 @@ func Run(ctx context.Context, config Config) (*Result, error) {
 		return nil, err
 	}
 
-	found, err := discover(ctx, client, accountID, config.Apps)
+	found, err := discover(ctx, client, accountID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +210,44 @@ func Run(ctx context.Context, config Config) (*Result, error) {
 		Warnings: found.Warnings,
 	}
 
-	labels, appLabels, references := assignLabels(found.Targets)
+	// Labels are assigned over the whole account, whatever the filters keep,
+	// so narrowing an export never renames what it keeps. An export narrowed
+	// by app, key or namespace walks the account a second time without those
+	// filters for that.
+	account := found.Targets
+	if filter.narrows() {
+		everything, err := discover(ctx, client, accountID, filter.unnarrowed())
+		if err != nil {
+			return nil, err
+		}
+		account = everything.Targets
+	}
+	labels, appLabels, warnings, err := assignLabels(found.Targets, account, namer)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(result.Warnings, warnings...)
+	references := newReferenceIndex(found.Targets, labels, func(target Target) bool {
+		return !target.Revoked && filter.wantsType(target.ResourceType)
+	})
 	renderer := newRenderer(config.Secrets, references)
 
 	var exports []exported
 	for index, target := range found.Targets {
+		if !filter.wantsType(target.ResourceType) {
+			continue
+		}
+		if target.Revoked {
+			exports = append(exports, revokedKey(target, appLabels[target.AppID]))
+			result.Revoked = append(result.Revoked, fmt.Sprintf("%s (%s) in %s", target.Name, target.ID, target.AppName))
+			continue
+		}
+
+		if target.DeadletterOf != "" {
+			exports = append(exports, deadletterLookup(target, labels[index], appLabels[target.AppID], references))
+			continue
+		}
+
 		schema, err := bridge.schema(target.ResourceType)
 		if err != nil {
 			return nil, err
@@ -213,31 +311,146 @@ func Run(ctx context.Context, config Config) (*Result, error) {
 
 	result.Files = buildFiles(config, exports, renderer.variables)
 	result.Variables = len(renderer.variables) > 0
+	result.captured = renderer.captured
+
+	if config.PriorManifest != nil {
+		transition := compareManifest(config.PriorManifest, found, filter, exports)
+		for _, move := range transition.moved {
+			result.Moved = append(result.Moved, move[0]+" -> "+move[1])
+		}
+		result.Removed = transition.removed
+		for _, address := range transition.outOfScope {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"%s was in the previous export but is outside this one's filters; "+
+					"Terraform will plan to destroy it if this configuration manages its state", address))
+		}
+		for _, move := range transition.blocked {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"%s is now %s, but another resource held one of those addresses in the previous export, "+
+					"so no moved block was written; move its state by hand with terraform state mv, "+
+					"through a temporary address if %s is still in state", move[0], move[1], move[1]))
+		}
+		for _, address := range transition.occupied {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"%s has left the account, but a new resource now has its address, so no removed block was written; "+
+					"Terraform drops the old one from state when it refreshes", address))
+		}
+		if !transition.empty() {
+			result.Files = append(result.Files, formatFile("moved.tf", movedFile(transition)))
+		}
+	}
+
+	manifest, err := manifestFile(exports)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, manifest)
 
 	return result, nil
 }
 
-// assignLabels gives every target an HCL label and records the reference
-// expression that points at it. Discovery emits an app before its children, so
-// one pass can name a child after its app.
-func assignLabels(targets []Target) (labels []string, appLabels map[string]string, references map[resourceRef]string) {
+// revokedKey is the review entry for a revoked API key: a comment naming it,
+// where its resource block would otherwise be.
+func revokedKey(target Target, appLabel string) exported {
+	return exported{
+		target:   target,
+		appLabel: appLabel,
+		hcl: fmt.Sprintf("# Revoked API key %s (%s), listed for review. Terraform cannot manage a\n"+
+			"# revoked key: it cannot be re-enabled, and the provider reads it as gone.\n",
+			quoteString(target.Name), target.ID),
+		reviewOnly: true,
+	}
+}
+
+// deadletterLookup is the data block reading a dead letter queue through a
+// queue using it, written where its resource block would otherwise be.
+func deadletterLookup(target Target, label, appLabel string, references *referenceIndex) exported {
+	identity := identityOf("data."+dataSourceTypeQueueDeadletter, label)
+	reference := func(attribute, id string) string {
+		if expression, ok := references.lookup(attribute, id, identity); ok {
+			return expression
+		}
+		return quoteString(id)
+	}
+	return exported{
+		target:   target,
+		label:    label,
+		appLabel: appLabel,
+		hcl: fmt.Sprintf("# Dead letter queue %s (%s), read rather than managed: Ably\n"+
+			"# creates and deletes it along with the queues using it.\n"+
+			"data %q %q {\n  app_id   = %s\n  queue_id = %s\n}\n",
+			quoteString(target.Name), target.ID, dataSourceTypeQueueDeadletter, label,
+			reference("app_id", target.AppID), reference("queue_id", target.DeadletterOf)),
+		lookup: true,
+	}
+}
+
+// assignLabels gives every target an HCL label. The labels are worked out over
+// account, everything the export would find without its app, key and
+// namespace filters, which takes in targets; a label depends on what else
+// shares its name, so this keeps it the same however the export is narrowed. Discovery emits an app before its
+// children, so one pass can name a child after its app.
+//
+// Overridden labels are reserved first, so a generated label never takes one.
+// Overrides naming no resource in the account are reported, as a typo would
+// otherwise go unseen.
+func assignLabels(targets, account []Target, namer *namer) (labels []string, appLabels map[string]string, warnings []string, err error) {
+	kept := map[manifestKey]int{}
+	for index, target := range targets {
+		kept[targetKey(target)] = index
+	}
+
 	labeller := newLabeller()
-	labels = make([]string, len(targets))
-	references = map[resourceRef]string{}
+	accountLabels := make([]string, len(account))
 	appLabels = map[string]string{}
 
-	for index, target := range targets {
-		label := labeller.assign(target.ResourceType, labelFor(target, appLabels[target.AppID]))
-		labels[index] = label
+	used := map[string]bool{}
+	overridden := make([]bool, len(account))
+	for index, target := range account {
+		key, label, ok := namer.override(target)
+		if !ok {
+			continue
+		}
+		if !labeller.reserve(target.ResourceType, label) {
+			return nil, nil, nil, fmt.Errorf("label override %q is given to more than one %s", label, target.ResourceType)
+		}
+		accountLabels[index] = label
+		overridden[index] = true
+		used[key] = true
+	}
+	for key, label := range namer.overrides {
+		if !used[key] {
+			warnings = append(warnings, fmt.Sprintf("label override %s: %s matched no resource in the export", key, label))
+		}
+	}
+	sort.Strings(warnings)
+
+	labels = make([]string, len(targets))
+	for index, target := range account {
+		_, isKept := kept[targetKey(target)]
+		if !overridden[index] {
+			base, warning := namer.base(target, appLabels[target.AppID])
+			// Only what the export keeps is worth a warning.
+			if warning != "" && isKept {
+				warnings = append(warnings, warning)
+			}
+			accountLabels[index] = labeller.assign(target.ResourceType, base)
+		}
 
 		if target.ResourceType == resourceTypeApp {
-			appLabels[target.ID] = label
+			appLabels[target.ID] = accountLabels[index]
+		}
+		if isKept {
+			labels[kept[targetKey(target)]] = accountLabels[index]
 		}
-		// referenceableAttributes decides which attributes get rewritten.
-		references[resourceRef{target.ResourceType, target.ID}] = fmt.Sprintf("%s.%s.id", target.ResourceType, label)
 	}
 
-	return labels, appLabels, references
+	return labels, appLabels, warnings, nil
+}
+
+// targetKey identifies a target the way a manifest entry does.
+func targetKey(target Target) manifestKey {
+	return manifestKey{target.ResourceType, target.AppID, target.ID}
 }
 
 // buildFiles lays the rendered resources out into files.
@@ -277,10 +490,18 @@ func buildFiles(config Config, exports []exported, variables []variableDecl) []F
 	}
 
 	if len(variables) > 0 {
-		files = append(files, formatFile("variables.tf", variablesFile(variables)))
+		switch config.Secrets {
+		case SecretsVault, SecretsAWSSecretsManager:
+			files = append(files, formatFile(secretsBackendFile, secretsBackend(config.Secrets)))
+		case SecretsEnv:
+			files = append(files, formatFile(variablesFileName, variablesFile(variables)))
+			files = append(files, File{Name: secretsEnvExample, Content: []byte(envExample(variables))})
+		default:
+			files = append(files, formatFile(variablesFileName, variablesFile(variables)))
+		}
 	}
 
-	if config.Imports && len(exports) > 0 {
+	if config.Imports && slices.ContainsFunc(exports, exported.managed) {
 		files = append(files, formatFile("imports.tf", importsFile(exports)))
 	}
 
@@ -308,7 +529,13 @@ func providerFile(config Config) string {
 	if config.ProviderVersion != "" {
 		fmt.Fprintf(&buf, "      version = %s\n", quoteString(config.ProviderVersion))
 	}
-	buf.WriteString("    }\n  }\n}\n\nprovider \"ably\" {\n")
+	buf.WriteString("    }\n")
+	// The secret manager modes read through another provider, configured the
+	// usual way for it: VAULT_ADDR and VAULT_TOKEN, or the AWS credential chain.
+	if name, source := config.Secrets.providerSource(); name != "" {
+		fmt.Fprintf(&buf, "    %s = {\n      source = %s\n    }\n", name, quoteString(source))
+	}
+	buf.WriteString("  }\n}\n\nprovider \"ably\" {\n")
 	buf.WriteString("  # The account token is read from the ABLY_ACCOUNT_TOKEN environment variable.\n")
 	if config.URL != DefaultURL {
 		fmt.Fprintf(&buf, "  url = %s\n", quoteString(config.URL))
@@ -322,12 +549,8 @@ func variablesFile(variables []variableDecl) string {
 	buf.WriteString(fileHeader())
 	buf.WriteString("\n# Sensitive values are not written by the exporter. Supply them before applying.\n")
 
-	sorted := make([]variableDecl, len(variables))
-	copy(sorted, variables)
-	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
-
 	seen := map[string]bool{}
-	for _, variable := range sorted {
+	for _, variable := range sortedVariables(variables) {
 		if seen[variable.Name] {
 			continue
 		}
@@ -349,6 +572,9 @@ func importsFile(exports []exported) string {
 `)
 
 	for _, export := range exports {
+		if !export.managed() {
+			continue
+		}
 		fmt.Fprintf(&buf, "\nimport {\n  to = %s.%s\n  id = %s\n}\n",
 			export.target.ResourceType, export.label, quoteString(export.importID))
 	}
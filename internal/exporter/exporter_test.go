@@ -2,6 +2,8 @@ package exporter
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
+	"github.com/ably/terraform-provider-ably/control/controltest"
 	"github.com/ably/terraform-provider-ably/internal/provider"
 )
 
@@ -19,7 +22,13 @@ import (
 func runExport(t *testing.T, config Config) (*Result, map[string]string) {
 	t.Helper()
 
-	fake := newFakeControlAPI(t)
+	return runExportWith(t, newFakeControlAPI(t), config)
+}
+
+// runExportWith is runExport against a fake the test has altered.
+func runExportWith(t *testing.T, fake *fakeControlAPI, config Config) (*Result, map[string]string) {
+	t.Helper()
+
 	config.Token = "fake-token"
 	config.URL = fake.url()
 
@@ -73,7 +82,7 @@ func TestRunExportsEveryResourceInTheAccount(t *testing.T) {
 		`resource "ably_api_key" "chat_service_root_key" {`,
 		`resource "ably_namespace" "chat_service_chat" {`,
 		`resource "ably_queue" "chat_service_orders" {`,
-		`resource "ably_rule_http" "chat_service"`,
+		`resource "ably_rule_http" "chat_service_example_com"`,
 	} {
 		if !strings.Contains(config, want) {
 			t.Errorf("generated config is missing %s:\n%s", want, config)
@@ -98,6 +107,55 @@ func TestRunWritesReferencesRatherThanIDs(t *testing.T) {
 	}
 }
 
+func TestRunReadsDeadletterQueues(t *testing.T) {
+	fake := newFakeControlAPIWith(t, func(rules map[string]map[string]any) {
+		rules["rule6"] = map[string]any{
+			"id":          "rule6",
+			"status":      "enabled",
+			"ruleType":    "amqp",
+			"requestMode": "single",
+			"source":      map[string]any{"channelFilter": "", "type": "channel.message"},
+			"target":      map[string]any{"queueId": "dlq1", "enveloped": false, "format": "json"},
+		}
+	})
+	fake.api.Inspect(func(state *controltest.State) {
+		state.Queues["app1"]["queue1"]["deadletterId"] = "dlq1"
+	})
+	fake.api.SeedQueue("app1", controltest.Record{
+		"id":         "dlq1",
+		"name":       "deadletter",
+		"region":     "eu-west-1-a",
+		"ttl":        60,
+		"maxLength":  10000,
+		"state":      "active",
+		"deadletter": true,
+		"amqp":       map[string]any{"uri": "amqps://example", "queueName": "deadletter"},
+		"stomp":      map[string]any{"uri": "stomp://example", "host": "shared", "destination": "/deadletter"},
+	})
+
+	result, files := runExportWith(t, fake, Config{Imports: true})
+	config := files["app_chat_service.tf"]
+
+	// The dead letter queue is read through the queue using it, and the rule
+	// delivering to it refers to that lookup.
+	if result.Counts["ably_queue"] != 1 {
+		t.Errorf("exported %d of ably_queue, want only the orders queue", result.Counts["ably_queue"])
+	}
+	if !strings.Contains(config, `data "ably_queue_deadletter" "chat_service_deadletter" {`) {
+		t.Errorf("generated config does not look up the dead letter queue:\n%s", config)
+	}
+	assertAssigns(t, config, "queue_id", "ably_queue.chat_service_orders.id")
+	assertAssigns(t, config, "queue_id", "data.ably_queue_deadletter.chat_service_deadletter.id")
+	if strings.Contains(config, `"dlq1"`) {
+		t.Errorf("the dead letter queue ID was written as a literal:\n%s", config)
+	}
+	for _, name := range []string{"imports.tf", ManifestFile} {
+		if strings.Contains(files[name], "dlq1") {
+			t.Errorf("%s adopts the dead letter queue:\n%s", name, files[name])
+		}
+	}
+}
+
 // assertAssigns checks an assignment, tolerating the whitespace hclwrite inserts
 // to align equals signs.
 func assertAssigns(t *testing.T, config, attribute, value string) {
@@ -295,6 +353,9 @@ func TestRunGeneratesParseableHCL(t *testing.T) {
 			_, files := runExport(t, Config{Secrets: secrets, Imports: true, ProviderVersion: DefaultProviderVersion})
 
 			for name, content := range files {
+				if !strings.HasSuffix(name, ".tf") {
+					continue
+				}
 				parser := hclparse.NewParser()
 				_, diagnostics := parser.ParseHCL([]byte(content), name)
 				if diagnostics.HasErrors() {
@@ -423,7 +484,7 @@ func TestSupportedResourceTypesCoverage(t *testing.T) {
 	}
 
 	for _, resourceType := range provider.ResourceTypeNames(context.Background()) {
-		if supported[resourceType] {
+		if supported[resourceType] || compositeResourceTypes[resourceType] {
 			continue
 		}
 		if provider.IsRuleResourceType(resourceType) {
@@ -438,7 +499,7 @@ func TestSupportedResourceTypesCoverage(t *testing.T) {
 }
 
 func TestParseSecretMode(t *testing.T) {
-	for _, valid := range []string{"inline", "vars", "omit"} {
+	for _, valid := range []string{"inline", "vars", "env", "vault", "aws-sm", "omit"} {
 		if _, err := ParseSecretMode(valid); err != nil {
 			t.Errorf("ParseSecretMode(%q): %s", valid, err)
 		}
@@ -526,7 +587,7 @@ func TestRunFlagsWithheldRequiredValues(t *testing.T) {
 
 	var reported bool
 	for _, missing := range result.Missing {
-		if strings.Contains(missing, "ably_rule_kafka.chat_service.target.auth.sasl.password") {
+		if strings.Contains(missing, "ably_rule_kafka.chat_service_key.target.auth.sasl.password") {
 			reported = true
 		}
 	}
@@ -542,13 +603,13 @@ func TestRunWiresWithheldRequiredValuesToVariables(t *testing.T) {
 	_, files := runExport(t, Config{Secrets: SecretsVars})
 	config := files["app_chat_service.tf"]
 
-	assertAssigns(t, config, "password", "var.rule_kafka_chat_service_target_auth_sasl_password")
+	assertAssigns(t, config, "password", "var.rule_kafka_chat_service_key_target_auth_sasl_password")
 
 	variables, ok := files["variables.tf"]
 	if !ok {
 		t.Fatalf("no variables.tf was generated, got %v", fileNames(files))
 	}
-	if !strings.Contains(variables, `variable "rule_kafka_chat_service_target_auth_sasl_password" {`) {
+	if !strings.Contains(variables, `variable "rule_kafka_chat_service_key_target_auth_sasl_password" {`) {
 		t.Errorf("no variable was declared for the withheld password:\n%s", variables)
 	}
 	if !strings.Contains(variables, "Required, but the Control API does not return it.") {
@@ -600,7 +661,7 @@ func TestRunDoesNotWriteTheAccountID(t *testing.T) {
 	_, files := runExport(t, Config{Imports: true, Secrets: SecretsVars, ProviderVersion: DefaultProviderVersion})
 
 	for name, content := range files {
-		if strings.Contains(content, fakeAccountID) {
+		if strings.Contains(content, controltest.AccountID) {
 			t.Errorf("%s names the account:\n%s", name, content)
 		}
 	}
@@ -763,3 +824,191 @@ func TestBuildFilesKeepsOneFilePerApp(t *testing.T) {
 		}
 	}
 }
+
+func TestRunFiltersByResourceType(t *testing.T) {
+	result, files := runExport(t, Config{Imports: true, IncludeTypes: []string{"ably_rule_*"}})
+
+	for resourceType, count := range result.Counts {
+		if !strings.HasPrefix(resourceType, "ably_rule_") {
+			t.Errorf("exported %d of %s, which -include-type ably_rule_* rules out", count, resourceType)
+		}
+	}
+	if result.Counts["ably_rule_http"] != 1 {
+		t.Errorf("exported %d HTTP rules, want 1:\n%s", result.Counts["ably_rule_http"], result.Summary())
+	}
+
+	// The app was left out, so the rules refer to it by ID, and keep the labels
+	// they would have had in a full export.
+	config := files["app_chat_service.tf"]
+	assertAssigns(t, config, "app_id", `"app1"`)
+	assertAssigns(t, config, "queue_id", `"queue1"`)
+	if !strings.Contains(config, `resource "ably_rule_http" "chat_service_example_com"`) {
+		t.Errorf("the HTTP rule was relabelled by the filter:\n%s", config)
+	}
+}
+
+func TestRunExcludesResourceTypes(t *testing.T) {
+	fake := newFakeControlAPI(t)
+
+	result, err := Run(context.Background(), Config{
+		Token:        "fake-token",
+		URL:          fake.url(),
+		ExcludeTypes: []string{"*_rule_*", "ably_queue"},
+	})
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	want := map[string]int{"ably_app": 1, "ably_api_key": 1, "ably_namespace": 1}
+	if result.Total != 3 {
+		t.Errorf("exported %d resources, want 3:\n%s", result.Total, result.Summary())
+	}
+	for resourceType, count := range want {
+		if result.Counts[resourceType] != count {
+			t.Errorf("exported %d of %s, want %d", result.Counts[resourceType], resourceType, count)
+		}
+	}
+
+	// Collections ruled out entirely are not even listed.
+	for _, request := range fake.recorded() {
+		if strings.HasSuffix(request, "/rules") || strings.HasSuffix(request, "/queues") {
+			t.Errorf("the exporter listed a collection it was told to exclude: %s", request)
+		}
+	}
+}
+
+func TestRunRejectsTypePatternsThatMatchNothing(t *testing.T) {
+	_, err := Run(context.Background(), Config{Token: "fake-token", IncludeTypes: []string{"ably_rules_*"}})
+	if err == nil {
+		t.Fatal("a type pattern matching no resource type should fail")
+	}
+	if !strings.Contains(err.Error(), "ably_rules_*") {
+		t.Errorf("error should name the pattern, got %s", err)
+	}
+}
+
+func TestRunExcludesApps(t *testing.T) {
+	fake := newFakeControlAPI(t)
+
+	_, err := Run(context.Background(), Config{
+		Token:       "fake-token",
+		URL:         fake.url(),
+		ExcludeApps: []string{"app1"},
+	})
+	if err == nil {
+		t.Fatal("excluding every app should fail rather than export nothing")
+	}
+
+	result, err := Run(context.Background(), Config{
+		Token:       "fake-token",
+		URL:         fake.url(),
+		ExcludeApps: []string{"typo-app"},
+	})
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	var warned bool
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "typo-app") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning named the exclusion that matched nothing, warnings were %v", result.Warnings)
+	}
+}
+
+// TestRunFilteredKeepsUnfilteredLabels narrows an export to resources whose
+// labels collide with ones it leaves out, and checks each keeps the label a
+// full export gives it.
+func TestRunFilteredKeepsUnfilteredLabels(t *testing.T) {
+	fake := newFakeControlAPI(t)
+	// Each sorts after, and sanitises like, a resource already in the account.
+	fake.api.SeedApp(controltest.Record{"id": "app2", "name": "chat-service"})
+	fake.api.SeedKey("app1", controltest.Record{"id": "key9", "name": "root-key"})
+	fake.api.SeedNamespace("app1", controltest.Record{"id": "chat-room"})
+	fake.api.SeedNamespace("app1", controltest.Record{"id": "chat_room"})
+
+	addresses := func(config Config) map[manifestKey]string {
+		t.Helper()
+		_, files := runExportWith(t, fake, config)
+		var manifest Manifest
+		if err := json.Unmarshal([]byte(files[ManifestFile]), &manifest); err != nil {
+			t.Fatalf("parsing %s: %s", ManifestFile, err)
+		}
+		got := map[manifestKey]string{}
+		for _, entry := range manifest.Resources {
+			got[entry.key()] = entry.Address
+		}
+		return got
+	}
+	full := addresses(Config{})
+
+	tests := []struct {
+		name   string
+		config Config
+		want   string // an address that only keeps its suffix if labels ignore the filter
+	}{
+		{"app", Config{Apps: []string{"chat-service"}}, "ably_app.chat_service_2"},
+		{"excluded app", Config{ExcludeApps: []string{"app1"}}, "ably_app.chat_service_2"},
+		{"key name", Config{KeyNames: []string{"root-key"}}, "ably_api_key.chat_service_root_key_2"},
+		{"namespace", Config{NamespaceNames: []string{"chat_room"}}, "ably_namespace.chat_service_chat_room_2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := addresses(tt.config)
+			if !slices.Contains(slices.Collect(maps.Values(filtered)), tt.want) {
+				t.Errorf("the filtered export has no %s: %v", tt.want, filtered)
+			}
+			for key, address := range filtered {
+				if full[key] != address {
+					t.Errorf("%s %s is %s, but %s in a full export", key.resourceType, key.id, address, full[key])
+				}
+			}
+		})
+	}
+}
+
+func TestRunFiltersKeysAndNamespacesByName(t *testing.T) {
+	result, _ := runExport(t, Config{KeyNames: []string{"ci-*"}, NamespaceNames: []string{"/^ch/"}})
+	if result.Counts["ably_api_key"] != 0 {
+		t.Errorf("exported %d keys, want none to match ci-*", result.Counts["ably_api_key"])
+	}
+	if result.Counts["ably_namespace"] != 1 {
+		t.Errorf("exported %d namespaces, want the one matching /^ch/", result.Counts["ably_namespace"])
+	}
+
+	result, _ = runExport(t, Config{KeyNames: []string{"root*"}, NamespaceNames: []string{"other"}})
+	if result.Counts["ably_api_key"] != 1 {
+		t.Errorf("exported %d keys, want the one matching root*", result.Counts["ably_api_key"])
+	}
+	if result.Counts["ably_namespace"] != 0 {
+		t.Errorf("exported %d namespaces, want none to match other", result.Counts["ably_namespace"])
+	}
+}
+
+// TestRunListsRevokedKeysForReview covers -exclude-revoked=false. A revoked key
+// cannot be managed, so it is written as a comment, never as a resource or an
+// import.
+func TestRunListsRevokedKeysForReview(t *testing.T) {
+	result, files := runExport(t, Config{Imports: true, IncludeRevoked: true})
+
+	if len(result.Revoked) != 1 || !strings.Contains(result.Revoked[0], "key2") {
+		t.Errorf("Revoked = %v, want the revoked key", result.Revoked)
+	}
+	if result.Counts["ably_api_key"] != 1 {
+		t.Errorf("exported %d keys, want only the active one", result.Counts["ably_api_key"])
+	}
+
+	config := files["app_chat_service.tf"]
+	if !strings.Contains(config, `# Revoked API key "revoked key" (key2)`) {
+		t.Errorf("the revoked key was not listed:\n%s", config)
+	}
+	if strings.Contains(files["imports.tf"], "key2") {
+		t.Errorf("a revoked key was given an import block:\n%s", files["imports.tf"])
+	}
+
+	result, _ = runExport(t, Config{})
+	if len(result.Revoked) != 0 {
+		t.Errorf("revoked keys were listed without being asked for: %v", result.Revoked)
+	}
+}
@@ -1,22 +1,24 @@
 package exporter
 
 import (
-	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/http/httptest"
 	"slices"
-	"strings"
 	"sync"
 	"testing"
+
+	"github.com/ably/terraform-provider-ably/control/controltest"
 )
 
-// fakeControlAPI is a read-only, in-process stand-in for the Control API, serving
-// a fixed account so the exporter can be tested with no credentials and no
-// network. The exporter only reads, so a fixture is enough; the provider's own
-// fake is the stateful one used for CRUD.
+// fakeControlAPI serves a fixed account from controltest, the provider's
+// in-process stand-in for the Control API, so the exporter can be tested with no
+// credentials and no network. The exporter only reads, so every other method is
+// refused.
 type fakeControlAPI struct {
+	// api holds the fixture. A test may seed or change it before running the
+	// exporter.
+	api    *controltest.Server
 	server *httptest.Server
 
 	// mu guards requests, which is appended to on the server's goroutines and
@@ -27,8 +29,6 @@ type fakeControlAPI struct {
 	requests []string
 }
 
-const fakeAccountID = "acc1"
-
 // newFakeControlAPI serves one account with one app holding a key, a namespace, a
 // queue and five rules. One rule has an unsupported ruleType; one is a Kafka rule
 // missing the SASL credentials the API never returns.
@@ -42,12 +42,9 @@ func newFakeControlAPI(t *testing.T) *fakeControlAPI {
 func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]any)) *fakeControlAPI {
 	t.Helper()
 
-	fake := &fakeControlAPI{}
-
 	rules := map[string]map[string]any{
 		"rule1": {
 			"id":          "rule1",
-			"appId":       "app1",
 			"status":      "enabled",
 			"ruleType":    "http",
 			"requestMode": "single",
@@ -65,7 +62,6 @@ func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]
 		},
 		"rule2": {
 			"id":          "rule2",
-			"appId":       "app1",
 			"status":      "enabled",
 			"ruleType":    "amqp",
 			"requestMode": "single",
@@ -81,7 +77,6 @@ func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]
 		},
 		"rule3": {
 			"id":             "rule3",
-			"appId":          "app1",
 			"status":         "enabled",
 			"ruleType":       "bodyguard/text-moderation",
 			"invocationMode": "BEFORE_PUBLISH",
@@ -101,7 +96,6 @@ func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]
 		},
 		"rule5": {
 			"id":          "rule5",
-			"appId":       "app1",
 			"status":      "enabled",
 			"ruleType":    "kafka",
 			"requestMode": "single",
@@ -122,7 +116,6 @@ func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]
 		// it with a warning rather than failing the whole export.
 		"rule4": {
 			"id":       "rule4",
-			"appId":    "app1",
 			"status":   "enabled",
 			"ruleType": "hive/text-moderation",
 			"target":   map[string]any{"apiKey": "hive-secret"},
@@ -133,104 +126,64 @@ func newFakeControlAPIWith(t *testing.T, alter func(rules map[string]map[string]
 		alter(rules)
 	}
 
-	responses := map[string]any{
-		"/me": map[string]any{
-			"account": map[string]any{"id": fakeAccountID, "name": "Test Account"},
-		},
-		"/accounts/" + fakeAccountID + "/apps": []any{
-			map[string]any{
-				"id":        "app1",
-				"accountId": fakeAccountID,
-				"name":      "Chat Service",
-				"status":    "enabled",
-				"tlsOnly":   true,
-				"created":   1600000000000,
-				"modified":  1600000000000,
-			},
-		},
-		"/apps/app1/keys": []any{
-			map[string]any{
-				"id":              "key1",
-				"appId":           "app1",
-				"name":            "root key",
-				"key":             "app1.key1:secret",
-				"status":          0,
-				"revocableTokens": false,
-				"capability":      map[string]any{"chat:*": []any{"publish", "subscribe"}},
-				"created":         1600000000000,
-				"modified":        1600000000000,
-			},
-			// Revoked keys are not exportable: the provider reads them as gone.
-			map[string]any{
-				"id":     "key2",
-				"appId":  "app1",
-				"name":   "revoked key",
-				"status": 1,
-			},
-		},
-		"/apps/app1/namespaces": []any{
-			map[string]any{
-				"id":          "chat",
-				"appId":       "app1",
-				"persisted":   true,
-				"persistLast": false,
-				"pushEnabled": false,
-				"tlsOnly":     false,
-			},
-		},
-		"/apps/app1/queues": []any{
-			map[string]any{
-				"id":         "queue1",
-				"appId":      "app1",
-				"name":       "orders",
-				"region":     "eu-west-1-a",
-				"ttl":        60,
-				"maxLength":  10000,
-				"state":      "active",
-				"deadletter": false,
-				"amqp":       map[string]any{"uri": "amqps://example", "queueName": "orders"},
-				"stomp":      map[string]any{"uri": "stomp://example", "host": "shared", "destination": "/orders"},
-			},
-		},
+	api := controltest.NewServer()
+	t.Cleanup(api.Close)
+	api.SeedApp(controltest.Record{
+		"id":       "app1",
+		"name":     "Chat Service",
+		"status":   "enabled",
+		"tlsOnly":  true,
+		"created":  1600000000000,
+		"modified": 1600000000000,
+	})
+	api.SeedKey("app1", controltest.Record{
+		"id":              "key1",
+		"name":            "root key",
+		"key":             "app1.key1:secret",
+		"revocableTokens": false,
+		"capability":      map[string]any{"chat:*": []any{"publish", "subscribe"}},
+		"created":         1600000000000,
+		"modified":        1600000000000,
+	})
+	// Revoked keys are not exportable: the provider reads them as gone.
+	api.SeedKey("app1", controltest.Record{
+		"id":     "key2",
+		"name":   "revoked key",
+		"status": 1,
+	})
+	api.SeedNamespace("app1", controltest.Record{
+		"id":          "chat",
+		"persisted":   true,
+		"persistLast": false,
+		"pushEnabled": false,
+		"tlsOnly":     false,
+	})
+	api.SeedQueue("app1", controltest.Record{
+		"id":        "queue1",
+		"name":      "orders",
+		"region":    "eu-west-1-a",
+		"ttl":       60,
+		"maxLength": 10000,
+		"state":     "active",
+		"amqp":      map[string]any{"uri": "amqps://example", "queueName": "orders"},
+		"stomp":     map[string]any{"uri": "stomp://example", "host": "shared", "destination": "/orders"},
+	})
+	for _, rule := range rules {
+		api.SeedRule("app1", rule)
 	}
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	fake := &fakeControlAPI{api: api}
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fake.mu.Lock()
 		fake.requests = append(fake.requests, r.Method+" "+r.URL.Path)
 		fake.mu.Unlock()
 
 		if r.Method != http.MethodGet {
-			http.Error(w, `{"message":"the exporter must only read"}`, http.StatusMethodNotAllowed)
+			http.Error(w, fmt.Sprintf(`{"message":"the exporter must only read, not %s"}`, r.Method), http.StatusMethodNotAllowed)
 			return
 		}
-
-		if body, ok := responses[r.URL.Path]; ok {
-			writeJSON(t, w, body)
-			return
-		}
-
-		switch {
-		case r.URL.Path == "/apps/app1/rules":
-			list := make([]any, 0, len(rules))
-			for _, id := range slices.Sorted(maps.Keys(rules)) {
-				list = append(list, rules[id])
-			}
-			writeJSON(t, w, list)
-		case strings.HasPrefix(r.URL.Path, "/apps/app1/rules/"):
-			id := strings.TrimPrefix(r.URL.Path, "/apps/app1/rules/")
-			rule, ok := rules[id]
-			if !ok {
-				http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
-				return
-			}
-			writeJSON(t, w, rule)
-		default:
-			http.Error(w, fmt.Sprintf(`{"message":"unexpected path %s"}`, r.URL.Path), http.StatusNotFound)
-		}
-	})
-
-	fake.server = httptest.NewServer(mux)
+		api.ServeHTTP(w, r)
+	}))
 	t.Cleanup(fake.server.Close)
 	return fake
 }
@@ -246,13 +199,3 @@ func (f *fakeControlAPI) recorded() []string {
 	defer f.mu.Unlock()
 	return slices.Clone(f.requests)
 }
-
-// writeJSON encodes a fixture. Errorf rather than Fatalf: FailNow from a handler
-// goroutine kills the response mid-flight and surfaces as a transport error.
-func writeJSON(t *testing.T, w http.ResponseWriter, body any) {
-	t.Helper()
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(body); err != nil {
-		t.Errorf("encoding fake response: %s", err)
-	}
-}
@@ -0,0 +1,177 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filter decides which of the resources in an account an export keeps, so a
+// team can take just its slice of a shared account.
+//
+// Apps are matched by ID or name, resource types by glob (ably_rule_*), and key
+// names and namespace IDs by glob or, wrapped in slashes, regular expression.
+type filter struct {
+	apps        []string
+	excludeApps []string
+
+	includeTypes []*regexp.Regexp
+	excludeTypes []*regexp.Regexp
+
+	keyNames       []*regexp.Regexp
+	namespaceNames []*regexp.Regexp
+
+	includeRevoked bool
+}
+
+// newFilter compiles the filters in a config. A type pattern that matches no
+// resource type the exporter supports is an error rather than a warning: it is
+// a typo, and an export of everything or nothing would look like it worked.
+func newFilter(config Config) (*filter, error) {
+	f := &filter{
+		apps:           config.Apps,
+		excludeApps:    config.ExcludeApps,
+		includeRevoked: config.IncludeRevoked,
+	}
+
+	var err error
+	if f.includeTypes, err = compileTypePatterns(config.IncludeTypes, "-include-type"); err != nil {
+		return nil, err
+	}
+	if f.excludeTypes, err = compileTypePatterns(config.ExcludeTypes, "-exclude-type"); err != nil {
+		return nil, err
+	}
+	if f.keyNames, err = compilePatterns(config.KeyNames); err != nil {
+		return nil, fmt.Errorf("-key-name: %w", err)
+	}
+	if f.namespaceNames, err = compilePatterns(config.NamespaceNames); err != nil {
+		return nil, fmt.Errorf("-namespace: %w", err)
+	}
+	return f, nil
+}
+
+func compileTypePatterns(patterns []string, flagName string) ([]*regexp.Regexp, error) {
+	compiled, err := compilePatterns(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", flagName, err)
+	}
+
+	supported := SupportedResourceTypes()
+	for index, pattern := range compiled {
+		if !matchesAny(pattern, supported) {
+			return nil, fmt.Errorf("%s %q matches no resource type the exporter supports", flagName, patterns[index])
+		}
+	}
+	return compiled, nil
+}
+
+// compilePatterns turns each pattern into an anchored regular expression. A
+// pattern wrapped in slashes (/^ci-/) is used as written; anything else is a
+// glob where * matches any run of characters and ? any one.
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expression, err := compilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, expression)
+	}
+	return compiled, nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expression, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", pattern, err)
+		}
+		return expression, nil
+	}
+
+	var buf strings.Builder
+	buf.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			buf.WriteString(".*")
+		case '?':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	buf.WriteString("$")
+	return regexp.Compile(buf.String())
+}
+
+func matchesAny(pattern *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyMatches(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsType reports whether resources of a type are exported.
+func (f *filter) wantsType(resourceType string) bool {
+	if len(f.includeTypes) > 0 && !anyMatches(f.includeTypes, resourceType) {
+		return false
+	}
+	return !anyMatches(f.excludeTypes, resourceType)
+}
+
+// wantsRules reports whether any rule resource type is exported, which decides
+// whether an app's rules are listed at all.
+func (f *filter) wantsRules() bool {
+	for _, resourceType := range SupportedResourceTypes() {
+		if isRuleType(resourceType) && f.wantsType(resourceType) {
+			return true
+		}
+	}
+	return false
+}
+
+// narrows reports whether the filter leaves out anything that could share a
+// label with what it keeps: apps, and keys and namespaces by name. Type
+// filters can't, as labels are only unique within a type and every kept app
+// is discovered whatever the type filters say.
+func (f *filter) narrows() bool {
+	return len(f.apps) > 0 || len(f.excludeApps) > 0 || len(f.keyNames) > 0 || len(f.namespaceNames) > 0
+}
+
+// unnarrowed returns f without the filters narrows looks at.
+func (f *filter) unnarrowed() *filter {
+	return &filter{includeTypes: f.includeTypes, excludeTypes: f.excludeTypes, includeRevoked: f.includeRevoked}
+}
+
+// wantsKey reports whether an API key with this name is exported.
+func (f *filter) wantsKey(name string) bool {
+	return len(f.keyNames) == 0 || anyMatches(f.keyNames, name)
+}
+
+// wantsNamespace reports whether a namespace with this ID is exported.
+func (f *filter) wantsNamespace(id string) bool {
+	return len(f.namespaceNames) == 0 || anyMatches(f.namespaceNames, id)
+}
+
+// isRuleType reports whether a supported resource type is a rule, which is
+// everything without its own collection endpoint.
+func isRuleType(resourceType string) bool {
+	switch resourceType {
+	case resourceTypeApp, resourceTypeKey, resourceTypeNamespace, resourceTypeQueue:
+		return false
+	default:
+		return true
+	}
+}
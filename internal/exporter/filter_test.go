@@ -0,0 +1,53 @@
+package exporter
+
+import "testing"
+
+func TestCompilePattern(t *testing.T) {
+	for _, test := range []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"ably_rule_*", "ably_rule_http", true},
+		{"ably_rule_*", "ably_ingress_rule_mongodb", false},
+		{"ably_queue", "ably_queue", true},
+		{"ably_queue", "ably_queue_status", false},
+		{"ci-?", "ci-1", true},
+		{"ci-?", "ci-12", false},
+		// Glob metacharacters aside, the pattern is literal.
+		{"a.b", "axb", false},
+		{"/^ci-[0-9]+$/", "ci-42", true},
+		{"/^ci-[0-9]+$/", "ci-x", false},
+		{"/stream/", "orders-stream-eu", true},
+	} {
+		expression, err := compilePattern(test.pattern)
+		if err != nil {
+			t.Fatalf("compilePattern(%q): %s", test.pattern, err)
+		}
+		if got := expression.MatchString(test.value); got != test.want {
+			t.Errorf("%q matching %q = %t, want %t", test.pattern, test.value, got, test.want)
+		}
+	}
+
+	if _, err := compilePattern("/[/"); err == nil {
+		t.Error("an invalid regular expression should fail")
+	}
+}
+
+func TestFilterWantsRules(t *testing.T) {
+	f, err := newFilter(Config{ExcludeTypes: []string{"ably_rule_*", "ably_ingress_rule_*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.wantsRules() {
+		t.Error("with every rule type excluded, rules should not be listed")
+	}
+
+	f, err = newFilter(Config{IncludeTypes: []string{"ably_rule_kafka"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.wantsRules() || f.wantsType("ably_rule_http") || !f.wantsType("ably_rule_kafka") {
+		t.Error("-include-type ably_rule_kafka should keep Kafka rules and nothing else")
+	}
+}
@@ -26,15 +26,36 @@ const (
 	// SecretsOmit leaves sensitive attributes out entirely, with a comment
 	// where each one would have gone.
 	SecretsOmit SecretMode = "omit"
+	// SecretsEnv is SecretsVars with a secrets.env.example listing the
+	// TF_VAR_ environment variables that supply them.
+	SecretsEnv SecretMode = "env"
+	// SecretsVault reads sensitive values from one HashiCorp Vault KV v2
+	// secret, through a vault_kv_secret_v2 data source in secrets.tf.
+	SecretsVault SecretMode = "vault"
+	// SecretsAWSSecretsManager reads sensitive values from one AWS Secrets
+	// Manager secret holding a JSON object, through a data source in
+	// secrets.tf.
+	SecretsAWSSecretsManager SecretMode = "aws-sm"
 )
 
 // ParseSecretMode validates a -secrets flag value.
 func ParseSecretMode(value string) (SecretMode, error) {
 	switch SecretMode(value) {
-	case SecretsInline, SecretsVars, SecretsOmit:
+	case SecretsInline, SecretsVars, SecretsOmit, SecretsEnv, SecretsVault, SecretsAWSSecretsManager:
 		return SecretMode(value), nil
 	default:
-		return "", fmt.Errorf("unknown secrets mode %q, expected inline, vars or omit", value)
+		return "", fmt.Errorf("unknown secrets mode %q, expected inline, vars, env, vault, aws-sm or omit", value)
+	}
+}
+
+// replaces reports whether the mode writes a lookup in place of each sensitive
+// value, rather than the value itself or nothing.
+func (m SecretMode) replaces() bool {
+	switch m {
+	case SecretsVars, SecretsEnv, SecretsVault, SecretsAWSSecretsManager:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -51,9 +72,9 @@ type variableDecl struct {
 // sensitive, and how values nest.
 type renderer struct {
 	secrets SecretMode
-	// references maps a resource type and Control API ID onto an HCL expression
-	// referring to the resource that owns it, so app_id becomes ably_app.foo.id.
-	references map[resourceRef]string
+	// references maps IDs and other values onto HCL expressions referring to
+	// the resource that owns them, so app_id becomes ably_app.foo.id.
+	references *referenceIndex
 
 	// Collected while rendering.
 	variables []variableDecl
@@ -62,10 +83,13 @@ type renderer struct {
 	// missing records required attributes the Control API withheld, which leave
 	// the config incomplete until someone fills them in.
 	missing []string
+	// captured holds the sensitive values replaced with lookups, by variable
+	// name, for -secrets-out.
+	captured map[string]string
 }
 
-func newRenderer(secrets SecretMode, references map[resourceRef]string) *renderer {
-	return &renderer{secrets: secrets, references: references}
+func newRenderer(secrets SecretMode, references *referenceIndex) *renderer {
+	return &renderer{secrets: secrets, references: references, captured: map[string]string{}}
 }
 
 // resourceIdentity is how the renderer refers to what it is writing: an address
@@ -153,8 +177,18 @@ func (r *renderer) writeAttributes(buf *strings.Builder, depth int, attributes [
 			continue
 		}
 
+		// A value belonging to another exported resource is written as a
+		// reference, so the config carries the dependency rather than a literal.
+		// It goes first because a key string used as a credential is sensitive,
+		// and the reference is better than any of the secrets modes.
+		if expression, ok := r.reference(attribute, attributeValue, identity); ok {
+			writeIndent(buf, depth)
+			fmt.Fprintf(buf, "%s = %s\n", attribute.Name, expression)
+			continue
+		}
+
 		if attribute.Sensitive {
-			written, err := r.writeSensitive(buf, depth, attribute, identity, attributePath)
+			written, err := r.writeSensitive(buf, depth, attribute, attributeValue, identity, attributePath)
 			if err != nil {
 				return err
 			}
@@ -178,17 +212,18 @@ func (r *renderer) writeAttributes(buf *strings.Builder, depth int, attributes [
 //
 // Kafka SASL passwords, AWS secret keys and Pulsar TLS certs are accepted on
 // write and never read back. Nothing can recover them, so the gap is made
-// obvious rather than left to fail at plan time. In vars mode it wires up a
-// variable, so the config is complete once the value is supplied.
+// obvious rather than left to fail at plan time. In the modes that replace
+// secrets it wires up a lookup, so the config is complete once the value is
+// supplied.
 func (r *renderer) writeMissingRequired(buf *strings.Builder, depth int, attribute *tfprotov6.SchemaAttribute, identity resourceIdentity, path []string) {
 	name := pathString(path)
 
-	if r.secrets == SecretsVars && isSimpleType(attribute) {
+	if r.secrets.replaces() && isSimpleType(attribute) {
 		variable := r.declareVariable(attribute, identity, path,
 			fmt.Sprintf("%s of %s. Required, but the Control API does not return it.", name, identity.address))
 		writeIndent(buf, depth)
-		fmt.Fprintf(buf, "%s = var.%s\n", attribute.Name, variable)
-		r.missing = append(r.missing, fmt.Sprintf("%s.%s (required; supply it in variables.tf)", identity.address, name))
+		fmt.Fprintf(buf, "%s = %s\n", attribute.Name, r.secretLookup(variable, attribute))
+		r.missing = append(r.missing, fmt.Sprintf("%s.%s (required; %s)", identity.address, name, r.secrets.supplyHint()))
 		return
 	}
 
@@ -233,7 +268,7 @@ func (r *renderer) declareVariable(attribute *tfprotov6.SchemaAttribute, identit
 // writeSensitive handles a sensitive attribute according to the secrets mode.
 // It returns true when it has dealt with the attribute itself, and false when
 // the value should be written inline as normal.
-func (r *renderer) writeSensitive(buf *strings.Builder, depth int, attribute *tfprotov6.SchemaAttribute, identity resourceIdentity, path []string) (bool, error) {
+func (r *renderer) writeSensitive(buf *strings.Builder, depth int, attribute *tfprotov6.SchemaAttribute, value tftypes.Value, identity resourceIdentity, path []string) (bool, error) {
 	name := pathString(path)
 	r.sensitive = append(r.sensitive, fmt.Sprintf("%s.%s", identity.address, name))
 
@@ -243,7 +278,7 @@ func (r *renderer) writeSensitive(buf *strings.Builder, depth int, attribute *tf
 		fmt.Fprintf(buf, "# %s omitted: sensitive value. Re-run with -secrets=inline or -secrets=vars to export it.\n", attribute.Name)
 		return true, nil
 
-	case SecretsVars:
+	case SecretsVars, SecretsEnv, SecretsVault, SecretsAWSSecretsManager:
 		// A variable stands in for a single string, number or bool. An object or
 		// collection has no equivalent, so leave it out rather than emit config
 		// that cannot type-check.
@@ -256,8 +291,11 @@ func (r *renderer) writeSensitive(buf *strings.Builder, depth int, attribute *tf
 
 		variable := r.declareVariable(attribute, identity, path,
 			fmt.Sprintf("%s of %s. Sensitive, so the exporter did not write its value.", name, identity.address))
+		if err := r.capture(variable, value, path); err != nil {
+			return false, err
+		}
 		writeIndent(buf, depth)
-		fmt.Fprintf(buf, "%s = var.%s\n", attribute.Name, variable)
+		fmt.Fprintf(buf, "%s = %s\n", attribute.Name, r.secretLookup(variable, attribute))
 		return true, nil
 
 	default:
@@ -292,23 +330,22 @@ func (r *renderer) writeValue(buf *strings.Builder, depth int, attribute *tfprot
 		return r.writeNestedValue(buf, depth, attribute.NestedType, value, identity, path)
 	}
 
-	// An ID that belongs to another exported resource is written as a
-	// reference so the config carries the dependency rather than a literal.
-	if len(path) > 0 && value.Type().Is(tftypes.String) {
-		if resourceType, referenceable := referenceableAttributes[path[len(path)-1]]; referenceable {
-			var literal string
-			if err := value.As(&literal); err == nil {
-				if expression, ok := r.references[resourceRef{resourceType, literal}]; ok {
-					buf.WriteString(expression)
-					return nil
-				}
-			}
-		}
-	}
-
 	return r.writePrimitive(buf, depth, value, path)
 }
 
+// reference returns the expression to write in place of a string attribute's
+// value, when the value belongs to another exported resource.
+func (r *renderer) reference(attribute *tfprotov6.SchemaAttribute, value tftypes.Value, identity resourceIdentity) (string, bool) {
+	if attribute.NestedType != nil || !value.Type().Is(tftypes.String) {
+		return "", false
+	}
+	var literal string
+	if err := value.As(&literal); err != nil {
+		return "", false
+	}
+	return r.references.lookup(attribute.Name, literal, identity)
+}
+
 // writeNestedValue writes a nested attribute: a single object, or a
 // list/set/map of objects.
 func (r *renderer) writeNestedValue(buf *strings.Builder, depth int, nested *tfprotov6.SchemaObject, value tftypes.Value, identity resourceIdentity, path []string) error {
@@ -0,0 +1,230 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ManifestFile is the name of the manifest every export writes beside its
+// configuration.
+const ManifestFile = "ably-export.json"
+
+// manifestVersion is bumped if the manifest's shape changes incompatibly.
+const manifestVersion = 1
+
+// Manifest records where an export put each resource, so the next export can
+// tell which addresses changed and which resources left the account.
+type Manifest struct {
+	Version   int             `json:"version"`
+	Resources []ManifestEntry `json:"resources"`
+}
+
+// ManifestEntry maps one resource, by Control API ID, to its Terraform address.
+type ManifestEntry struct {
+	ResourceType string `json:"type"`
+	ID           string `json:"id"`
+	// AppID is the owning app, empty for apps themselves.
+	AppID string `json:"app_id,omitempty"`
+	// Name is the resource's name where it has one, kept so a later export can
+	// apply its name filters to a resource it no longer finds.
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address"`
+}
+
+// ReadManifest loads the manifest a previous export wrote.
+func ReadManifest(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the previous manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing the previous manifest %s: %w", path, err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("%s is a version %d manifest; this exporter reads version %d",
+			path, manifest.Version, manifestVersion)
+	}
+	return &manifest, nil
+}
+
+// manifestKey identifies a resource across exports. Namespace IDs are only
+// unique within an app, so the app is part of it.
+type manifestKey struct {
+	resourceType string
+	appID        string
+	id           string
+}
+
+func (e ManifestEntry) key() manifestKey {
+	return manifestKey{e.ResourceType, e.AppID, e.ID}
+}
+
+func manifestFile(exports []exported) (File, error) {
+	manifest := Manifest{Version: manifestVersion, Resources: []ManifestEntry{}}
+	for _, export := range exports {
+		if !export.managed() {
+			continue
+		}
+		manifest.Resources = append(manifest.Resources, entryFor(export.target, export.label))
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return File{}, fmt.Errorf("encoding the manifest: %w", err)
+	}
+	return File{Name: ManifestFile, Content: append(content, '\n')}, nil
+}
+
+func entryFor(target Target, label string) ManifestEntry {
+	entry := ManifestEntry{
+		ResourceType: target.ResourceType,
+		ID:           target.ID,
+		Name:         target.Name,
+		Address:      target.ResourceType + "." + label,
+	}
+	if target.ResourceType != resourceTypeApp {
+		entry.AppID = target.AppID
+	}
+	return entry
+}
+
+// transition is what became of the resources in a previous export.
+type transition struct {
+	// moved pairs an old address with its new one.
+	moved [][2]string
+	// removed lists addresses whose resources have left the account.
+	removed []string
+	// outOfScope lists addresses this export's filters left out. They are
+	// still in the account, so they are neither moved nor removed.
+	outOfScope []string
+	// blocked pairs an old address with a new one that no moved block can
+	// carry it to: the new address is still held in state by another
+	// resource, as in a swap or a chain of renames, or the old one is now
+	// taken by another resource. Terraform rejects such moves.
+	blocked [][2]string
+	// occupied lists addresses whose resources have left the account but that
+	// a resource in this export now has, so a removed block would be rejected.
+	occupied []string
+}
+
+// compareManifest works out, for each resource in a previous export, whether it
+// kept its address, moved to a new one, or left the account.
+//
+// A resource is only taken to have left the account if this export would have
+// found it: its app is still there and kept, and the filters take its type and
+// name. Anything else is reported as out of scope, because a removed block for a
+// resource that still exists would quietly drop it from state.
+//
+// Moves and removals that collide with an address in use are set aside as
+// blocked or occupied rather than written, since Terraform would reject the
+// whole file over them.
+func compareManifest(prior *Manifest, found *discovery, filter *filter, exports []exported) transition {
+	current := map[manifestKey]string{}
+	for _, export := range exports {
+		if export.managed() {
+			entry := entryFor(export.target, export.label)
+			current[entry.key()] = entry.Address
+		}
+	}
+
+	keptApps := map[string]bool{}
+	for _, target := range found.Targets {
+		if target.ResourceType == resourceTypeApp {
+			keptApps[target.ID] = true
+		}
+	}
+
+	var result transition
+	var moved [][2]string
+	var removed []string
+	for _, entry := range prior.Resources {
+		if address, ok := current[entry.key()]; ok {
+			if address != entry.Address {
+				moved = append(moved, [2]string{entry.Address, address})
+			}
+			continue
+		}
+
+		appID := entry.AppID
+		if entry.ResourceType == resourceTypeApp {
+			appID = entry.ID
+		}
+
+		switch {
+		case !found.AccountApps[appID]:
+			removed = append(removed, entry.Address)
+		case !keptApps[appID] || !filter.wantsType(entry.ResourceType):
+			result.outOfScope = append(result.outOfScope, entry.Address)
+		case entry.ResourceType == resourceTypeKey && !filter.wantsKey(entry.Name):
+			result.outOfScope = append(result.outOfScope, entry.Address)
+		case entry.ResourceType == resourceTypeNamespace && !filter.wantsNamespace(entry.ID):
+			result.outOfScope = append(result.outOfScope, entry.Address)
+		default:
+			// Found nowhere this export looked, found revoked, which the
+			// provider reads as gone, or a dead letter queue, which is now
+			// read rather than managed. Either way it is only forgotten.
+			removed = append(removed, entry.Address)
+		}
+	}
+
+	held := map[string]bool{}
+	for _, entry := range prior.Resources {
+		held[entry.Address] = true
+	}
+	taken := map[string]bool{}
+	for _, address := range current {
+		taken[address] = true
+	}
+	for _, move := range moved {
+		if held[move[1]] || taken[move[0]] {
+			result.blocked = append(result.blocked, move)
+		} else {
+			result.moved = append(result.moved, move)
+		}
+	}
+	for _, address := range removed {
+		if taken[address] {
+			result.occupied = append(result.occupied, address)
+		} else {
+			result.removed = append(result.removed, address)
+		}
+	}
+
+	sort.Slice(result.moved, func(i, j int) bool { return result.moved[i][0] < result.moved[j][0] })
+	sort.Strings(result.removed)
+	sort.Strings(result.outOfScope)
+	sort.Slice(result.blocked, func(i, j int) bool { return result.blocked[i][0] < result.blocked[j][0] })
+	sort.Strings(result.occupied)
+	return result
+}
+
+func (t transition) empty() bool {
+	return len(t.moved) == 0 && len(t.removed) == 0
+}
+
+// movedFile holds the moved and removed blocks that carry state from a previous
+// export to this one.
+func movedFile(t transition) string {
+	var buf strings.Builder
+	buf.WriteString(fileHeader())
+	buf.WriteString(`
+# Moved and removed blocks carry Terraform state over from the previous export:
+# resources whose address changed, and resources no longer in the account.
+# Delete this file once they have been applied.
+`)
+
+	for _, move := range t.moved {
+		fmt.Fprintf(&buf, "\nmoved {\n  from = %s\n  to   = %s\n}\n", move[0], move[1])
+	}
+	for _, address := range t.removed {
+		// destroy = false: the resource is already gone, and this only forgets
+		// it rather than have Terraform try to delete it.
+		fmt.Fprintf(&buf, "\nremoved {\n  from = %s\n\n  lifecycle {\n    destroy = false\n  }\n}\n", address)
+	}
+	return buf.String()
+}
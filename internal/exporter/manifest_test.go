@@ -0,0 +1,222 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestRunWritesAManifest(t *testing.T) {
+	result, files := runExport(t, Config{IncludeRevoked: true})
+
+	var manifest Manifest
+	if err := json.Unmarshal([]byte(files[ManifestFile]), &manifest); err != nil {
+		t.Fatalf("parsing %s: %s\n%s", ManifestFile, err, files[ManifestFile])
+	}
+	if len(manifest.Resources) != result.Total {
+		t.Errorf("the manifest lists %d resources, want the %d exported", len(manifest.Resources), result.Total)
+	}
+	for _, want := range []ManifestEntry{
+		{ResourceType: "ably_app", ID: "app1", Name: "Chat Service", Address: "ably_app.chat_service"},
+		{ResourceType: "ably_namespace", ID: "chat", AppID: "app1", Name: "chat", Address: "ably_namespace.chat_service_chat"},
+	} {
+		if !slices.Contains(manifest.Resources, want) {
+			t.Errorf("the manifest is missing %+v:\n%s", want, files[ManifestFile])
+		}
+	}
+	// A revoked key is listed for review, not exported, so has no address.
+	for _, entry := range manifest.Resources {
+		if entry.ID == "key2" {
+			t.Errorf("the manifest lists the revoked key: %+v", entry)
+		}
+	}
+}
+
+func TestRunWithoutAPriorManifestWritesNoMovedBlocks(t *testing.T) {
+	_, files := runExport(t, Config{})
+	if _, ok := files["moved.tf"]; ok {
+		t.Errorf("moved.tf was generated without a previous manifest:\n%s", files["moved.tf"])
+	}
+}
+
+// TestRunMovesAndRemovesAgainstAPriorManifest covers the three fates of a
+// resource in a previous export: a new address, gone from the account, or merely
+// outside this export's filters.
+func TestRunMovesAndRemovesAgainstAPriorManifest(t *testing.T) {
+	prior := &Manifest{Version: manifestVersion, Resources: []ManifestEntry{
+		// Unchanged.
+		{ResourceType: "ably_namespace", ID: "chat", AppID: "app1", Address: "ably_namespace.chat_service_chat"},
+		// Renamed.
+		{ResourceType: "ably_app", ID: "app1", Address: "ably_app.chat"},
+		// Deleted from a kept app, and an app deleted outright.
+		{ResourceType: "ably_rule_http", ID: "rule9", AppID: "app1", Address: "ably_rule_http.old"},
+		{ResourceType: "ably_app", ID: "app9", Address: "ably_app.gone"},
+		// Exported once, since revoked, which the provider reads as gone.
+		{ResourceType: "ably_api_key", ID: "key2", AppID: "app1", Name: "revoked key", Address: "ably_api_key.revoked"},
+		// Still there, but filtered out of this export.
+		{ResourceType: "ably_queue", ID: "queue1", AppID: "app1", Address: "ably_queue.orders"},
+	}}
+
+	result, files := runExport(t, Config{PriorManifest: prior, ExcludeTypes: []string{"ably_queue"}})
+
+	if want := []string{"ably_app.chat -> ably_app.chat_service"}; !slices.Equal(result.Moved, want) {
+		t.Errorf("Moved = %v, want %v", result.Moved, want)
+	}
+	if want := []string{"ably_api_key.revoked", "ably_app.gone", "ably_rule_http.old"}; !slices.Equal(result.Removed, want) {
+		t.Errorf("Removed = %v, want %v", result.Removed, want)
+	}
+
+	moved, ok := files["moved.tf"]
+	if !ok {
+		t.Fatalf("no moved.tf was generated, got %v", fileNames(files))
+	}
+	if _, diagnostics := hclparse.NewParser().ParseHCL([]byte(moved), "moved.tf"); diagnostics.HasErrors() {
+		t.Fatalf("moved.tf does not parse: %s\n%s", diagnostics, moved)
+	}
+	for _, want := range []string{
+		"from = ably_app.chat\n  to   = ably_app.chat_service",
+		"from = ably_app.gone",
+		"destroy = false",
+	} {
+		if !strings.Contains(moved, want) {
+			t.Errorf("moved.tf is missing %q:\n%s", want, moved)
+		}
+	}
+	// A removed block for a resource that still exists would drop it from state.
+	if strings.Contains(moved, "ably_queue.orders") || strings.Contains(moved, "chat_service_chat") {
+		t.Errorf("moved.tf mentions a resource that neither moved nor left:\n%s", moved)
+	}
+
+	var warned bool
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "ably_queue.orders") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning named the filtered-out queue, warnings were %v", result.Warnings)
+	}
+}
+
+// TestCompareManifestSetsAsideCollisions covers the moves and removals
+// Terraform rejects: addresses swapped or chained between resources, and a
+// removed block for an address a new resource has taken.
+func TestCompareManifestSetsAsideCollisions(t *testing.T) {
+	namespace := func(id, label string) exported {
+		return exported{target: Target{ResourceType: resourceTypeNamespace, ID: id, AppID: "app1", Name: id}, label: label}
+	}
+	prior := &Manifest{Version: manifestVersion, Resources: []ManifestEntry{
+		{ResourceType: "ably_app", ID: "app1", Address: "ably_app.chat"},
+		// Swapped.
+		{ResourceType: "ably_namespace", ID: "a", AppID: "app1", Address: "ably_namespace.x"},
+		{ResourceType: "ably_namespace", ID: "b", AppID: "app1", Address: "ably_namespace.y"},
+		// Chained: d takes e's address as e moves on.
+		{ResourceType: "ably_namespace", ID: "d", AppID: "app1", Address: "ably_namespace.p"},
+		{ResourceType: "ably_namespace", ID: "e", AppID: "app1", Address: "ably_namespace.q"},
+		// Renamed, with nothing in the way.
+		{ResourceType: "ably_namespace", ID: "f", AppID: "app1", Address: "ably_namespace.s"},
+		// Deleted, once with its address taken by a new namespace.
+		{ResourceType: "ably_namespace", ID: "gone", AppID: "app1", Address: "ably_namespace.u"},
+		{ResourceType: "ably_namespace", ID: "gone2", AppID: "app1", Address: "ably_namespace.v"},
+	}}
+	exports := []exported{
+		{target: Target{ResourceType: resourceTypeApp, ID: "app1"}, label: "chat"},
+		namespace("a", "y"),
+		namespace("b", "x"),
+		namespace("d", "q"),
+		namespace("e", "r"),
+		namespace("f", "t"),
+		namespace("g", "u"),
+	}
+	found := &discovery{
+		Targets:     []Target{{ResourceType: resourceTypeApp, ID: "app1"}},
+		AccountApps: map[string]bool{"app1": true},
+	}
+
+	transition := compareManifest(prior, found, &filter{}, exports)
+
+	if want := [][2]string{{"ably_namespace.s", "ably_namespace.t"}}; !slices.Equal(transition.moved, want) {
+		t.Errorf("moved = %v, want %v", transition.moved, want)
+	}
+	wantBlocked := [][2]string{
+		{"ably_namespace.p", "ably_namespace.q"},
+		{"ably_namespace.q", "ably_namespace.r"},
+		{"ably_namespace.x", "ably_namespace.y"},
+		{"ably_namespace.y", "ably_namespace.x"},
+	}
+	if !slices.Equal(transition.blocked, wantBlocked) {
+		t.Errorf("blocked = %v, want %v", transition.blocked, wantBlocked)
+	}
+	if want := []string{"ably_namespace.v"}; !slices.Equal(transition.removed, want) {
+		t.Errorf("removed = %v, want %v", transition.removed, want)
+	}
+	if want := []string{"ably_namespace.u"}; !slices.Equal(transition.occupied, want) {
+		t.Errorf("occupied = %v, want %v", transition.occupied, want)
+	}
+
+	moved := movedFile(transition)
+	for _, address := range []string{"ably_namespace.p", "ably_namespace.q", "ably_namespace.x", "ably_namespace.y", "ably_namespace.u"} {
+		if strings.Contains(moved, address+"\n") {
+			t.Errorf("moved.tf has a block for %s:\n%s", address, moved)
+		}
+	}
+}
+
+func TestRunWarnsAboutARemovedBlockForATakenAddress(t *testing.T) {
+	prior := &Manifest{Version: manifestVersion, Resources: []ManifestEntry{
+		{ResourceType: "ably_namespace", ID: "old", AppID: "app1", Name: "old", Address: "ably_namespace.chat_service_chat"},
+	}}
+
+	result, files := runExport(t, Config{PriorManifest: prior})
+
+	if len(result.Removed) != 0 {
+		t.Errorf("Removed = %v, want the taken address left out", result.Removed)
+	}
+	if moved, ok := files["moved.tf"]; ok {
+		t.Errorf("moved.tf was generated for a taken address:\n%s", moved)
+	}
+	var warned bool
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "ably_namespace.chat_service_chat has left the account") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning named the taken address, warnings were %v", result.Warnings)
+	}
+}
+
+func TestReadManifestRoundTrips(t *testing.T) {
+	directory := t.TempDir()
+	result, _ := runExport(t, Config{})
+	if err := WriteFiles(directory, result, false); err != nil {
+		t.Fatalf("WriteFiles: %s", err)
+	}
+
+	manifest, err := ReadManifest(filepath.Join(directory, ManifestFile))
+	if err != nil {
+		t.Fatalf("ReadManifest: %s", err)
+	}
+
+	// Against its own manifest, an unchanged account moves and removes nothing.
+	again, files := runExport(t, Config{PriorManifest: manifest})
+	if len(again.Moved) != 0 || len(again.Removed) != 0 {
+		t.Errorf("re-exporting an unchanged account moved %v and removed %v", again.Moved, again.Removed)
+	}
+	if _, ok := files["moved.tf"]; ok {
+		t.Error("re-exporting an unchanged account generated moved.tf")
+	}
+
+	future := filepath.Join(directory, "future.json")
+	if err := os.WriteFile(future, []byte(`{"version": 99, "resources": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadManifest(future); err == nil {
+		t.Error("a manifest of an unknown version should fail")
+	}
+}
@@ -2,9 +2,16 @@ package exporter
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
 	"strings"
+	"text/template"
 	"unicode"
 	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
 )
 
 // resourceRef identifies an exported resource by type and Control API ID.
@@ -20,13 +27,82 @@ type resourceRef struct {
 // It is an allowlist, not a rule like "anything ending in _id", because plenty of
 // attributes hold unrelated identifiers (a Bodyguard channel_id, an Azure app ID)
 // that would be rewritten into nonsense on an ID collision. Pairing with the type
-// also stops a namespace ID being read as a queue ID.
+// also stops a namespace ID being read as a queue ID. Key and queue values are
+// opaque enough to match anywhere; see referenceIndex.
 var referenceableAttributes = map[string]string{
 	"app_id":         resourceTypeApp,
 	"queue_id":       resourceTypeQueue,
 	"signing_key_id": resourceTypeKey,
 }
 
+// referenceIndex maps values found in rendered state onto the HCL expressions
+// that refer to the resources owning them, so the config carries its
+// dependencies and is portable between accounts.
+type referenceIndex struct {
+	// ids holds every exported resource by type and ID, for
+	// referenceableAttributes.
+	ids map[resourceRef]string
+	// literals holds values opaque enough to be matched in any string
+	// attribute: API key IDs, key strings and queue IDs. All three are random
+	// or carry an app ID, so an accidental match is not a concern. Queue names
+	// are not indexed: no rule attribute holds one (an SQS queue_name names an
+	// AWS queue), and as ordinary words they would match by accident.
+	literals map[string]string
+}
+
+// newReferenceIndex indexes the targets that exported accepts. A reference to
+// anything else, filtered out or revoked, would point at a resource missing
+// from the config, so those values stay literal.
+func newReferenceIndex(targets []Target, labels []string, exported func(Target) bool) *referenceIndex {
+	index := &referenceIndex{
+		ids:      map[resourceRef]string{},
+		literals: map[string]string{},
+	}
+
+	for position, target := range targets {
+		if !exported(target) {
+			continue
+		}
+		address := target.ResourceType + "." + labels[position]
+		if target.DeadletterOf != "" {
+			address = "data." + dataSourceTypeQueueDeadletter + "." + labels[position]
+		}
+		index.ids[resourceRef{target.ResourceType, target.ID}] = address + ".id"
+
+		switch target.ResourceType {
+		case resourceTypeKey:
+			index.literals[target.ID] = address + ".id"
+			if target.Key != "" {
+				index.literals[target.Key] = address + ".key"
+			}
+		case resourceTypeQueue:
+			index.literals[target.ID] = address + ".id"
+		}
+	}
+	return index
+}
+
+// lookup returns the reference for a string value of the named attribute, if
+// one applies. It never returns a reference to the resource being written, which
+// Terraform would reject as a cycle.
+func (index *referenceIndex) lookup(attribute, value string, self resourceIdentity) (string, bool) {
+	if index == nil || value == "" {
+		return "", false
+	}
+
+	expression, ok := "", false
+	if resourceType, referenceable := referenceableAttributes[attribute]; referenceable {
+		expression, ok = index.ids[resourceRef{resourceType, value}]
+	}
+	if !ok {
+		expression, ok = index.literals[value]
+	}
+	if !ok || strings.HasPrefix(expression, self.address+".") {
+		return "", false
+	}
+	return expression, true
+}
+
 // labeller hands out unique HCL resource labels, derived from Ably names rather
 // than IDs because the output is meant to be read. Same-type collisions get a
 // numeric suffix in discovery order, which is deterministic because discovery
@@ -54,6 +130,12 @@ func (l *labeller) assign(resourceType, base string) string {
 	}
 }
 
+// reserve claims a label chosen by hand, before any are generated, so a
+// generated label never takes it. It fails if the label is already taken.
+func (l *labeller) reserve(resourceType, label string) bool {
+	return l.claim(resourceType, label)
+}
+
 func (l *labeller) claim(resourceType, label string) bool {
 	key := resourceType + "." + label
 	if l.used[key] {
@@ -93,9 +175,9 @@ func sanitiseLabel(name string) string {
 	return label
 }
 
-// labelFor builds the label base for a target. Named resources use their name;
-// rules have none, so they take their app's label and a numeric suffix when an
-// app has several of the same type.
+// labelFor builds the label base for a target. Named resources use their name.
+// Rules have none, so they take their app's label and what they deliver to, the
+// stream, topic or host, with a numeric suffix when that still collides.
 func labelFor(target Target, appLabel string) string {
 	switch {
 	case target.ResourceType == resourceTypeApp:
@@ -106,6 +188,187 @@ func labelFor(target Target, appLabel string) string {
 	case target.Name != "":
 		return appLabel + "_" + sanitiseLabel(target.Name)
 	default:
+		if destination := ruleDestination(target.RuleTarget); destination != "" {
+			return appLabel + "_" + sanitiseLabel(destination)
+		}
 		return appLabel
 	}
 }
+
+// destinationFields are the rule target fields that name where a rule delivers,
+// in order of preference. The first one a rule has becomes part of its label.
+var destinationFields = []string{
+	"streamName",
+	"deliveryStreamName",
+	"topic",
+	"queueName",
+	"functionName",
+	"routingKey",
+}
+
+// ruleDestination names where a rule delivers, from its Control API target: a
+// stream, topic, queue or function name, or failing those the host of its URL.
+// Empty if the target has none of them.
+func ruleDestination(target map[string]any) string {
+	for _, field := range destinationFields {
+		if value, ok := target[field].(string); ok && strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	if value, ok := target["url"].(string); ok {
+		return urlHost(value)
+	}
+	return ""
+}
+
+// urlHost returns the host of a URL without its port, or empty if it has none.
+func urlHost(value string) string {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// namer decides the label base for each target, applying the -naming templates
+// and the override file on top of labelFor.
+type namer struct {
+	templates []namingTemplate
+	// overrides maps a Control API ID, or "app_id,id" for resources whose IDs
+	// are only unique within an app, to a chosen label.
+	overrides map[string]string
+}
+
+// namingTemplate is a label template for the resource types matching pattern.
+type namingTemplate struct {
+	pattern  string
+	types    *regexp.Regexp
+	template *template.Template
+}
+
+// labelData is what a naming template sees.
+type labelData struct {
+	// App is the owning app's label, or for an app its default label.
+	App string
+	// AppName is the owning app's Ably name.
+	AppName  string
+	Name     string
+	ID       string
+	Type     string
+	RuleType string
+	// Target is a rule's Control API target, e.g. .Target.url. Empty for
+	// anything but a rule.
+	Target map[string]any
+}
+
+// namingFuncs are the functions available in naming templates.
+var namingFuncs = template.FuncMap{
+	"host":  func(value any) string { return urlHost(fmt.Sprint(value)) },
+	"base":  func(value any) string { return path.Base(fmt.Sprint(value)) },
+	"lower": func(value any) string { return strings.ToLower(fmt.Sprint(value)) },
+}
+
+// newNamer compiles the naming templates in a config and checks its overrides.
+//
+// A template is "TYPE_GLOB=TEMPLATE", or a bare template for every type. The
+// first template whose glob matches a resource's type applies. A missing field
+// is an error rather than "<no value>", so the resource falls back to its
+// default label instead of getting a nonsense one.
+func newNamer(config Config) (*namer, error) {
+	n := &namer{overrides: config.LabelOverrides}
+
+	for _, spec := range config.Naming {
+		pattern, text := "*", spec
+		if before, after, found := strings.Cut(spec, "="); found && !strings.Contains(before, "{{") {
+			pattern, text = strings.TrimSpace(before), after
+		}
+
+		types, err := compileTypePatterns([]string{pattern}, "-naming")
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := template.New(pattern).Funcs(namingFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("-naming %q: %w", spec, err)
+		}
+		n.templates = append(n.templates, namingTemplate{
+			pattern:  pattern,
+			types:    types[0],
+			template: parsed,
+		})
+	}
+
+	for id, label := range n.overrides {
+		if sanitiseLabel(label) != label {
+			return nil, fmt.Errorf("label override for %s: %q is not a valid label; try %q", id, label, sanitiseLabel(label))
+		}
+	}
+	return n, nil
+}
+
+// ReadLabelOverrides loads a YAML file mapping Control API IDs to labels:
+//
+//	app1: chat
+//	key1: chat_root
+//	app1,chat: chat_channels   # a namespace, whose ID is only unique per app
+func ReadLabelOverrides(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading label overrides: %w", err)
+	}
+	overrides := map[string]string{}
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing label overrides %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// override returns the chosen label for a target, if the override file has one,
+// and the key it was found under.
+func (n *namer) override(target Target) (key, label string, ok bool) {
+	if target.AppID != "" {
+		key = target.AppID + "," + target.ID
+		if label, ok = n.overrides[key]; ok {
+			return key, label, true
+		}
+	}
+	label, ok = n.overrides[target.ID]
+	return target.ID, label, ok
+}
+
+// base returns the label base for a target: the first matching template's
+// output, or labelFor's. A template that fails for this target, or produces
+// nothing, falls back with a warning.
+func (n *namer) base(target Target, appLabel string) (string, string) {
+	fallback := labelFor(target, appLabel)
+	for _, naming := range n.templates {
+		if !naming.types.MatchString(target.ResourceType) {
+			continue
+		}
+
+		data := labelData{
+			App:      appLabel,
+			AppName:  target.AppName,
+			Name:     target.Name,
+			ID:       target.ID,
+			Type:     target.ResourceType,
+			RuleType: target.RuleType,
+			Target:   target.RuleTarget,
+		}
+		if target.ResourceType == resourceTypeApp {
+			data.App = sanitiseLabel(fallback)
+		}
+
+		var buf strings.Builder
+		if err := naming.template.Execute(&buf, data); err != nil {
+			return fallback, fmt.Sprintf("%s %s: naming template for %s failed, so it keeps its default label: %s",
+				target.ResourceType, target.ID, naming.pattern, err)
+		}
+		if strings.TrimSpace(buf.String()) == "" {
+			return fallback, fmt.Sprintf("%s %s: naming template for %s produced an empty label, so it keeps its default one",
+				target.ResourceType, target.ID, naming.pattern)
+		}
+		return buf.String(), ""
+	}
+	return fallback, ""
+}
@@ -0,0 +1,190 @@
+package exporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRuleDestination(t *testing.T) {
+	for _, test := range []struct {
+		target map[string]any
+		want   string
+	}{
+		{map[string]any{"streamName": "orders-stream", "region": "eu-west-1"}, "orders-stream"},
+		{map[string]any{"topic": "events", "serviceUrl": "pulsar://broker:6650"}, "events"},
+		{map[string]any{"url": "https://hooks.example.com:8443/ably?x=1"}, "hooks.example.com"},
+		// A named destination beats the URL it is reached through.
+		{map[string]any{"url": "amqps://broker.example", "routingKey": "orders"}, "orders"},
+		{map[string]any{"queueId": "queue1"}, ""},
+		{map[string]any{"streamName": "  "}, ""},
+		{nil, ""},
+	} {
+		if got := ruleDestination(test.target); got != test.want {
+			t.Errorf("ruleDestination(%v) = %q, want %q", test.target, got, test.want)
+		}
+	}
+}
+
+func TestRunNamesWithTemplates(t *testing.T) {
+	result, files := runExport(t, Config{Naming: []string{
+		"ably_rule_http={{.App}}_{{.RuleType}}_{{.Target.url | host}}",
+		// .Target.url is missing for a queue rule, so it falls back.
+		"ably_rule_amqp={{.Target.url | host}}",
+		"ably_api_key=key_{{.Name}}",
+	}})
+
+	config := files["app_chat_service.tf"]
+	for _, want := range []string{
+		`resource "ably_rule_http" "chat_service_http_example_com" {`,
+		`resource "ably_api_key" "key_root_key" {`,
+		// No template for these, so the default applies.
+		`resource "ably_app" "chat_service" {`,
+		`resource "ably_rule_kafka" "chat_service_key" {`,
+		`resource "ably_rule_amqp" "chat_service" {`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("generated config is missing %s:\n%s", want, config)
+		}
+	}
+
+	var warned bool
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "rule2") && strings.Contains(warning, "default label") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning for the template that failed, warnings were %v", result.Warnings)
+	}
+}
+
+func TestRunRejectsBadNamingTemplates(t *testing.T) {
+	fake := newFakeControlAPI(t)
+	for _, naming := range []string{
+		"ably_rules_*={{.Name}}",
+		"ably_app={{.Name",
+	} {
+		if _, err := Run(context.Background(), Config{Token: "fake-token", URL: fake.url(), Naming: []string{naming}}); err == nil {
+			t.Errorf("-naming %q should fail", naming)
+		}
+	}
+}
+
+func TestRunAppliesLabelOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels.yaml")
+	content := "app1: chat\napp1,chat: chat_channels\nrule1: chat_service_example_com_2\nnowhere: unused\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	overrides, err := ReadLabelOverrides(path)
+	if err != nil {
+		t.Fatalf("ReadLabelOverrides: %s", err)
+	}
+
+	result, files := runExport(t, Config{LabelOverrides: overrides})
+
+	// The app's override carries through to its file and its children's labels.
+	config, ok := files["app_chat.tf"]
+	if !ok {
+		t.Fatalf("no app_chat.tf was generated, got %v", fileNames(files))
+	}
+	for _, want := range []string{
+		`resource "ably_app" "chat" {`,
+		`resource "ably_namespace" "chat_channels" {`,
+		`resource "ably_api_key" "chat_root_key" {`,
+		`resource "ably_rule_http" "chat_service_example_com_2" {`,
+		"app_id = ably_app.chat.id",
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("generated config is missing %s:\n%s", want, config)
+		}
+	}
+
+	var warned bool
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "nowhere") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning for the override that matched nothing, warnings were %v", result.Warnings)
+	}
+
+	fake := newFakeControlAPI(t)
+	if _, err := Run(context.Background(), Config{
+		Token: "fake-token", URL: fake.url(), LabelOverrides: map[string]string{"app1": "Chat App"},
+	}); err == nil {
+		t.Error("an override that is not a valid label should fail")
+	}
+}
+
+// TestRunRefersToKeyStrings covers a rule using an exported key as a
+// credential: the key string becomes a reference rather than a copied secret.
+func TestRunRefersToKeyStrings(t *testing.T) {
+	fake := newFakeControlAPIWith(t, func(rules map[string]map[string]any) {
+		target := rules["rule1"]["target"].(map[string]any)
+		target["headers"] = []any{map[string]any{"name": "Authorization", "value": "app1.key1:secret"}}
+	})
+
+	result, err := Run(context.Background(), Config{Token: "fake-token", URL: fake.url(), Secrets: SecretsVars})
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	var config string
+	for _, file := range result.Files {
+		if file.Name == "app_chat_service.tf" {
+			config = string(file.Content)
+		}
+	}
+
+	assertAssigns(t, config, "value", "ably_api_key.chat_service_root_key.key")
+	if strings.Contains(config, "app1.key1:secret") {
+		t.Errorf("the key string was copied into the config:\n%s", config)
+	}
+}
+
+func TestReferenceIndexLookup(t *testing.T) {
+	targets := []Target{
+		{ResourceType: resourceTypeApp, ID: "app1", Name: "One"},
+		{ResourceType: resourceTypeKey, ID: "key1", AppID: "app1", Name: "root", Key: "app1.key1:secret"},
+		{ResourceType: resourceTypeQueue, ID: "app1:us-east-1-a:orders", AppID: "app1", Name: "orders"},
+		{ResourceType: resourceTypeQueue, ID: "app1:us-east-1-a:audit", AppID: "app1", Name: "audit"},
+		{ResourceType: resourceTypeApp, ID: "app2", Name: "Two"},
+		{ResourceType: resourceTypeQueue, ID: "app2:us-east-1-a:audit", AppID: "app2", Name: "audit"},
+		{ResourceType: resourceTypeKey, ID: "key9", AppID: "app2", Name: "filtered", Key: "app2.key9:secret"},
+	}
+	labels := []string{"one", "one_root", "one_orders", "one_audit", "two", "two_audit", "two_filtered"}
+	index := newReferenceIndex(targets, labels, func(target Target) bool { return target.ID != "key9" })
+	rule := identityOf("ably_rule_http", "one")
+
+	for _, test := range []struct {
+		attribute string
+		value     string
+		self      resourceIdentity
+		want      string
+	}{
+		{"app_id", "app1", rule, "ably_app.one.id"},
+		// Opaque values match in any attribute.
+		{"value", "app1.key1:secret", rule, "ably_api_key.one_root.key"},
+		{"routing_key", "app1:us-east-1-a:orders", rule, "ably_queue.one_orders.id"},
+		{"client_id", "key1", rule, "ably_api_key.one_root.id"},
+		// Queue names never match: an SQS rule's queue_name names an AWS
+		// queue, which may share a name with an Ably one.
+		{"queue_name", "orders", identityOf("ably_rule_sqs", "one"), ""},
+		{"topic", "orders", rule, ""},
+		// App IDs only match in app_id, as before.
+		{"description", "app1", rule, ""},
+		// Filtered-out resources stay literal.
+		{"value", "app2.key9:secret", rule, ""},
+		// Never a reference to the resource being written.
+		{"queue_id", "app1:us-east-1-a:orders", identityOf(resourceTypeQueue, "one_orders"), ""},
+	} {
+		got, _ := index.lookup(test.attribute, test.value, test.self)
+		if got != test.want {
+			t.Errorf("lookup(%s = %q) = %q, want %q", test.attribute, test.value, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Names the secrets modes write into the configuration, and where the secret
+// manager modes look unless told otherwise.
+const (
+	vaultDataSource    = "data.vault_kv_secret_v2.ably"
+	awsSecretsLocal    = "local.ably_secrets"
+	defaultSecretName  = "ably-export"
+	defaultVaultMount  = "secret"
+	secretsEnvExample  = "secrets.env.example"
+	secretsBackendFile = "secrets.tf"
+	variablesFileName  = "variables.tf"
+)
+
+// supplyHint tells the user where a value the export could not capture goes.
+func (m SecretMode) supplyHint() string {
+	switch m {
+	case SecretsEnv:
+		return "supply it as a TF_VAR_ variable, see " + secretsEnvExample
+	case SecretsVault:
+		return "add it to the Vault secret read in " + secretsBackendFile
+	case SecretsAWSSecretsManager:
+		return "add it to the AWS secret read in " + secretsBackendFile
+	default:
+		return "supply it in " + variablesFileName
+	}
+}
+
+// providerSource is the extra provider a mode's lookups need, if any.
+func (m SecretMode) providerSource() (name, source string) {
+	switch m {
+	case SecretsVault:
+		return "vault", "hashicorp/vault"
+	case SecretsAWSSecretsManager:
+		return "aws", "hashicorp/aws"
+	default:
+		return "", ""
+	}
+}
+
+// secretLookup is the expression that reads a replaced value back. A secret
+// manager returns strings, so numbers and bools are converted.
+func (r *renderer) secretLookup(name string, attribute *tfprotov6.SchemaAttribute) string {
+	var expression string
+	switch r.secrets {
+	case SecretsVault:
+		expression = fmt.Sprintf("%s.data[%s]", vaultDataSource, quoteString(name))
+	case SecretsAWSSecretsManager:
+		expression = fmt.Sprintf("%s[%s]", awsSecretsLocal, quoteString(name))
+	default:
+		return "var." + name
+	}
+
+	switch {
+	case attribute.Type.Is(tftypes.Number):
+		return "tonumber(" + expression + ")"
+	case attribute.Type.Is(tftypes.Bool):
+		return "tobool(" + expression + ")"
+	default:
+		return expression
+	}
+}
+
+// capture records a replaced value for -secrets-out, as the string a secret
+// manager would hold.
+func (r *renderer) capture(name string, value tftypes.Value, path []string) error {
+	var literal string
+	switch {
+	case value.Type().Is(tftypes.String):
+		if err := value.As(&literal); err != nil {
+			return fmt.Errorf("decoding string at %s: %w", pathString(path), err)
+		}
+	case value.Type().Is(tftypes.Bool):
+		var flag bool
+		if err := value.As(&flag); err != nil {
+			return fmt.Errorf("decoding bool at %s: %w", pathString(path), err)
+		}
+		literal = strconv.FormatBool(flag)
+	case value.Type().Is(tftypes.Number):
+		number := new(big.Float)
+		if err := value.As(&number); err != nil {
+			return fmt.Errorf("decoding number at %s: %w", pathString(path), err)
+		}
+		literal = formatNumber(number)
+	default:
+		return nil
+	}
+	r.captured[name] = literal
+	return nil
+}
+
+// secretsBackend is secrets.tf for the secret manager modes: where the secret
+// lives, as variables with defaults, and the data source reading it.
+func secretsBackend(mode SecretMode) string {
+	var buf strings.Builder
+	buf.WriteString(fileHeader())
+
+	switch mode {
+	case SecretsVault:
+		fmt.Fprintf(&buf, `
+# Sensitive values are read from one Vault KV v2 secret, keyed by the names
+# used in the configuration. Seed it with the file -secrets-out wrote:
+#   vault kv put -mount=%[1]s %[2]s @secrets.json
+# The values are held in Terraform state, as any data source's are.
+
+variable "ably_secrets_vault_mount" {
+  description = "Mount of the KV v2 secrets engine holding the Ably secrets."
+  type        = string
+  default     = %[3]s
+}
+
+variable "ably_secrets_vault_name" {
+  description = "Name of the Vault secret holding the Ably secrets."
+  type        = string
+  default     = %[4]s
+}
+
+data "vault_kv_secret_v2" "ably" {
+  mount = var.ably_secrets_vault_mount
+  name  = var.ably_secrets_vault_name
+}
+`, defaultVaultMount, defaultSecretName, quoteString(defaultVaultMount), quoteString(defaultSecretName))
+
+	case SecretsAWSSecretsManager:
+		fmt.Fprintf(&buf, `
+# Sensitive values are read from one AWS Secrets Manager secret holding a JSON
+# object, keyed by the names used in the configuration. Seed it with the file
+# -secrets-out wrote:
+#   aws secretsmanager create-secret --name %[1]s --secret-string file://secrets.json
+# The values are held in Terraform state, as any data source's are.
+
+variable "ably_secrets_aws_secret_id" {
+  description = "Name or ARN of the AWS secret holding the Ably secrets."
+  type        = string
+  default     = %[2]s
+}
+
+data "aws_secretsmanager_secret_version" "ably" {
+  secret_id = var.ably_secrets_aws_secret_id
+}
+
+locals {
+  ably_secrets = jsondecode(data.aws_secretsmanager_secret_version.ably.secret_string)
+}
+`, defaultSecretName, quoteString(defaultSecretName))
+	}
+
+	return buf.String()
+}
+
+// envExample lists the TF_VAR_ variables -secrets=env expects, without values.
+// It is not Terraform, so it carries a shell comment rather than the file header.
+func envExample(variables []variableDecl) string {
+	var buf strings.Builder
+	buf.WriteString("# Generated by the Ably Terraform exporter.\n")
+	buf.WriteString("# Copy this file, fill in the values, and source it before running Terraform.\n")
+	buf.WriteString("# Do not commit the filled-in copy.\n")
+
+	seen := map[string]bool{}
+	for _, variable := range sortedVariables(variables) {
+		if seen[variable.Name] {
+			continue
+		}
+		seen[variable.Name] = true
+		fmt.Fprintf(&buf, "\n# %s\nexport TF_VAR_%s=\n", variable.Description, variable.Name)
+	}
+	return buf.String()
+}
+
+func sortedVariables(variables []variableDecl) []variableDecl {
+	sorted := make([]variableDecl, len(variables))
+	copy(sorted, variables)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// WriteSecrets writes the sensitive values an export replaced with lookups to
+// path, as a flat JSON object of name to value that `vault kv put` and
+// `aws secretsmanager` both load as it is. Values the Control API withheld are
+// not in it; Result.Missing lists them.
+//
+// The file is created 0600 and never overwritten, so a second export cannot
+// clobber values someone has since added by hand.
+func WriteSecrets(path string, result *Result) error {
+	content, err := json.MarshalIndent(result.captured, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding secrets: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists; move it aside, it is never overwritten", path)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if _, err := file.Write(append(content, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// TestRunSecretManagerModes checks the vault and aws-sm modes against a
+// file-based stand-in for the manager: the -secrets-out file. Every lookup in
+// the config has to find its value there, unless the Control API withheld it.
+func TestRunSecretManagerModes(t *testing.T) {
+	for _, test := range []struct {
+		mode     SecretMode
+		provider string
+		lookup   *regexp.Regexp
+	}{
+		{SecretsVault, `source = "hashicorp/vault"`, regexp.MustCompile(`data\.vault_kv_secret_v2\.ably\.data\["([a-z0-9_]+)"\]`)},
+		{SecretsAWSSecretsManager, `source = "hashicorp/aws"`, regexp.MustCompile(`local\.ably_secrets\["([a-z0-9_]+)"\]`)},
+	} {
+		t.Run(string(test.mode), func(t *testing.T) {
+			result, files := runExport(t, Config{Secrets: test.mode})
+
+			if !strings.Contains(files["provider.tf"], test.provider) {
+				t.Errorf("provider.tf does not require the secret manager's provider:\n%s", files["provider.tf"])
+			}
+			if _, ok := files["variables.tf"]; ok {
+				t.Errorf("variables.tf was generated in %s mode", test.mode)
+			}
+			backend, ok := files["secrets.tf"]
+			if !ok {
+				t.Fatalf("no secrets.tf was generated, got %v", fileNames(files))
+			}
+			if _, diagnostics := hclparse.NewParser().ParseHCL([]byte(backend), "secrets.tf"); diagnostics.HasErrors() {
+				t.Fatalf("secrets.tf does not parse: %s\n%s", diagnostics, backend)
+			}
+
+			path := filepath.Join(t.TempDir(), "secrets.json")
+			if err := WriteSecrets(path, result); err != nil {
+				t.Fatalf("WriteSecrets: %s", err)
+			}
+			secrets := readSecrets(t, path)
+			if secrets[`rule_bodyguard_chat_service_target_api_key`] != "bodyguard-secret" {
+				t.Errorf("the captured secrets are missing the Bodyguard API key: %v", secrets)
+			}
+
+			config := files["app_chat_service.tf"]
+			matches := test.lookup.FindAllStringSubmatch(config, -1)
+			if len(matches) == 0 {
+				t.Fatalf("no secret manager lookups in:\n%s", config)
+			}
+			for _, match := range matches {
+				if _, ok := secrets[match[1]]; ok {
+					continue
+				}
+				if !listsMissing(result.Missing, match[1]) {
+					t.Errorf("%s is looked up but neither captured nor reported missing", match[1])
+				}
+			}
+			if strings.Contains(config, "bodyguard-secret") {
+				t.Errorf("a secret was written into the config:\n%s", config)
+			}
+		})
+	}
+}
+
+// listsMissing reports whether a lookup name belongs to a value reported
+// missing. Missing entries are `address.path (reason)`; the name is the same with
+// the ably_ prefix dropped and dots as underscores.
+func listsMissing(missing []string, name string) bool {
+	for _, entry := range missing {
+		attribute, _, _ := strings.Cut(entry, " ")
+		if sanitiseLabel(strings.TrimPrefix(attribute, "ably_")) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRunSecretsEnv(t *testing.T) {
+	_, files := runExport(t, Config{Secrets: SecretsEnv})
+
+	variables, ok := files["variables.tf"]
+	if !ok {
+		t.Fatalf("env mode generated no variables.tf, got %v", fileNames(files))
+	}
+	example, ok := files["secrets.env.example"]
+	if !ok {
+		t.Fatalf("env mode generated no secrets.env.example, got %v", fileNames(files))
+	}
+
+	declared := regexp.MustCompile(`variable "([a-z0-9_]+)"`).FindAllStringSubmatch(variables, -1)
+	if len(declared) == 0 {
+		t.Fatalf("no variables declared:\n%s", variables)
+	}
+	for _, variable := range declared {
+		if !strings.Contains(example, "export TF_VAR_"+variable[1]+"=\n") {
+			t.Errorf("secrets.env.example does not list %s:\n%s", variable[1], example)
+		}
+	}
+	if strings.Contains(example, "bodyguard-secret") {
+		t.Errorf("secrets.env.example carries a value:\n%s", example)
+	}
+}
+
+func TestWriteSecretsIsPrivateAndNeverOverwrites(t *testing.T) {
+	result, _ := runExport(t, Config{Secrets: SecretsVars})
+	path := filepath.Join(t.TempDir(), "secrets.json")
+
+	if err := WriteSecrets(path, result); err != nil {
+		t.Fatalf("WriteSecrets: %s", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("secrets file mode = %o, want 600", mode)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"edited": "by hand"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteSecrets(path, result); err == nil {
+		t.Error("WriteSecrets overwrote an existing file")
+	}
+	if secrets := readSecrets(t, path); secrets["edited"] != "by hand" {
+		t.Errorf("the existing file was changed: %v", secrets)
+	}
+}
+
+func readSecrets(t *testing.T, path string) map[string]string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secrets := map[string]string{}
+	if err := json.Unmarshal(content, &secrets); err != nil {
+		t.Fatalf("parsing %s: %s\n%s", path, err, content)
+	}
+	return secrets
+}
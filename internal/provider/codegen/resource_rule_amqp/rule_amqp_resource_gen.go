@@ -0,0 +1,1446 @@
+// Code generated by terraform-plugin-framework-generator DO NOT EDIT.
+
+package resource_rule_amqp
+
+import (
+	"context"
+	"fmt"
+	"github.com/ably/terraform-provider-ably/internal/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func RuleAmqpResourceSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The Ably application ID.",
+				MarkdownDescription: "The Ably application ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The rule ID.",
+				MarkdownDescription: "The rule ID.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"request_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Single request mode sends each event separately to the endpoint specified by the rule. You can read more about single request mode events in the <a href=\"https://ably.com/docs/general/webhooks#batching\">batching docs</a>.",
+				MarkdownDescription: "Single request mode sends each event separately to the endpoint specified by the rule. You can read more about single request mode events in the <a href=\"https://ably.com/docs/general/webhooks#batching\">batching docs</a>.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("single", "batch"),
+				},
+				Default: stringdefault.StaticString("single"),
+			},
+			"source": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"channel_filter": schema.StringAttribute{
+						Optional:            true,
+						Description:         "A JavaScript regular expression searched for in channel names; the rule applies to the channels it matches. Leave unset to apply the rule to all channels. Test filters with the `provider::ably::channel_filter_matches` function.",
+						MarkdownDescription: "A JavaScript regular expression searched for in channel names; the rule applies to the channels it matches. Leave unset to apply the rule to all channels. Test filters with the `provider::ably::channel_filter_matches` function.",
+						Validators: []validator.String{
+							validators.ChannelFilter(),
+						},
+					},
+					"type": schema.StringAttribute{
+						Required:            true,
+						Description:         "Ably currently supports the following sources for all rule types, in both single and batch mode: `channel.message`, `channel.presence`, `channel.lifecycle` and `channel.occupancy`. If the source `channel.message` is selected, you receive notifications when messages are published on a channel. If the source `channel.presence` is selected, you receive notifications of presence events when clients enter, update their data, or leave channels. If the source `channel.lifecycle` is selected, you receive notifications of channel lifecycle events, such as when a channel is created (following the first client attaching to this channel) or discarded (when there are no more clients attached to the channel). If the source `channel.occupancy` is selected, you receive notifications of occupancy events, which relate to the number and type of occupants in the channel.",
+						MarkdownDescription: "Ably currently supports the following sources for all rule types, in both single and batch mode: `channel.message`, `channel.presence`, `channel.lifecycle` and `channel.occupancy`. If the source `channel.message` is selected, you receive notifications when messages are published on a channel. If the source `channel.presence` is selected, you receive notifications of presence events when clients enter, update their data, or leave channels. If the source `channel.lifecycle` is selected, you receive notifications of channel lifecycle events, such as when a channel is created (following the first client attaching to this channel) or discarded (when there are no more clients attached to the channel). If the source `channel.occupancy` is selected, you receive notifications of occupancy events, which relate to the number and type of occupants in the channel.",
+					},
+				},
+				CustomType: SourceType{
+					ObjectType: types.ObjectType{
+						AttrTypes: SourceValue{}.AttributeTypes(ctx),
+					},
+				},
+				Required:            true,
+				Description:         "The source for the rule.",
+				MarkdownDescription: "The source for the rule.",
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The status of the rule. Rules can be enabled or disabled.",
+				MarkdownDescription: "The status of the rule. Rules can be enabled or disabled.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("enabled", "disabled"),
+				},
+				Default: stringdefault.StaticString("enabled"),
+			},
+			"target": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"enveloped": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Description:         "Delivered messages are wrapped in an Ably envelope by default that contains metadata about the message and its payload. The form of the envelope depends on whether it is part of a Webhook/Function or a Queue/Firehose rule. For everything besides Webhooks, you can ensure you only get the raw payload by unchecking \"Enveloped\" when setting up the rule.",
+						MarkdownDescription: "Delivered messages are wrapped in an Ably envelope by default that contains metadata about the message and its payload. The form of the envelope depends on whether it is part of a Webhook/Function or a Queue/Firehose rule. For everything besides Webhooks, you can ensure you only get the raw payload by unchecking \"Enveloped\" when setting up the rule.",
+						Default:             booldefault.StaticBool(false),
+					},
+					"format": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Description:         "JSON provides a simpler text-based encoding, whereas MsgPack provides a more efficient binary encoding.",
+						MarkdownDescription: "JSON provides a simpler text-based encoding, whereas MsgPack provides a more efficient binary encoding.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("json", "msgpack", "json/ably-compact"),
+						},
+						Default: stringdefault.StaticString("json"),
+					},
+					"headers": schema.ListNestedAttribute{
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Required:            true,
+									Description:         "The name of the header.",
+									MarkdownDescription: "The name of the header.",
+									Validators: []validator.String{
+										validators.HeaderName(),
+									},
+								},
+								"value": schema.StringAttribute{
+									Required:            true,
+									Description:         "The value of the header.",
+									MarkdownDescription: "The value of the header.",
+									Validators: []validator.String{
+										validators.HeaderValue(),
+									},
+								},
+							},
+							CustomType: HeadersType{
+								ObjectType: types.ObjectType{
+									AttrTypes: HeadersValue{}.AttributeTypes(ctx),
+								},
+							},
+						},
+						Optional:            true,
+						Description:         "If you have additional information to send, you'll need to include the relevant headers.",
+						MarkdownDescription: "If you have additional information to send, you'll need to include the relevant headers.",
+					},
+					"queue_id": schema.StringAttribute{
+						Required:            true,
+						Description:         "The ID of your Ably queue.",
+						MarkdownDescription: "The ID of your Ably queue.",
+					},
+				},
+				CustomType: TargetType{
+					ObjectType: types.ObjectType{
+						AttrTypes: TargetValue{}.AttributeTypes(ctx),
+					},
+				},
+				Required:            true,
+				Description:         "The target for the rule.",
+				MarkdownDescription: "The target for the rule.",
+			},
+		},
+	}
+}
+
+type RuleAmqpModel struct {
+	AppId       types.String `tfsdk:"app_id"`
+	Id          types.String `tfsdk:"id"`
+	RequestMode types.String `tfsdk:"request_mode"`
+	Source      SourceValue  `tfsdk:"source"`
+	Status      types.String `tfsdk:"status"`
+	Target      TargetValue  `tfsdk:"target"`
+}
+
+var _ basetypes.ObjectTypable = SourceType{}
+
+type SourceType struct {
+	basetypes.ObjectType
+}
+
+func (t SourceType) Equal(o attr.Type) bool {
+	other, ok := o.(SourceType)
+
+	if !ok {
+		return false
+	}
+
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t SourceType) String() string {
+	return "SourceType"
+}
+
+func (t SourceType) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := in.Attributes()
+
+	channelFilterAttribute, ok := attributes["channel_filter"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`channel_filter is missing from object`)
+
+		return nil, diags
+	}
+
+	channelFilterVal, ok := channelFilterAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`channel_filter expected to be basetypes.StringValue, was: %T`, channelFilterAttribute))
+	}
+
+	typeAttribute, ok := attributes["type"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`type is missing from object`)
+
+		return nil, diags
+	}
+
+	typeVal, ok := typeAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`type expected to be basetypes.StringValue, was: %T`, typeAttribute))
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return SourceValue{
+		ChannelFilter: channelFilterVal,
+		SourceType:    typeVal,
+		state:         attr.ValueStateKnown,
+	}, diags
+}
+
+func NewSourceValueNull() SourceValue {
+	return SourceValue{
+		state: attr.ValueStateNull,
+	}
+}
+
+func NewSourceValueUnknown() SourceValue {
+	return SourceValue{
+		state: attr.ValueStateUnknown,
+	}
+}
+
+func NewSourceValue(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) (SourceValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Reference: https://github.com/hashicorp/terraform-plugin-framework/issues/521
+	ctx := context.Background()
+
+	for name, attributeType := range attributeTypes {
+		attribute, ok := attributes[name]
+
+		if !ok {
+			diags.AddError(
+				"Missing SourceValue Attribute Value",
+				"While creating a SourceValue value, a missing attribute value was detected. "+
+					"A SourceValue must contain values for all attributes, even if null or unknown. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("SourceValue Attribute Name (%s) Expected Type: %s", name, attributeType.String()),
+			)
+
+			continue
+		}
+
+		if !attributeType.Equal(attribute.Type(ctx)) {
+			diags.AddError(
+				"Invalid SourceValue Attribute Type",
+				"While creating a SourceValue value, an invalid attribute value was detected. "+
+					"A SourceValue must use a matching attribute type for the value. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("SourceValue Attribute Name (%s) Expected Type: %s\n", name, attributeType.String())+
+					fmt.Sprintf("SourceValue Attribute Name (%s) Given Type: %s", name, attribute.Type(ctx)),
+			)
+		}
+	}
+
+	for name := range attributes {
+		_, ok := attributeTypes[name]
+
+		if !ok {
+			diags.AddError(
+				"Extra SourceValue Attribute Value",
+				"While creating a SourceValue value, an extra attribute value was detected. "+
+					"A SourceValue must not contain values beyond the expected attribute types. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("Extra SourceValue Attribute Name: %s", name),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		return NewSourceValueUnknown(), diags
+	}
+
+	channelFilterAttribute, ok := attributes["channel_filter"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`channel_filter is missing from object`)
+
+		return NewSourceValueUnknown(), diags
+	}
+
+	channelFilterVal, ok := channelFilterAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`channel_filter expected to be basetypes.StringValue, was: %T`, channelFilterAttribute))
+	}
+
+	typeAttribute, ok := attributes["type"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`type is missing from object`)
+
+		return NewSourceValueUnknown(), diags
+	}
+
+	typeVal, ok := typeAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`type expected to be basetypes.StringValue, was: %T`, typeAttribute))
+	}
+
+	if diags.HasError() {
+		return NewSourceValueUnknown(), diags
+	}
+
+	return SourceValue{
+		ChannelFilter: channelFilterVal,
+		SourceType:    typeVal,
+		state:         attr.ValueStateKnown,
+	}, diags
+}
+
+func NewSourceValueMust(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) SourceValue {
+	object, diags := NewSourceValue(attributeTypes, attributes)
+
+	if diags.HasError() {
+		// This could potentially be added to the diag package.
+		diagsStrings := make([]string, 0, len(diags))
+
+		for _, diagnostic := range diags {
+			diagsStrings = append(diagsStrings, fmt.Sprintf(
+				"%s | %s | %s",
+				diagnostic.Severity(),
+				diagnostic.Summary(),
+				diagnostic.Detail()))
+		}
+
+		panic("NewSourceValueMust received error(s): " + strings.Join(diagsStrings, "\n"))
+	}
+
+	return object
+}
+
+func (t SourceType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	if in.Type() == nil {
+		return NewSourceValueNull(), nil
+	}
+
+	if !in.Type().Equal(t.TerraformType(ctx)) {
+		return nil, fmt.Errorf("expected %s, got %s", t.TerraformType(ctx), in.Type())
+	}
+
+	if !in.IsKnown() {
+		return NewSourceValueUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return NewSourceValueNull(), nil
+	}
+
+	attributes := map[string]attr.Value{}
+
+	val := map[string]tftypes.Value{}
+
+	err := in.As(&val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range val {
+		a, err := t.AttrTypes[k].ValueFromTerraform(ctx, v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attributes[k] = a
+	}
+
+	return NewSourceValueMust(SourceValue{}.AttributeTypes(ctx), attributes), nil
+}
+
+func (t SourceType) ValueType(ctx context.Context) attr.Value {
+	return SourceValue{}
+}
+
+var _ basetypes.ObjectValuable = SourceValue{}
+
+type SourceValue struct {
+	ChannelFilter basetypes.StringValue `tfsdk:"channel_filter"`
+	SourceType    basetypes.StringValue `tfsdk:"type"`
+	state         attr.ValueState
+}
+
+func (v SourceValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
+	attrTypes := make(map[string]tftypes.Type, 2)
+
+	var val tftypes.Value
+	var err error
+
+	attrTypes["channel_filter"] = basetypes.StringType{}.TerraformType(ctx)
+	attrTypes["type"] = basetypes.StringType{}.TerraformType(ctx)
+
+	objectType := tftypes.Object{AttributeTypes: attrTypes}
+
+	switch v.state {
+	case attr.ValueStateKnown:
+		vals := make(map[string]tftypes.Value, 2)
+
+		val, err = v.ChannelFilter.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["channel_filter"] = val
+
+		val, err = v.SourceType.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["type"] = val
+
+		if err := tftypes.ValidateValue(objectType, vals); err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		return tftypes.NewValue(objectType, vals), nil
+	case attr.ValueStateNull:
+		return tftypes.NewValue(objectType, nil), nil
+	case attr.ValueStateUnknown:
+		return tftypes.NewValue(objectType, tftypes.UnknownValue), nil
+	default:
+		panic(fmt.Sprintf("unhandled Object state in ToTerraformValue: %s", v.state))
+	}
+}
+
+func (v SourceValue) IsNull() bool {
+	return v.state == attr.ValueStateNull
+}
+
+func (v SourceValue) IsUnknown() bool {
+	return v.state == attr.ValueStateUnknown
+}
+
+func (v SourceValue) String() string {
+	return "SourceValue"
+}
+
+func (v SourceValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributeTypes := map[string]attr.Type{
+		"channel_filter": basetypes.StringType{},
+		"type":           basetypes.StringType{},
+	}
+
+	if v.IsNull() {
+		return types.ObjectNull(attributeTypes), diags
+	}
+
+	if v.IsUnknown() {
+		return types.ObjectUnknown(attributeTypes), diags
+	}
+
+	objVal, diags := types.ObjectValue(
+		attributeTypes,
+		map[string]attr.Value{
+			"channel_filter": v.ChannelFilter,
+			"type":           v.SourceType,
+		})
+
+	return objVal, diags
+}
+
+func (v SourceValue) Equal(o attr.Value) bool {
+	other, ok := o.(SourceValue)
+
+	if !ok {
+		return false
+	}
+
+	if v.state != other.state {
+		return false
+	}
+
+	if v.state != attr.ValueStateKnown {
+		return true
+	}
+
+	if !v.ChannelFilter.Equal(other.ChannelFilter) {
+		return false
+	}
+
+	if !v.SourceType.Equal(other.SourceType) {
+		return false
+	}
+
+	return true
+}
+
+func (v SourceValue) Type(ctx context.Context) attr.Type {
+	return SourceType{
+		basetypes.ObjectType{
+			AttrTypes: v.AttributeTypes(ctx),
+		},
+	}
+}
+
+func (v SourceValue) AttributeTypes(ctx context.Context) map[string]attr.Type {
+	return map[string]attr.Type{
+		"channel_filter": basetypes.StringType{},
+		"type":           basetypes.StringType{},
+	}
+}
+
+var _ basetypes.ObjectTypable = TargetType{}
+
+type TargetType struct {
+	basetypes.ObjectType
+}
+
+func (t TargetType) Equal(o attr.Type) bool {
+	other, ok := o.(TargetType)
+
+	if !ok {
+		return false
+	}
+
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t TargetType) String() string {
+	return "TargetType"
+}
+
+func (t TargetType) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := in.Attributes()
+
+	envelopedAttribute, ok := attributes["enveloped"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`enveloped is missing from object`)
+
+		return nil, diags
+	}
+
+	envelopedVal, ok := envelopedAttribute.(basetypes.BoolValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`enveloped expected to be basetypes.BoolValue, was: %T`, envelopedAttribute))
+	}
+
+	formatAttribute, ok := attributes["format"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`format is missing from object`)
+
+		return nil, diags
+	}
+
+	formatVal, ok := formatAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`format expected to be basetypes.StringValue, was: %T`, formatAttribute))
+	}
+
+	headersAttribute, ok := attributes["headers"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`headers is missing from object`)
+
+		return nil, diags
+	}
+
+	headersVal, ok := headersAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`headers expected to be basetypes.ListValue, was: %T`, headersAttribute))
+	}
+
+	queueIdAttribute, ok := attributes["queue_id"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`queue_id is missing from object`)
+
+		return nil, diags
+	}
+
+	queueIdVal, ok := queueIdAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`queue_id expected to be basetypes.StringValue, was: %T`, queueIdAttribute))
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return TargetValue{
+		Enveloped: envelopedVal,
+		Format:    formatVal,
+		Headers:   headersVal,
+		QueueId:   queueIdVal,
+		state:     attr.ValueStateKnown,
+	}, diags
+}
+
+func NewTargetValueNull() TargetValue {
+	return TargetValue{
+		state: attr.ValueStateNull,
+	}
+}
+
+func NewTargetValueUnknown() TargetValue {
+	return TargetValue{
+		state: attr.ValueStateUnknown,
+	}
+}
+
+func NewTargetValue(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) (TargetValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Reference: https://github.com/hashicorp/terraform-plugin-framework/issues/521
+	ctx := context.Background()
+
+	for name, attributeType := range attributeTypes {
+		attribute, ok := attributes[name]
+
+		if !ok {
+			diags.AddError(
+				"Missing TargetValue Attribute Value",
+				"While creating a TargetValue value, a missing attribute value was detected. "+
+					"A TargetValue must contain values for all attributes, even if null or unknown. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("TargetValue Attribute Name (%s) Expected Type: %s", name, attributeType.String()),
+			)
+
+			continue
+		}
+
+		if !attributeType.Equal(attribute.Type(ctx)) {
+			diags.AddError(
+				"Invalid TargetValue Attribute Type",
+				"While creating a TargetValue value, an invalid attribute value was detected. "+
+					"A TargetValue must use a matching attribute type for the value. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("TargetValue Attribute Name (%s) Expected Type: %s\n", name, attributeType.String())+
+					fmt.Sprintf("TargetValue Attribute Name (%s) Given Type: %s", name, attribute.Type(ctx)),
+			)
+		}
+	}
+
+	for name := range attributes {
+		_, ok := attributeTypes[name]
+
+		if !ok {
+			diags.AddError(
+				"Extra TargetValue Attribute Value",
+				"While creating a TargetValue value, an extra attribute value was detected. "+
+					"A TargetValue must not contain values beyond the expected attribute types. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("Extra TargetValue Attribute Name: %s", name),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		return NewTargetValueUnknown(), diags
+	}
+
+	envelopedAttribute, ok := attributes["enveloped"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`enveloped is missing from object`)
+
+		return NewTargetValueUnknown(), diags
+	}
+
+	envelopedVal, ok := envelopedAttribute.(basetypes.BoolValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`enveloped expected to be basetypes.BoolValue, was: %T`, envelopedAttribute))
+	}
+
+	formatAttribute, ok := attributes["format"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`format is missing from object`)
+
+		return NewTargetValueUnknown(), diags
+	}
+
+	formatVal, ok := formatAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`format expected to be basetypes.StringValue, was: %T`, formatAttribute))
+	}
+
+	headersAttribute, ok := attributes["headers"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`headers is missing from object`)
+
+		return NewTargetValueUnknown(), diags
+	}
+
+	headersVal, ok := headersAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`headers expected to be basetypes.ListValue, was: %T`, headersAttribute))
+	}
+
+	queueIdAttribute, ok := attributes["queue_id"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`queue_id is missing from object`)
+
+		return NewTargetValueUnknown(), diags
+	}
+
+	queueIdVal, ok := queueIdAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`queue_id expected to be basetypes.StringValue, was: %T`, queueIdAttribute))
+	}
+
+	if diags.HasError() {
+		return NewTargetValueUnknown(), diags
+	}
+
+	return TargetValue{
+		Enveloped: envelopedVal,
+		Format:    formatVal,
+		Headers:   headersVal,
+		QueueId:   queueIdVal,
+		state:     attr.ValueStateKnown,
+	}, diags
+}
+
+func NewTargetValueMust(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) TargetValue {
+	object, diags := NewTargetValue(attributeTypes, attributes)
+
+	if diags.HasError() {
+		// This could potentially be added to the diag package.
+		diagsStrings := make([]string, 0, len(diags))
+
+		for _, diagnostic := range diags {
+			diagsStrings = append(diagsStrings, fmt.Sprintf(
+				"%s | %s | %s",
+				diagnostic.Severity(),
+				diagnostic.Summary(),
+				diagnostic.Detail()))
+		}
+
+		panic("NewTargetValueMust received error(s): " + strings.Join(diagsStrings, "\n"))
+	}
+
+	return object
+}
+
+func (t TargetType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	if in.Type() == nil {
+		return NewTargetValueNull(), nil
+	}
+
+	if !in.Type().Equal(t.TerraformType(ctx)) {
+		return nil, fmt.Errorf("expected %s, got %s", t.TerraformType(ctx), in.Type())
+	}
+
+	if !in.IsKnown() {
+		return NewTargetValueUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return NewTargetValueNull(), nil
+	}
+
+	attributes := map[string]attr.Value{}
+
+	val := map[string]tftypes.Value{}
+
+	err := in.As(&val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range val {
+		a, err := t.AttrTypes[k].ValueFromTerraform(ctx, v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attributes[k] = a
+	}
+
+	return NewTargetValueMust(TargetValue{}.AttributeTypes(ctx), attributes), nil
+}
+
+func (t TargetType) ValueType(ctx context.Context) attr.Value {
+	return TargetValue{}
+}
+
+var _ basetypes.ObjectValuable = TargetValue{}
+
+type TargetValue struct {
+	Enveloped basetypes.BoolValue   `tfsdk:"enveloped"`
+	Format    basetypes.StringValue `tfsdk:"format"`
+	Headers   basetypes.ListValue   `tfsdk:"headers"`
+	QueueId   basetypes.StringValue `tfsdk:"queue_id"`
+	state     attr.ValueState
+}
+
+func (v TargetValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
+	attrTypes := make(map[string]tftypes.Type, 4)
+
+	var val tftypes.Value
+	var err error
+
+	attrTypes["enveloped"] = basetypes.BoolType{}.TerraformType(ctx)
+	attrTypes["format"] = basetypes.StringType{}.TerraformType(ctx)
+	attrTypes["headers"] = basetypes.ListType{
+		ElemType: HeadersValue{}.Type(ctx),
+	}.TerraformType(ctx)
+	attrTypes["queue_id"] = basetypes.StringType{}.TerraformType(ctx)
+
+	objectType := tftypes.Object{AttributeTypes: attrTypes}
+
+	switch v.state {
+	case attr.ValueStateKnown:
+		vals := make(map[string]tftypes.Value, 4)
+
+		val, err = v.Enveloped.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["enveloped"] = val
+
+		val, err = v.Format.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["format"] = val
+
+		val, err = v.Headers.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["headers"] = val
+
+		val, err = v.QueueId.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["queue_id"] = val
+
+		if err := tftypes.ValidateValue(objectType, vals); err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		return tftypes.NewValue(objectType, vals), nil
+	case attr.ValueStateNull:
+		return tftypes.NewValue(objectType, nil), nil
+	case attr.ValueStateUnknown:
+		return tftypes.NewValue(objectType, tftypes.UnknownValue), nil
+	default:
+		panic(fmt.Sprintf("unhandled Object state in ToTerraformValue: %s", v.state))
+	}
+}
+
+func (v TargetValue) IsNull() bool {
+	return v.state == attr.ValueStateNull
+}
+
+func (v TargetValue) IsUnknown() bool {
+	return v.state == attr.ValueStateUnknown
+}
+
+func (v TargetValue) String() string {
+	return "TargetValue"
+}
+
+func (v TargetValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	headers := types.ListValueMust(
+		HeadersType{
+			basetypes.ObjectType{
+				AttrTypes: HeadersValue{}.AttributeTypes(ctx),
+			},
+		},
+		v.Headers.Elements(),
+	)
+
+	if v.Headers.IsNull() {
+		headers = types.ListNull(
+			HeadersType{
+				basetypes.ObjectType{
+					AttrTypes: HeadersValue{}.AttributeTypes(ctx),
+				},
+			},
+		)
+	}
+
+	if v.Headers.IsUnknown() {
+		headers = types.ListUnknown(
+			HeadersType{
+				basetypes.ObjectType{
+					AttrTypes: HeadersValue{}.AttributeTypes(ctx),
+				},
+			},
+		)
+	}
+
+	attributeTypes := map[string]attr.Type{
+		"enveloped": basetypes.BoolType{},
+		"format":    basetypes.StringType{},
+		"headers": basetypes.ListType{
+			ElemType: HeadersValue{}.Type(ctx),
+		},
+		"queue_id": basetypes.StringType{},
+	}
+
+	if v.IsNull() {
+		return types.ObjectNull(attributeTypes), diags
+	}
+
+	if v.IsUnknown() {
+		return types.ObjectUnknown(attributeTypes), diags
+	}
+
+	objVal, diags := types.ObjectValue(
+		attributeTypes,
+		map[string]attr.Value{
+			"enveloped": v.Enveloped,
+			"format":    v.Format,
+			"headers":   headers,
+			"queue_id":  v.QueueId,
+		})
+
+	return objVal, diags
+}
+
+func (v TargetValue) Equal(o attr.Value) bool {
+	other, ok := o.(TargetValue)
+
+	if !ok {
+		return false
+	}
+
+	if v.state != other.state {
+		return false
+	}
+
+	if v.state != attr.ValueStateKnown {
+		return true
+	}
+
+	if !v.Enveloped.Equal(other.Enveloped) {
+		return false
+	}
+
+	if !v.Format.Equal(other.Format) {
+		return false
+	}
+
+	if !v.Headers.Equal(other.Headers) {
+		return false
+	}
+
+	if !v.QueueId.Equal(other.QueueId) {
+		return false
+	}
+
+	return true
+}
+
+func (v TargetValue) Type(ctx context.Context) attr.Type {
+	return TargetType{
+		basetypes.ObjectType{
+			AttrTypes: v.AttributeTypes(ctx),
+		},
+	}
+}
+
+func (v TargetValue) AttributeTypes(ctx context.Context) map[string]attr.Type {
+	return map[string]attr.Type{
+		"enveloped": basetypes.BoolType{},
+		"format":    basetypes.StringType{},
+		"headers": basetypes.ListType{
+			ElemType: HeadersValue{}.Type(ctx),
+		},
+		"queue_id": basetypes.StringType{},
+	}
+}
+
+var _ basetypes.ObjectTypable = HeadersType{}
+
+type HeadersType struct {
+	basetypes.ObjectType
+}
+
+func (t HeadersType) Equal(o attr.Type) bool {
+	other, ok := o.(HeadersType)
+
+	if !ok {
+		return false
+	}
+
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t HeadersType) String() string {
+	return "HeadersType"
+}
+
+func (t HeadersType) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := in.Attributes()
+
+	nameAttribute, ok := attributes["name"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`name is missing from object`)
+
+		return nil, diags
+	}
+
+	nameVal, ok := nameAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`name expected to be basetypes.StringValue, was: %T`, nameAttribute))
+	}
+
+	valueAttribute, ok := attributes["value"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`value is missing from object`)
+
+		return nil, diags
+	}
+
+	valueVal, ok := valueAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`value expected to be basetypes.StringValue, was: %T`, valueAttribute))
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return HeadersValue{
+		Name:  nameVal,
+		Value: valueVal,
+		state: attr.ValueStateKnown,
+	}, diags
+}
+
+func NewHeadersValueNull() HeadersValue {
+	return HeadersValue{
+		state: attr.ValueStateNull,
+	}
+}
+
+func NewHeadersValueUnknown() HeadersValue {
+	return HeadersValue{
+		state: attr.ValueStateUnknown,
+	}
+}
+
+func NewHeadersValue(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) (HeadersValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Reference: https://github.com/hashicorp/terraform-plugin-framework/issues/521
+	ctx := context.Background()
+
+	for name, attributeType := range attributeTypes {
+		attribute, ok := attributes[name]
+
+		if !ok {
+			diags.AddError(
+				"Missing HeadersValue Attribute Value",
+				"While creating a HeadersValue value, a missing attribute value was detected. "+
+					"A HeadersValue must contain values for all attributes, even if null or unknown. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("HeadersValue Attribute Name (%s) Expected Type: %s", name, attributeType.String()),
+			)
+
+			continue
+		}
+
+		if !attributeType.Equal(attribute.Type(ctx)) {
+			diags.AddError(
+				"Invalid HeadersValue Attribute Type",
+				"While creating a HeadersValue value, an invalid attribute value was detected. "+
+					"A HeadersValue must use a matching attribute type for the value. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("HeadersValue Attribute Name (%s) Expected Type: %s\n", name, attributeType.String())+
+					fmt.Sprintf("HeadersValue Attribute Name (%s) Given Type: %s", name, attribute.Type(ctx)),
+			)
+		}
+	}
+
+	for name := range attributes {
+		_, ok := attributeTypes[name]
+
+		if !ok {
+			diags.AddError(
+				"Extra HeadersValue Attribute Value",
+				"While creating a HeadersValue value, an extra attribute value was detected. "+
+					"A HeadersValue must not contain values beyond the expected attribute types. "+
+					"This is always an issue with the provider and should be reported to the provider developers.\n\n"+
+					fmt.Sprintf("Extra HeadersValue Attribute Name: %s", name),
+			)
+		}
+	}
+
+	if diags.HasError() {
+		return NewHeadersValueUnknown(), diags
+	}
+
+	nameAttribute, ok := attributes["name"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`name is missing from object`)
+
+		return NewHeadersValueUnknown(), diags
+	}
+
+	nameVal, ok := nameAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`name expected to be basetypes.StringValue, was: %T`, nameAttribute))
+	}
+
+	valueAttribute, ok := attributes["value"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`value is missing from object`)
+
+		return NewHeadersValueUnknown(), diags
+	}
+
+	valueVal, ok := valueAttribute.(basetypes.StringValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`value expected to be basetypes.StringValue, was: %T`, valueAttribute))
+	}
+
+	if diags.HasError() {
+		return NewHeadersValueUnknown(), diags
+	}
+
+	return HeadersValue{
+		Name:  nameVal,
+		Value: valueVal,
+		state: attr.ValueStateKnown,
+	}, diags
+}
+
+func NewHeadersValueMust(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) HeadersValue {
+	object, diags := NewHeadersValue(attributeTypes, attributes)
+
+	if diags.HasError() {
+		// This could potentially be added to the diag package.
+		diagsStrings := make([]string, 0, len(diags))
+
+		for _, diagnostic := range diags {
+			diagsStrings = append(diagsStrings, fmt.Sprintf(
+				"%s | %s | %s",
+				diagnostic.Severity(),
+				diagnostic.Summary(),
+				diagnostic.Detail()))
+		}
+
+		panic("NewHeadersValueMust received error(s): " + strings.Join(diagsStrings, "\n"))
+	}
+
+	return object
+}
+
+func (t HeadersType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	if in.Type() == nil {
+		return NewHeadersValueNull(), nil
+	}
+
+	if !in.Type().Equal(t.TerraformType(ctx)) {
+		return nil, fmt.Errorf("expected %s, got %s", t.TerraformType(ctx), in.Type())
+	}
+
+	if !in.IsKnown() {
+		return NewHeadersValueUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return NewHeadersValueNull(), nil
+	}
+
+	attributes := map[string]attr.Value{}
+
+	val := map[string]tftypes.Value{}
+
+	err := in.As(&val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range val {
+		a, err := t.AttrTypes[k].ValueFromTerraform(ctx, v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attributes[k] = a
+	}
+
+	return NewHeadersValueMust(HeadersValue{}.AttributeTypes(ctx), attributes), nil
+}
+
+func (t HeadersType) ValueType(ctx context.Context) attr.Value {
+	return HeadersValue{}
+}
+
+var _ basetypes.ObjectValuable = HeadersValue{}
+
+type HeadersValue struct {
+	Name  basetypes.StringValue `tfsdk:"name"`
+	Value basetypes.StringValue `tfsdk:"value"`
+	state attr.ValueState
+}
+
+func (v HeadersValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
+	attrTypes := make(map[string]tftypes.Type, 2)
+
+	var val tftypes.Value
+	var err error
+
+	attrTypes["name"] = basetypes.StringType{}.TerraformType(ctx)
+	attrTypes["value"] = basetypes.StringType{}.TerraformType(ctx)
+
+	objectType := tftypes.Object{AttributeTypes: attrTypes}
+
+	switch v.state {
+	case attr.ValueStateKnown:
+		vals := make(map[string]tftypes.Value, 2)
+
+		val, err = v.Name.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["name"] = val
+
+		val, err = v.Value.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["value"] = val
+
+		if err := tftypes.ValidateValue(objectType, vals); err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		return tftypes.NewValue(objectType, vals), nil
+	case attr.ValueStateNull:
+		return tftypes.NewValue(objectType, nil), nil
+	case attr.ValueStateUnknown:
+		return tftypes.NewValue(objectType, tftypes.UnknownValue), nil
+	default:
+		panic(fmt.Sprintf("unhandled Object state in ToTerraformValue: %s", v.state))
+	}
+}
+
+func (v HeadersValue) IsNull() bool {
+	return v.state == attr.ValueStateNull
+}
+
+func (v HeadersValue) IsUnknown() bool {
+	return v.state == attr.ValueStateUnknown
+}
+
+func (v HeadersValue) String() string {
+	return "HeadersValue"
+}
+
+func (v HeadersValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributeTypes := map[string]attr.Type{
+		"name":  basetypes.StringType{},
+		"value": basetypes.StringType{},
+	}
+
+	if v.IsNull() {
+		return types.ObjectNull(attributeTypes), diags
+	}
+
+	if v.IsUnknown() {
+		return types.ObjectUnknown(attributeTypes), diags
+	}
+
+	objVal, diags := types.ObjectValue(
+		attributeTypes,
+		map[string]attr.Value{
+			"name":  v.Name,
+			"value": v.Value,
+		})
+
+	return objVal, diags
+}
+
+func (v HeadersValue) Equal(o attr.Value) bool {
+	other, ok := o.(HeadersValue)
+
+	if !ok {
+		return false
+	}
+
+	if v.state != other.state {
+		return false
+	}
+
+	if v.state != attr.ValueStateKnown {
+		return true
+	}
+
+	if !v.Name.Equal(other.Name) {
+		return false
+	}
+
+	if !v.Value.Equal(other.Value) {
+		return false
+	}
+
+	return true
+}
+
+func (v HeadersValue) Type(ctx context.Context) attr.Type {
+	return HeadersType{
+		basetypes.ObjectType{
+			AttrTypes: v.AttributeTypes(ctx),
+		},
+	}
+}
+
+func (v HeadersValue) AttributeTypes(ctx context.Context) map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":  basetypes.StringType{},
+		"value": basetypes.StringType{},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/ably/terraform-provider-ably/internal/provider/planmodifiers"
+	"github.com/ably/terraform-provider-ably/internal/provider/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -111,9 +112,12 @@ func RuleBeforePublishLambdaResourceSchema(ctx context.Context) schema.Schema {
 			"source": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"channel_filter": schema.StringAttribute{
-						Required:            true,
-						Description:         "This field allows you to filter your rule based on a regular expression that is matched against the complete channel name. Leave this empty if you want the rule to apply to all channels.",
-						MarkdownDescription: "This field allows you to filter your rule based on a regular expression that is matched against the complete channel name. Leave this empty if you want the rule to apply to all channels.",
+						Optional:            true,
+						Description:         "A JavaScript regular expression searched for in channel names; the rule applies to the channels it matches. Leave unset to apply the rule to all channels. Test filters with the `provider::ably::channel_filter_matches` function.",
+						MarkdownDescription: "A JavaScript regular expression searched for in channel names; the rule applies to the channels it matches. Leave unset to apply the rule to all channels. Test filters with the `provider::ably::channel_filter_matches` function.",
+						Validators: []validator.String{
+							validators.ChannelFilter(),
+						},
 					},
 					"type": schema.StringAttribute{
 						Required:            true,
@@ -129,7 +133,9 @@ func RuleBeforePublishLambdaResourceSchema(ctx context.Context) schema.Schema {
 						AttrTypes: SourceValue{}.AttributeTypes(ctx),
 					},
 				},
-				Optional: true,
+				Optional:            true,
+				Description:         "The source for the rule.",
+				MarkdownDescription: "The source for the rule.",
 			},
 			"status": schema.StringAttribute{
 				Optional:            true,
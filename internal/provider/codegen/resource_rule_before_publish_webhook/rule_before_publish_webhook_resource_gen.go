@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/ably/terraform-provider-ably/internal/provider/planmodifiers"
+	"github.com/ably/terraform-provider-ably/internal/provider/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -124,19 +125,20 @@ func RuleBeforePublishWebhookResourceSchema(ctx context.Context) schema.Schema {
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
 								"name": schema.StringAttribute{
-									Optional:            true,
+									Required:            true,
 									Description:         "The name of the header.",
 									MarkdownDescription: "The name of the header.",
 									Validators: []validator.String{
+										validators.HeaderName(),
 										stringvalidator.LengthAtLeast(1),
 									},
 								},
 								"value": schema.StringAttribute{
-									Optional:            true,
+									Required:            true,
 									Description:         "The value of the header.",
 									MarkdownDescription: "The value of the header.",
 									Validators: []validator.String{
-										stringvalidator.LengthAtLeast(1),
+										validators.HeaderValue(),
 									},
 								},
 							},
@@ -146,7 +148,9 @@ func RuleBeforePublishWebhookResourceSchema(ctx context.Context) schema.Schema {
 								},
 							},
 						},
-						Optional: true,
+						Optional:            true,
+						Description:         "If you have additional information to send, you'll need to include the relevant headers.",
+						MarkdownDescription: "If you have additional information to send, you'll need to include the relevant headers.",
 					},
 					"url": schema.StringAttribute{
 						Required:            true,
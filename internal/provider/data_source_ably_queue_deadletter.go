@@ -0,0 +1,161 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &DataSourceQueueDeadletter{}
+
+// DataSourceQueueDeadletter reads the dead letter queue of a queue. Ably
+// creates and deletes dead letter queues itself, so they are read rather than
+// managed.
+type DataSourceQueueDeadletter struct {
+	p *AblyProvider
+}
+
+// Metadata returns the data source type name.
+func (d DataSourceQueueDeadletter) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "ably_queue_deadletter"
+}
+
+// Schema defines the schema for the data source.
+func (d DataSourceQueueDeadletter) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The `ably_queue_deadletter` data source reads the dead letter queue Ably keeps for a queue, so consumers of dead letters " +
+			"can be configured alongside the queue. Ably creates and deletes dead letter queues itself, so there is no resource for them.",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The application ID.",
+			},
+			"queue_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the queue whose dead letter queue to read.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the dead letter queue.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the dead letter queue.",
+			},
+			"region": schema.StringAttribute{
+				Computed:    true,
+				Description: "The data center region of the dead letter queue.",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "The current state of the dead letter queue.",
+			},
+			"ttl": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Time to live in minutes.",
+			},
+			"max_length": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Message limit in number of messages.",
+			},
+			"amqp_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "URI for the AMQP queue interface.",
+			},
+			"amqp_queue_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the dead letter queue for AMQP consumers.",
+			},
+			"stomp_uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "URI for the STOMP queue interface.",
+			},
+			"stomp_host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The host type for the queue.",
+			},
+			"stomp_destination": schema.StringAttribute{
+				Computed:    true,
+				Description: "Destination queue for STOMP consumers.",
+			},
+		},
+	}
+}
+
+// findDeadletter returns the dead letter queue of the queue queueID from the
+// queues of an app.
+func findDeadletter(queues []control.QueueResponse, queueID string) (control.QueueResponse, error) {
+	var deadletterID string
+	found := false
+	for _, q := range queues {
+		if q.ID == queueID {
+			deadletterID, found = deref(q.DeadletterID), true
+			break
+		}
+	}
+	switch {
+	case !found:
+		return control.QueueResponse{}, fmt.Errorf("the app has no queue with ID %s", queueID)
+	case deadletterID == "":
+		return control.QueueResponse{}, fmt.Errorf("queue %s has no dead letter queue", queueID)
+	}
+
+	for _, q := range queues {
+		if q.ID == deadletterID {
+			return q, nil
+		}
+	}
+	return control.QueueResponse{}, fmt.Errorf("queue %s names %s as its dead letter queue, but the app has no queue with that ID", queueID, deadletterID)
+}
+
+// Read refreshes the data source.
+func (d DataSourceQueueDeadletter) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !d.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	var config AblyQueueDeadletter
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	appID := config.AppID.ValueString()
+
+	// The Control API cannot fetch a single queue, so list them all.
+	queues, err := d.p.client.ListQueues(ctx, appID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ably_queue_deadletter",
+			fmt.Sprintf("Could not list the queues of app %s: %s", appID, err),
+		)
+		return
+	}
+	q, err := findDeadletter(queues, config.QueueID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ably_queue_deadletter", fmt.Sprintf("In app %s, %s.", appID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, AblyQueueDeadletter{
+		AppID:     config.AppID,
+		QueueID:   config.QueueID,
+		ID:        types.StringValue(q.ID),
+		Name:      types.StringValue(q.Name),
+		Region:    types.StringValue(q.Region),
+		State:     types.StringValue(q.State),
+		Ttl:       types.Int64Value(int64(q.TTL)),
+		MaxLength: types.Int64Value(int64(q.MaxLength)),
+
+		AmqpUri:          types.StringValue(q.AMQP.URI),
+		AmqpQueueName:    types.StringValue(q.AMQP.QueueName),
+		StompURI:         types.StringValue(q.Stomp.URI),
+		StompHost:        types.StringValue(q.Stomp.Host),
+		StompDestination: types.StringValue(q.Stomp.Destination),
+	})...)
+}
@@ -0,0 +1,69 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAblyQueueDeadletter(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyQueueDeadletterConfig(appName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ably_queue_deadletter.dlq", "id", "ably_queue.queue0", "deadletter_id"),
+					resource.TestCheckResourceAttrSet("data.ably_queue_deadletter.dlq", "amqp_uri"),
+					resource.TestCheckResourceAttrSet("data.ably_queue_deadletter.dlq", "amqp_queue_name"),
+					resource.TestCheckResourceAttrSet("data.ably_queue_deadletter.dlq", "stomp_uri"),
+					resource.TestCheckResourceAttrSet("data.ably_queue_deadletter.dlq", "stomp_destination"),
+					// The dead letter queue's own status reports it as one.
+					resource.TestCheckResourceAttr("data.ably_queue_status.dlq", "deadletter", "true"),
+				),
+			},
+		},
+	})
+}
+
+// Function with inline HCL to provision a queue and read its dead letter
+// queue.
+func testAccAblyQueueDeadletterConfig(appName string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name = %[1]q
+}
+
+resource "ably_queue" "queue0" {
+	app_id     = ably_app.app0.id
+	name       = "orders"
+	ttl        = 60
+	max_length = 100
+	region     = "us-east-1-a"
+}
+
+data "ably_queue_deadletter" "dlq" {
+	app_id   = ably_app.app0.id
+	queue_id = ably_queue.queue0.id
+}
+
+data "ably_queue_status" "dlq" {
+	app_id = ably_app.app0.id
+	id     = data.ably_queue_deadletter.dlq.id
+}
+`, appName)
+}
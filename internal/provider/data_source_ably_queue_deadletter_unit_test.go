@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+)
+
+func TestFindDeadletter(t *testing.T) {
+	t.Parallel()
+
+	queues := []control.QueueResponse{
+		{ID: "q1", Name: "orders", DeadletterID: ptr("dlq")},
+		{ID: "q2", Name: "audit"},
+		{ID: "q3", Name: "stale", DeadletterID: ptr("gone")},
+		{ID: "dlq", Name: "deadletter", Deadletter: true},
+	}
+
+	tests := []struct {
+		queueID   string
+		want      string
+		errSubstr string
+	}{
+		{queueID: "q1", want: "dlq"},
+		{queueID: "q2", errSubstr: "queue q2 has no dead letter queue"},
+		{queueID: "q3", errSubstr: "names gone as its dead letter queue"},
+		{queueID: "q4", errSubstr: "no queue with ID q4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.queueID, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := findDeadletter(queues, tt.queueID)
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("dead letter queue = %s, want %s", got.ID, tt.want)
+			}
+		})
+	}
+}
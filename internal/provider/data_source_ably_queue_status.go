@@ -0,0 +1,154 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &DataSourceQueueStatus{}
+
+// DataSourceQueueStatus reads the live state, message counts and message
+// rates of a queue. They change all the time, so ably_queue leaves them out.
+type DataSourceQueueStatus struct {
+	p *AblyProvider
+}
+
+// Metadata returns the data source type name.
+func (d DataSourceQueueStatus) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "ably_queue_status"
+}
+
+// Schema defines the schema for the data source.
+func (d DataSourceQueueStatus) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The `ably_queue_status` data source reads the current state, message counts and message rates of an Ably queue. " +
+			"Use it in `check` blocks to alert when a queue stops running or a dead letter queue fills up.",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The application ID.",
+			},
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the queue.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the queue.",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "The current state of the queue, such as `Running`.",
+			},
+			"deadletter": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether this is a dead letter queue.",
+			},
+			"deadletter_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the queue's dead letter queue.",
+			},
+			"messages": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The number of messages in the queue. A count the Control API does not report is null.",
+				Attributes: map[string]schema.Attribute{
+					"ready": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Messages waiting to be consumed.",
+					},
+					"unacknowledged": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Messages delivered to a consumer but not yet acknowledged.",
+					},
+					"total": schema.Int64Attribute{
+						Computed:    true,
+						Description: "All messages in the queue.",
+					},
+				},
+			},
+			"stats": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The rates, in messages per second, at which messages move through the queue. A rate the Control API does not report is null.",
+				Attributes: map[string]schema.Attribute{
+					"publish_rate": schema.Float64Attribute{
+						Computed:    true,
+						Description: "The rate at which messages are published to the queue.",
+					},
+					"delivery_rate": schema.Float64Attribute{
+						Computed:    true,
+						Description: "The rate at which messages are delivered to consumers.",
+					},
+					"acknowledgement_rate": schema.Float64Attribute{
+						Computed:    true,
+						Description: "The rate at which consumers acknowledge messages.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// ablyQueueStatus maps a queue returned by the Control API onto the data
+// source model.
+func ablyQueueStatus(appID string, q control.QueueResponse) AblyQueueStatus {
+	return AblyQueueStatus{
+		AppID:        types.StringValue(appID),
+		ID:           types.StringValue(q.ID),
+		Name:         types.StringValue(q.Name),
+		State:        types.StringValue(q.State),
+		Deadletter:   types.BoolValue(q.Deadletter),
+		DeadletterID: optStringValue(q.DeadletterID),
+		Messages: &AblyQueueStatusMessages{
+			Ready:          optIntValue(q.Messages.Ready),
+			Unacknowledged: optIntValue(q.Messages.Unacknowledged),
+			Total:          optIntValue(q.Messages.Total),
+		},
+		Stats: &AblyQueueStatusStats{
+			PublishRate:         optFloat64Value(q.Stats.PublishRate),
+			DeliveryRate:        optFloat64Value(q.Stats.DeliveryRate),
+			AcknowledgementRate: optFloat64Value(q.Stats.AcknowledgementRate),
+		},
+	}
+}
+
+// Read refreshes the data source.
+func (d DataSourceQueueStatus) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !d.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	var config AblyQueueStatus
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	appID := config.AppID.ValueString()
+	queueID := config.ID.ValueString()
+
+	// The Control API cannot fetch a single queue, so list them all.
+	queues, err := d.p.client.ListQueues(ctx, appID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ably_queue_status",
+			fmt.Sprintf("Could not list the queues of app %s: %s", appID, err),
+		)
+		return
+	}
+	for _, q := range queues {
+		if q.ID == queueID {
+			resp.Diagnostics.Append(resp.State.Set(ctx, ablyQueueStatus(appID, q))...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error reading ably_queue_status",
+		fmt.Sprintf("App %s has no queue with ID %s.", appID, queueID),
+	)
+}
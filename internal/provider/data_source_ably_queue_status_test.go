@@ -0,0 +1,69 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAblyQueueStatus(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyQueueStatusConfig(appName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_queue.queue0", "wait_for_state", "Running"),
+					resource.TestCheckResourceAttrPair("data.ably_queue_status.queue0", "name", "ably_queue.queue0", "name"),
+					resource.TestCheckResourceAttrPair("data.ably_queue_status.queue0", "state", "ably_queue.queue0", "state"),
+					resource.TestCheckResourceAttr("data.ably_queue_status.queue0", "deadletter", "false"),
+				),
+			},
+		},
+	})
+}
+
+// Function with inline HCL to provision a queue that must be running before
+// its status is read.
+func testAccAblyQueueStatusConfig(appName string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name = %[1]q
+}
+
+resource "ably_queue" "queue0" {
+	app_id         = ably_app.app0.id
+	name           = "orders"
+	ttl            = 60
+	max_length     = 100
+	region         = "us-east-1-a"
+	wait_for_state = "Running"
+}
+
+data "ably_queue_status" "queue0" {
+	app_id = ably_app.app0.id
+	id     = ably_queue.queue0.id
+}
+
+check "queue_running" {
+	assert {
+		condition     = lower(data.ably_queue_status.queue0.state) == "running"
+		error_message = "The orders queue is not running."
+	}
+}
+`, appName)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+)
+
+func TestAblyQueueStatus(t *testing.T) {
+	t.Parallel()
+
+	got := ablyQueueStatus("app1", control.QueueResponse{
+		ID:       "q1",
+		Name:     "orders-dlq",
+		State:    "Running",
+		Messages: control.QueueMessages{Ready: ptr(7), Total: ptr(9)},
+		Stats:    control.QueueStats{PublishRate: ptr(1.5)},
+	})
+
+	if got.AppID.ValueString() != "app1" || got.ID.ValueString() != "q1" || got.State.ValueString() != "Running" {
+		t.Errorf("identity = %s/%s in state %s, want app1/q1 in state Running", got.AppID, got.ID, got.State)
+	}
+	if got.Messages.Ready.ValueInt64() != 7 || got.Messages.Total.ValueInt64() != 9 {
+		t.Errorf("messages = %+v, want 7 ready of 9", got.Messages)
+	}
+	if got.Stats.PublishRate.ValueFloat64() != 1.5 {
+		t.Errorf("publish_rate = %s, want 1.5", got.Stats.PublishRate)
+	}
+	// Counts and rates the Control API leaves out are null, not zero.
+	if !got.Messages.Unacknowledged.IsNull() || !got.Stats.DeliveryRate.IsNull() || !got.DeadletterID.IsNull() {
+		t.Errorf("missing values = %s, %s, %s, want null", got.Messages.Unacknowledged, got.Stats.DeliveryRate, got.DeadletterID)
+	}
+}
@@ -0,0 +1,81 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema of the deletion_protection
+// attribute shared by ably_app, ably_queue and ably_namespace. It lives only
+// in state, so changing it never calls the Control API.
+func deletionProtectionAttribute(resourceType string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: fmt.Sprintf("Whether Terraform is prevented from deleting the %s. While true, destroying "+
+			"or replacing it fails; set it to false and apply before removing the resource. Defaults to false.", resourceType),
+	}
+}
+
+// deletionProtectionValue returns the deletion_protection value to keep in
+// state. State written before the attribute existed, or by an import, holds
+// null, which reads back as the default.
+func deletionProtectionValue(v types.Bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return v
+}
+
+// checkDeletionProtection adds an error to diags and returns false when the
+// resource being deleted has deletion_protection enabled.
+func checkDeletionProtection(resourceType, id string, protected types.Bool, diags *diag.Diagnostics) bool {
+	if !protected.ValueBool() {
+		return true
+	}
+	diags.AddError(
+		"Deletion protection enabled",
+		fmt.Sprintf("%s %s has deletion_protection enabled. Set deletion_protection = false and apply before destroying or replacing it.", resourceType, id),
+	)
+	return false
+}
+
+// checkAppProtected adds an error to diags and returns false when the name of
+// the app being deleted matches the provider's protect_apps_matching pattern.
+func (p *AblyProvider) checkAppProtected(resourceType, id, name string, diags *diag.Diagnostics) bool {
+	if p.protectApps == nil || !p.protectApps.MatchString(name) {
+		return true
+	}
+	diags.AddError(
+		"App protected by provider configuration",
+		fmt.Sprintf("%s %s is named %q, which matches the provider's protect_apps_matching pattern %q, so it cannot be deleted. "+
+			"Change protect_apps_matching to delete it.", resourceType, id, name, p.protectApps.String()),
+	)
+	return false
+}
+
+// resolveProtectApps compiles the protect_apps_matching provider attribute,
+// falling back to the ABLY_PROTECT_APPS_MATCHING environment variable when it
+// is null. It returns nil when neither is set.
+func resolveProtectApps(attr types.String) (*regexp.Regexp, error) {
+	source, pattern := "protect_apps_matching", attr.ValueString()
+	if attr.IsNull() {
+		source, pattern = "ABLY_PROTECT_APPS_MATCHING", os.Getenv("ABLY_PROTECT_APPS_MATCHING")
+	}
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid regular expression: %w", source, err)
+	}
+	return re, nil
+}
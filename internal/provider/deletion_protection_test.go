@@ -0,0 +1,141 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccDeletionProtection(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeletionProtectionConfig(appName, true, 60),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "deletion_protection", "true"),
+					resource.TestCheckResourceAttr("ably_queue.queue0", "deletion_protection", "true"),
+				),
+			},
+			// Destroying protected resources fails and leaves them in place.
+			{
+				Config:      testAccDeletionProtectionConfig(appName, true, 60),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection enabled`),
+			},
+			// So does replacing one.
+			{
+				Config:      testAccDeletionProtectionConfig(appName, true, 30),
+				ExpectError: regexp.MustCompile(`deletion_protection enabled`),
+			},
+			// Turning protection off is an in-place update, after which the
+			// TestCase destroys everything.
+			{
+				Config: testAccDeletionProtectionConfig(appName, false, 60),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ably_queue.queue0", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "deletion_protection", "false"),
+					resource.TestCheckResourceAttr("ably_namespace.namespace0", "deletion_protection", "false"),
+					resource.TestCheckResourceAttr("ably_queue.queue0", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProtectAppsMatching(t *testing.T) {
+	appName := "prod-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProtectAppsMatchingConfig(appName, "^prod-"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "deletion_protection", "false"),
+				),
+			},
+			{
+				Config:      testAccProtectAppsMatchingConfig(appName, "^prod-"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`protect_apps_matching`),
+			},
+			{
+				Config:      testAccProtectAppsMatchingConfig(appName, "^(prod"),
+				ExpectError: regexp.MustCompile(`not a valid regular expression`),
+			},
+			// A pattern that no longer matches lets the TestCase destroy the app.
+			{
+				Config: testAccProtectAppsMatchingConfig(appName, "^staging-"),
+			},
+		},
+	})
+}
+
+// Function with inline HCL to provision an app, namespace and queue, all
+// with the given deletion_protection.
+func testAccDeletionProtectionConfig(appName string, protected bool, ttl int) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name                = %[1]q
+	deletion_protection = %[2]t
+}
+
+resource "ably_namespace" "namespace0" {
+	app_id              = ably_app.app0.id
+	id                  = "protected"
+	deletion_protection = %[2]t
+}
+
+resource "ably_queue" "queue0" {
+	app_id              = ably_app.app0.id
+	name                = "protected"
+	ttl                 = %[3]d
+	max_length          = 100
+	region              = "us-east-1-a"
+	deletion_protection = %[2]t
+}
+`, appName, protected, ttl)
+}
+
+// Function with inline HCL to provision an app with a provider-level
+// protect_apps_matching pattern.
+func testAccProtectAppsMatchingConfig(appName, pattern string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {
+	protect_apps_matching = %[2]q
+}
+
+resource "ably_app" "app0" {
+	name = %[1]q
+}
+`, appName, pattern)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveProtectApps(t *testing.T) {
+	tests := []struct {
+		name      string
+		attr      types.String
+		env       string // "" means unset
+		want      string // "" means no pattern
+		errSubstr string
+	}{
+		{name: "unset", attr: types.StringNull()},
+		{name: "attribute", attr: types.StringValue("^prod-"), want: "^prod-"},
+		{name: "env fallback", attr: types.StringNull(), env: "-live$", want: "-live$"},
+		{name: "attribute wins over env", attr: types.StringValue("^prod-"), env: "-live$", want: "^prod-"},
+		{name: "empty attribute disables env", attr: types.StringValue(""), env: "-live$"},
+		{name: "invalid attribute", attr: types.StringValue("^(prod"), errSubstr: "protect_apps_matching"},
+		{name: "invalid env names env var", attr: types.StringNull(), env: "(?<x>", errSubstr: "ABLY_PROTECT_APPS_MATCHING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ABLY_PROTECT_APPS_MATCHING", tt.env)
+
+			re, err := resolveProtectApps(tt.attr)
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := ""
+			if re != nil {
+				got = re.String()
+			}
+			if got != tt.want {
+				t.Fatalf("pattern = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeletionChecks(t *testing.T) {
+	p := &AblyProvider{protectApps: regexp.MustCompile("^prod-")}
+
+	tests := []struct {
+		name      string
+		protected types.Bool
+		appName   string
+		errSubstr string // "" means the delete may go ahead
+	}{
+		{name: "unprotected", protected: types.BoolValue(false), appName: "staging-orders"},
+		{name: "null from old state", protected: types.BoolNull(), appName: "staging-orders"},
+		{name: "deletion_protection", protected: types.BoolValue(true), appName: "staging-orders", errSubstr: "deletion_protection = false"},
+		{name: "name matches pattern", protected: types.BoolValue(false), appName: "prod-orders", errSubstr: `matches the provider's protect_apps_matching pattern "^prod-"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			ok := checkDeletionProtection("ably_app", "app1", tt.protected, &diags) &&
+				p.checkAppProtected("ably_app", "app1", tt.appName, &diags)
+
+			if ok != (tt.errSubstr == "") || ok == diags.HasError() {
+				t.Fatalf("ok = %t with diagnostics %v", ok, diags)
+			}
+			if tt.errSubstr != "" && !strings.Contains(diags[0].Detail(), tt.errSubstr) {
+				t.Errorf("detail = %q, want it to contain %q", diags[0].Detail(), tt.errSubstr)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+	if !(&AblyProvider{}).checkAppProtected("ably_app", "app1", "prod-orders", &diags) {
+		t.Errorf("an unconfigured pattern protected an app: %v", diags)
+	}
+}
@@ -0,0 +1,44 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of the destroy_behavior attribute.
+const (
+	destroyBehaviorDelete  = "delete"
+	destroyBehaviorDisable = "disable"
+)
+
+// destroyBehaviorAttribute returns the schema of the destroy_behavior
+// attribute shared by ably_app and the rule resources. Like
+// deletion_protection it lives only in state.
+func destroyBehaviorAttribute(resourceType string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(destroyBehaviorDelete),
+		Description: fmt.Sprintf("What destroying the %[1]s does: `delete` deletes it, while `disable` only sets its status to "+
+			"`disabled` and removes it from state, keeping its history and stats for a later cleanup. Defaults to `delete`.", resourceType),
+		Validators: []validator.String{
+			stringvalidator.OneOf(destroyBehaviorDelete, destroyBehaviorDisable),
+		},
+	}
+}
+
+// destroyBehaviorValue returns the destroy_behavior value to keep in state.
+// State written before the attribute existed, or by an import, holds null,
+// which reads back as the default.
+func destroyBehaviorValue(v types.String) types.String {
+	if v.IsNull() || v.IsUnknown() {
+		return types.StringValue(destroyBehaviorDelete)
+	}
+	return v
+}
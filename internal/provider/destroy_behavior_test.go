@@ -0,0 +1,104 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDestroyBehaviorDisable(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDisabledOnDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDestroyBehaviorConfig(appName, "delete"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "destroy_behavior", "delete"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "destroy_behavior", "delete"),
+				),
+			},
+			// Switching to disable is an in-place update; the TestCase then
+			// destroys the app and rule, which must only disable them.
+			{
+				Config: testAccDestroyBehaviorConfig(appName, "disable"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "destroy_behavior", "disable"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "destroy_behavior", "disable"),
+					resource.TestCheckResourceAttr("ably_rule_http.rule0", "status", "enabled"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckDisabledOnDestroy verifies that the destroyed app and rule still
+// exist, disabled, and then deletes the app as a cleanup job would.
+func testAccCheckDisabledOnDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	client, accountID, err := testAccClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	app := s.RootModule().Resources["ably_app.app0"].Primary
+	rule := s.RootModule().Resources["ably_rule_http.rule0"].Primary
+
+	apps, err := client.ListApps(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("error listing apps during destroy check: %w", err)
+	}
+	status := ""
+	for _, a := range apps {
+		if a.ID == app.ID {
+			status = a.Status
+		}
+	}
+	if status != "disabled" {
+		return fmt.Errorf("ably_app %s has status %q after destroy, want disabled", app.ID, status)
+	}
+
+	got, err := client.GetRule(ctx, app.ID, rule.ID)
+	if err != nil {
+		return fmt.Errorf("ably_rule_http %s was not kept on destroy: %w", rule.ID, err)
+	}
+	if got.Status != "disabled" {
+		return fmt.Errorf("ably_rule_http %s has status %q after destroy, want disabled", rule.ID, got.Status)
+	}
+
+	return client.DeleteApp(ctx, app.ID)
+}
+
+// Function with inline HCL to provision an app and an HTTP rule with the
+// given destroy_behavior.
+func testAccDestroyBehaviorConfig(appName, destroyBehavior string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name             = %[1]q
+	destroy_behavior = %[2]q
+}
+
+resource "ably_rule_http" "rule0" {
+	app_id           = ably_app.app0.id
+	source           = { channel_filter = "^events", type = "channel.message" }
+	target           = { url = "https://example.com/webhook", format = "json" }
+	destroy_behavior = %[2]q
+}
+`, appName, destroyBehavior)
+}
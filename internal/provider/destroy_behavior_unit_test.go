@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestDisableRuleKeepsSecrets destroys imported rules with destroy_behavior =
+// "disable" and checks the write-only credentials the API holds, which state
+// doesn't, survive.
+func TestDisableRuleKeepsSecrets(t *testing.T) {
+	t.Parallel()
+
+	source := map[string]any{"channelFilter": "^orders", "type": "channel.message"}
+	tests := []struct {
+		resource resource.Resource
+		rule     controltest.Record
+		secret   []string // the path of the secret from the target
+	}{
+		{
+			resource: &ResourceRuleKafka{},
+			rule: controltest.Record{
+				"id": "r-kafka", "ruleType": "kafka", "requestMode": "single", "source": source,
+				"target": map[string]any{
+					"routingKey": "topic:key",
+					"brokers":    []any{"kafka.example.com:9092"},
+					"auth":       map[string]any{"sasl": map[string]any{"mechanism": "scram-sha-256", "username": "user", "password": "sasl-secret"}},
+				},
+			},
+			secret: []string{"auth", "sasl", "password"},
+		},
+		{
+			resource: &ResourceRuleKinesis{},
+			rule: controltest.Record{
+				"id": "r-kinesis", "ruleType": "aws/kinesis", "requestMode": "single", "source": source,
+				"target": map[string]any{
+					"region":         "eu-west-1",
+					"streamName":     "orders",
+					"partitionKey":   "key",
+					"authentication": map[string]any{"authenticationMode": "credentials", "accessKeyId": "AKID", "secretAccessKey": "aws-secret"},
+				},
+			},
+			secret: []string{"authentication", "secretAccessKey"},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		id := tt.rule["id"].(string)
+		t.Run(id, func(t *testing.T) {
+			t.Parallel()
+
+			fake := controltest.NewServer()
+			t.Cleanup(fake.Close)
+			fake.SeedRule("app1", tt.rule)
+			client := control.NewClient("fake-token", control.WithRetryMax(0))
+			client.BaseURL = fake.URL
+			p := &AblyProvider{configured: true, client: client, accountID: controltest.AccountID}
+
+			// Import the rule, as Read would with no prior state.
+			response, err := client.GetRule(ctx, "app1", id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			imported, diags := GetRuleResponse(&response, &AblyRule{})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			imported.DestroyBehavior = types.StringValue(destroyBehaviorDisable)
+
+			var schemaResp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, imported); diags.HasError() {
+				t.Fatalf("setting the state: %v", diags)
+			}
+
+			switch r := tt.resource.(type) {
+			case *ResourceRuleKafka:
+				r.p = p
+			case *ResourceRuleKinesis:
+				r.p = p
+			}
+			resp := &resource.DeleteResponse{State: state}
+			tt.resource.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var stored controltest.Record
+			fake.Inspect(func(state *controltest.State) { stored = state.Rules["app1"][id] })
+			if stored == nil {
+				t.Fatal("the rule was deleted, want it disabled")
+			}
+			if stored["status"] != "disabled" {
+				t.Errorf("status = %v, want disabled", stored["status"])
+			}
+			obj := stored["target"].(map[string]any)
+			for _, field := range tt.secret[:len(tt.secret)-1] {
+				obj = obj[field].(map[string]any)
+			}
+			if got := obj[tt.secret[len(tt.secret)-1]]; got == "" || got == nil {
+				t.Errorf("the stored %v was cleared", tt.secret)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/ably/terraform-provider-ably/control"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -513,28 +512,11 @@ resource "ably_rule_amqp" "amqp_rule" {
 // testAccCheckE2EDestroy verifies that the app (and by cascade all child resources)
 // have been deleted after the test completes.
 func testAccCheckE2EDestroy(s *terraform.State) error {
-	token := os.Getenv("ABLY_ACCOUNT_TOKEN")
-	if token == "" {
-		return fmt.Errorf("ABLY_ACCOUNT_TOKEN not set")
-	}
-
-	client := control.NewClient(token)
-	url := os.Getenv("ABLY_URL")
-	if url != "" {
-		client.BaseURL = url
-	}
-
 	ctx := context.Background()
-
-	// Get account ID via /me
-	me, err := client.Me(ctx)
+	client, accountID, err := testAccClient(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to call /me: %w", err)
-	}
-	if me.Account == nil || me.Account.ID == "" {
-		return fmt.Errorf("could not determine account ID from /me")
+		return err
 	}
-	accountID := me.Account.ID
 
 	// Check that the app is gone
 	for _, rs := range s.RootModule().Resources {
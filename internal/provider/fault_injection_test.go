@@ -0,0 +1,160 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// These tests make the hermetic fake fail, so they only run against it. Other
+// tests share the fake and may run in parallel, so each fault is scoped to the
+// paths of the test's own app, whose ID the first step captures.
+
+func skipUnlessHermetic(t *testing.T) {
+	t.Helper()
+	if hermeticFake == nil {
+		t.Skip("fault injection needs the hermetic fake; unset TF_ACC to use it")
+	}
+}
+
+// captureAppID stores the app's ID in *id once it has been created.
+func captureAppID(id *string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith("ably_app.app0", "id", func(v string) error {
+		*id = v
+		return nil
+	})
+}
+
+func TestAccFaultRetriesServerErrors(t *testing.T) {
+	skipUnlessHermetic(t)
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	var appID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFaultConfig(appName, false),
+				Check:  captureAppID(&appID),
+			},
+			// Two 503s are within the provider's retries, so the create
+			// succeeds.
+			{
+				PreConfig: func() {
+					hermeticFake.InjectFault(http.MethodPost, "/apps/"+appID+"/namespaces",
+						controltest.Fault{Status: http.StatusServiceUnavailable, Times: 2})
+				},
+				Config: testAccFaultConfig(appName, true),
+				Check:  resource.TestCheckResourceAttr("ably_namespace.namespace0", "id", "faulty"),
+			},
+		},
+	})
+}
+
+func TestAccFaultOutOfBandDeletion(t *testing.T) {
+	skipUnlessHermetic(t)
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	var appID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFaultConfig(appName, false),
+				Check:  captureAppID(&appID),
+			},
+			// The namespace is deleted behind Terraform's back straight after
+			// it is created, so the refresh after the apply finds it gone,
+			// drops it from state and plans to create it again.
+			{
+				PreConfig: func() {
+					hermeticFake.InjectFault(http.MethodPost, "/apps/"+appID+"/namespaces", controltest.Fault{
+						Times: 1,
+						Then:  func(state *controltest.State) { delete(state.Namespaces[appID], "faulty") },
+					})
+				},
+				Config:             testAccFaultConfig(appName, true),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccFaultConfig(appName, true),
+				Check:  resource.TestCheckResourceAttr("ably_namespace.namespace0", "id", "faulty"),
+			},
+		},
+	})
+}
+
+// TestAccFaultAppP12UploadRollsBack fails the PKCS#12 upload that follows
+// creating an app. The app is not in state yet, so it must be deleted again
+// rather than left for the next apply to duplicate.
+func TestAccFaultAppP12UploadRollsBack(t *testing.T) {
+	skipUnlessHermetic(t)
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	bundle := base64.StdEncoding.EncodeToString(testAccSelfSignedPKCS12(t, "password"))
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes need Terraform 1.11.
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				// Only this test uploads bundles to the shared fake, so the
+				// fault can match every app.
+				PreConfig: func() {
+					hermeticFake.InjectFault(http.MethodPost, "/apps/*/pkcs12",
+						controltest.Fault{Status: http.StatusBadRequest, Times: 1})
+				},
+				Config:      testAccAblyAppP12Config(appName, bundle, "password"),
+				ExpectError: regexp.MustCompile("Error uploading APNs PKCS#12 bundle"),
+			},
+		},
+	})
+	hermeticFake.Inspect(func(state *controltest.State) {
+		for id, app := range state.Apps {
+			if app["name"] == appName {
+				t.Errorf("app %s was left behind after its upload failed", id)
+			}
+		}
+	})
+}
+
+// testAccFaultConfig is an app with, when namespace is set, a namespace in it.
+// The provider retries quickly, so fault bursts do not slow the suite.
+func testAccFaultConfig(appName string, namespace bool) string {
+	config := fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {
+	retry_wait_min_seconds = 1
+	retry_wait_max_seconds = 1
+}
+
+resource "ably_app" "app0" {
+	name = %q
+}
+`, appName)
+	if namespace {
+		config += `
+resource "ably_namespace" "namespace0" {
+	app_id = ably_app.app0.id
+	id     = "faulty"
+}
+`
+	}
+	return config
+}
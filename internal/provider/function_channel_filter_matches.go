@@ -0,0 +1,56 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/ably/terraform-provider-ably/internal/provider/validators"
+)
+
+type FunctionChannelFilterMatches struct{}
+
+var _ function.Function = FunctionChannelFilterMatches{}
+
+func (f FunctionChannelFilterMatches) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "channel_filter_matches"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f FunctionChannelFilterMatches) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a rule's channel filter matches a channel name",
+		Description: "Given a rule's `source.channel_filter` and a channel name, returns whether the rule applies to that channel. " +
+			"As in Ably, the filter is searched for anywhere in the name, and an empty filter matches every channel. " +
+			"Use it in `terraform test` to check filters before they reach production. " +
+			"The provider evaluates filters with Go's RE2 engine, so filters using JavaScript syntax it lacks, such as lookarounds and backreferences, are an error.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "filter",
+				Description: "The channel filter, a JavaScript regular expression.",
+			},
+			function.StringParameter{
+				Name:        "channel",
+				Description: "The channel name to test the filter against.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run evaluates the function.
+func (f FunctionChannelFilterMatches) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filter, channel string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &filter, &channel))
+	if resp.Error != nil {
+		return
+	}
+
+	matches, err := validators.ChannelFilterMatches(filter, channel)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, matches))
+}
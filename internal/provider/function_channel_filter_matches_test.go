@@ -0,0 +1,48 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionChannelFilterMatches(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Provider-defined functions need Terraform 1.8.
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "lobby" {
+  value = provider::ably::channel_filter_matches("^chat:", "chat:lobby")
+}
+
+output "private" {
+  value = provider::ably::channel_filter_matches("^chat:", "private:chat:lobby")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("lobby", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("private", knownvalue.Bool(false)),
+				},
+			},
+			{
+				Config: `
+output "broken" {
+  value = provider::ably::channel_filter_matches("^(chat", "chat:lobby")
+}
+`,
+				ExpectError: regexp.MustCompile(`not a valid regular expression`),
+			},
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionChannelFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter    string
+		channel   string
+		want      bool
+		errSubstr string
+	}{
+		{filter: "", channel: "anything", want: true},
+		{filter: "^chat:", channel: "chat:lobby", want: true},
+		{filter: "^chat:", channel: "private:chat:lobby", want: false},
+		{filter: "lobby", channel: "chat:lobby:1", want: true},
+		{filter: "^(chat|support):[a-z]+$", channel: "support:tickets", want: true},
+		{filter: "^(chat|support):[a-z]+$", channel: "support:tickets-1", want: false},
+		{filter: "^(chat", channel: "chat", errSubstr: "not a valid regular expression"},
+		{filter: "(?i)chat", channel: "CHAT", errSubstr: "inline flags"},
+		{filter: "^(?!private:)", channel: "chat", errSubstr: "cannot evaluate"},
+	}
+	for _, tt := range tests {
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.filter), types.StringValue(tt.channel)}),
+		}
+		resp := &function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+		FunctionChannelFilterMatches{}.Run(context.Background(), req, resp)
+
+		if tt.errSubstr != "" {
+			if resp.Error == nil || !strings.Contains(resp.Error.Error(), tt.errSubstr) {
+				t.Errorf("channel_filter_matches(%q, %q) error = %v, want one containing %q", tt.filter, tt.channel, resp.Error, tt.errSubstr)
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Errorf("channel_filter_matches(%q, %q): unexpected error: %v", tt.filter, tt.channel, resp.Error)
+			continue
+		}
+		if got := resp.Result.Value(); !got.Equal(types.BoolValue(tt.want)) {
+			t.Errorf("channel_filter_matches(%q, %q) = %s, want %t", tt.filter, tt.channel, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AblyAppIdentity is the resource identity of an ably_app.
+type AblyAppIdentity struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// AblyAppScopedIdentity is the resource identity of a resource that lives in
+// an app: keys, namespaces, queues and rules.
+type AblyAppScopedIdentity struct {
+	AppID types.String `tfsdk:"app_id"`
+	ID    types.String `tfsdk:"id"`
+}
+
+// GetAppIdentitySchema returns the identity schema of ably_app.
+func GetAppIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The application ID.",
+			},
+		},
+	}
+}
+
+// GetAppScopedIdentitySchema returns the identity schema shared by every
+// resource that lives in an app.
+func GetAppScopedIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"app_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the app the resource belongs to.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the resource.",
+			},
+		},
+	}
+}
+
+// setAppIdentity records an app's identity. identity is nil when Terraform
+// does not support resource identity.
+func setAppIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, AblyAppIdentity{ID: types.StringValue(id)})
+}
+
+// setAppScopedIdentity records the identity of a resource in an app.
+// identity is nil when Terraform does not support resource identity.
+func setAppScopedIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, appID, id string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, AblyAppScopedIdentity{
+		AppID: types.StringValue(appID),
+		ID:    types.StringValue(id),
+	})
+}
+
+// ResolveChildID turns the human half of an "app/child" import identifier
+// into the resource's ID, given the ID of the app it lives in.
+type ResolveChildID func(ctx context.Context, p *AblyProvider, appID, ref string) (string, error)
+
+// ImportResource handles importing a resource that lives in an app. It
+// accepts the identity of an import block, the IDs as "app_id,id", or human
+// keys as "app/child", where app is an app ID or name and resolve turns child
+// into the resource's ID. A nil resolve takes child as the ID.
+func ImportResource(ctx context.Context, p *AblyProvider, req resource.ImportStateRequest, resp *resource.ImportStateResponse, resolve ResolveChildID) {
+	if req.ID == "" && req.Identity != nil {
+		var identity AblyAppScopedIdentity
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), identity.AppID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		return
+	}
+
+	var appID, id string
+	if ids := strings.Split(req.ID, ","); len(ids) == 2 && ids[0] != "" && ids[1] != "" {
+		appID, id = ids[0], ids[1]
+	} else if appRef, childRef, ok := strings.Cut(req.ID, "/"); ok && appRef != "" && childRef != "" && !strings.Contains(req.ID, ",") {
+		if !p.ensureConfigured(&resp.Diagnostics) {
+			return
+		}
+		var err error
+		if appID, err = resolveAppID(ctx, p, appRef); err != nil {
+			resp.Diagnostics.AddError("Cannot Resolve Import Identifier", err.Error())
+			return
+		}
+		id = childRef
+		if resolve != nil {
+			if id, err = resolve(ctx, p, appID, childRef); err != nil {
+				resp.Diagnostics.AddError("Cannot Resolve Import Identifier", err.Error())
+				return
+			}
+		}
+	} else {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format 'app_id,id' or 'app/name', where app is an app ID or name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// resolveAppID finds the app an import identifier refers to, by ID or else
+// by name.
+func resolveAppID(ctx context.Context, p *AblyProvider, ref string) (string, error) {
+	apps, err := p.client.ListApps(ctx, p.accountID)
+	if err != nil {
+		return "", fmt.Errorf("could not list apps: %w", err)
+	}
+	return resolveByIDOrName("app", ref, apps,
+		func(a control.AppResponse) string { return a.ID },
+		func(a control.AppResponse) string { return a.Name })
+}
+
+// resolveKeyID finds a key by ID or name. Revoked keys are skipped, as
+// ably_api_key reads them as deleted.
+func resolveKeyID(ctx context.Context, p *AblyProvider, appID, ref string) (string, error) {
+	keys, err := p.client.ListKeys(ctx, appID)
+	if err != nil {
+		return "", fmt.Errorf("could not list keys of app %s: %w", appID, err)
+	}
+	var active []control.KeyResponse
+	for _, k := range keys {
+		if k.Status == 0 {
+			active = append(active, k)
+		}
+	}
+	return resolveByIDOrName("key", ref, active,
+		func(k control.KeyResponse) string { return k.ID },
+		func(k control.KeyResponse) string { return k.Name })
+}
+
+// resolveQueueID finds a queue by ID or name.
+func resolveQueueID(ctx context.Context, p *AblyProvider, appID, ref string) (string, error) {
+	queues, err := p.client.ListQueues(ctx, appID)
+	if err != nil {
+		return "", fmt.Errorf("could not list queues of app %s: %w", appID, err)
+	}
+	return resolveByIDOrName("queue", ref, queues,
+		func(q control.QueueResponse) string { return q.ID },
+		func(q control.QueueResponse) string { return q.Name })
+}
+
+// resolveByIDOrName returns the ID of the item whose ID is ref, or else of
+// the one item named ref. Names are not unique, so a name several items share
+// is an error listing their IDs.
+func resolveByIDOrName[T any](kind, ref string, items []T, id, name func(T) string) (string, error) {
+	var named []string
+	for _, item := range items {
+		if id(item) == ref {
+			return ref, nil
+		}
+		if name(item) == ref {
+			named = append(named, id(item))
+		}
+	}
+	switch len(named) {
+	case 0:
+		return "", fmt.Errorf("no %s has the ID or name %q", kind, ref)
+	case 1:
+		return named[0], nil
+	default:
+		slices.Sort(named)
+		return "", fmt.Errorf("%d %ss are named %q (IDs %s); import by ID instead", len(named), kind, ref, strings.Join(named, ", "))
+	}
+}
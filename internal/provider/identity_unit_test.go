@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResolveByIDOrName(t *testing.T) {
+	t.Parallel()
+
+	type item struct{ id, name string }
+	items := []item{{"a1", "orders"}, {"a2", "audit"}, {"a3", "audit"}, {"orders", "other"}}
+	id := func(i item) string { return i.id }
+	name := func(i item) string { return i.name }
+
+	tests := []struct {
+		ref       string
+		want      string
+		errSubstr string
+	}{
+		{ref: "a2", want: "a2"},
+		// An ID beats a name, even one another item has.
+		{ref: "orders", want: "orders"},
+		{ref: "other", want: "orders"},
+		{ref: "audit", errSubstr: "a2, a3"},
+		{ref: "missing", errSubstr: `no queue has the ID or name "missing"`},
+	}
+	for _, tt := range tests {
+		got, err := resolveByIDOrName("queue", tt.ref, items, id, name)
+		if tt.errSubstr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("resolve(%q) error = %v, want one containing %q", tt.ref, err, tt.errSubstr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolve(%q) = %q, %v, want %q", tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportResource(t *testing.T) {
+	t.Parallel()
+
+	fake := controltest.NewServer()
+	t.Cleanup(fake.Close)
+	fake.SeedApp(controltest.Record{"id": "app1", "name": "Chat"})
+	fake.SeedApp(controltest.Record{"id": "app2", "name": "Twin"})
+	fake.SeedApp(controltest.Record{"id": "app3", "name": "Twin"})
+	fake.SeedKey("app1", controltest.Record{"id": "key1", "name": "root"})
+	fake.SeedKey("app1", controltest.Record{"id": "key2", "name": "shared"})
+	fake.SeedKey("app1", controltest.Record{"id": "key3", "name": "shared"})
+	// Revoked, so not a candidate for its name.
+	fake.SeedKey("app1", controltest.Record{"id": "key4", "name": "root", "status": 1})
+
+	client := control.NewClient("fake-token", control.WithRetryMax(0))
+	client.BaseURL = fake.URL
+	p := &AblyProvider{configured: true, client: client, accountID: controltest.AccountID}
+
+	stateSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{Required: true},
+			"id":     schema.StringAttribute{Computed: true},
+		},
+	}
+	stateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"app_id": tftypes.String,
+		"id":     tftypes.String,
+	}}
+
+	tests := []struct {
+		id        string
+		wantApp   string
+		wantID    string
+		errSubstr string
+	}{
+		{id: "app1,key9", wantApp: "app1", wantID: "key9"},
+		{id: "Chat/root", wantApp: "app1", wantID: "key1"},
+		{id: "app1/key2", wantApp: "app1", wantID: "key2"},
+		{id: "Chat/shared", errSubstr: "key2, key3"},
+		{id: "Twin/root", errSubstr: "app2, app3"},
+		{id: "Nowhere/root", errSubstr: "no app"},
+		{id: "key1", errSubstr: "'app_id,id' or 'app/name'"},
+		{id: "app1,", errSubstr: "'app_id,id' or 'app/name'"},
+	}
+	for _, tt := range tests {
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{Schema: stateSchema, Raw: tftypes.NewValue(stateType, nil)},
+		}
+		ImportResource(context.Background(), p, resource.ImportStateRequest{ID: tt.id}, resp, resolveKeyID)
+
+		if tt.errSubstr != "" {
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.errSubstr) {
+				t.Errorf("import %q: diagnostics = %v, want an error containing %q", tt.id, resp.Diagnostics, tt.errSubstr)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Errorf("import %q: unexpected error: %v", tt.id, resp.Diagnostics)
+			continue
+		}
+		var appID, id types.String
+		resp.State.GetAttribute(context.Background(), path.Root("app_id"), &appID)
+		resp.State.GetAttribute(context.Background(), path.Root("id"), &id)
+		if appID.ValueString() != tt.wantApp || id.ValueString() != tt.wantID {
+			t.Errorf("import %q = %s,%s, want %s,%s", tt.id, appID, id, tt.wantApp, tt.wantID)
+		}
+	}
+}
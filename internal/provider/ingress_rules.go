@@ -6,14 +6,8 @@ import (
 	"fmt"
 
 	"github.com/ably/terraform-provider-ably/control"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -28,7 +22,7 @@ func GetPlanIngressRule(plan AblyIngressRule) (any, diag.Diagnostics) {
 			Status:   status,
 			RuleType: "ingress/mongodb",
 			Target: control.IngressMongoDBTarget{
-				URL:                      t.Url.ValueString(),
+				URL:                      t.URL.ValueString(),
 				Database:                 t.Database.ValueString(),
 				Collection:               t.Collection.ValueString(),
 				Pipeline:                 t.Pipeline.ValueString(),
@@ -39,20 +33,20 @@ func GetPlanIngressRule(plan AblyIngressRule) (any, diag.Diagnostics) {
 		}, diags
 	case *AblyIngressRuleTargetPostgresOutbox:
 		var sslRootCert *string
-		if !t.SslRootCert.IsNull() && !t.SslRootCert.IsUnknown() {
-			v := t.SslRootCert.ValueString()
+		if !t.SSLRootCert.IsNull() && !t.SSLRootCert.IsUnknown() {
+			v := t.SSLRootCert.ValueString()
 			sslRootCert = &v
 		}
 		return control.IngressPostgresOutboxRulePost{
 			Status:   status,
 			RuleType: "ingress-postgres-outbox",
 			Target: control.IngressPostgresOutboxTarget{
-				URL:               t.Url.ValueString(),
+				URL:               t.URL.ValueString(),
 				OutboxTableSchema: t.OutboxTableSchema.ValueString(),
 				OutboxTableName:   t.OutboxTableName.ValueString(),
 				NodesTableSchema:  t.NodesTableSchema.ValueString(),
 				NodesTableName:    t.NodesTableName.ValueString(),
-				SSLMode:           t.SslMode.ValueString(),
+				SSLMode:           t.SSLMode.ValueString(),
 				SSLRootCert:       sslRootCert,
 				PrimarySite:       t.PrimarySite.ValueString(),
 			},
@@ -81,7 +75,7 @@ func GetIngressRuleResponse(ablyRule *control.RuleResponse, plan *AblyIngressRul
 			return AblyIngressRule{}, diags
 		}
 		respTarget = &AblyIngressRuleTargetMongo{
-			Url:                      types.StringValue(target.URL),
+			URL:                      types.StringValue(target.URL),
 			Database:                 types.StringValue(target.Database),
 			Collection:               types.StringValue(target.Collection),
 			Pipeline:                 types.StringValue(target.Pipeline),
@@ -96,13 +90,13 @@ func GetIngressRuleResponse(ablyRule *control.RuleResponse, plan *AblyIngressRul
 			return AblyIngressRule{}, diags
 		}
 		respTarget = &AblyIngressRuleTargetPostgresOutbox{
-			Url:               types.StringValue(target.URL),
+			URL:               types.StringValue(target.URL),
 			OutboxTableSchema: types.StringValue(target.OutboxTableSchema),
 			OutboxTableName:   types.StringValue(target.OutboxTableName),
 			NodesTableSchema:  types.StringValue(target.NodesTableSchema),
 			NodesTableName:    types.StringValue(target.NodesTableName),
-			SslMode:           types.StringValue(target.SSLMode),
-			SslRootCert:       optStringValue(target.SSLRootCert),
+			SSLMode:           types.StringValue(target.SSLMode),
+			SSLRootCert:       optStringValue(target.SSLRootCert),
 			PrimarySite:       types.StringValue(target.PrimarySite),
 		}
 	default:
@@ -119,46 +113,13 @@ func GetIngressRuleResponse(ablyRule *control.RuleResponse, plan *AblyIngressRul
 		Status: types.StringValue(ablyRule.Status),
 		Target: respTarget,
 	}
+	if plan != nil {
+		respRule.DestroyBehavior = destroyBehaviorValue(plan.DestroyBehavior)
+	}
 
 	return respRule, diags
 }
 
-func GetIngressRuleSchema(target map[string]schema.Attribute, markdownDescription string) schema.Schema {
-	return schema.Schema{
-		MarkdownDescription: markdownDescription,
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed:    true,
-				Description: "The rule ID.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"app_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The Ably application ID.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"status": schema.StringAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "The status of the rule. Rules can be enabled or disabled.",
-				Default:     stringdefault.StaticString("enabled"),
-				Validators: []validator.String{
-					stringvalidator.OneOf("enabled", "disabled"),
-				},
-			},
-			"target": schema.SingleNestedAttribute{
-				Required:    true,
-				Description: "The target for the ingress rule",
-				Attributes:  target,
-			},
-		},
-	}
-}
-
 // CreateIngressRule creates a new ingress rule resource.
 func CreateIngressRule[T any](r Rule, ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	if !r.Provider().ensureConfigured(&resp.Diagnostics) {
@@ -196,6 +157,7 @@ func CreateIngressRule[T any](r Rule, ctx context.Context, req resource.CreateRe
 
 	diags = resp.State.Set(ctx, responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -240,6 +202,7 @@ func ReadIngressRule[T any](r Rule, ctx context.Context, req resource.ReadReques
 
 	diags = resp.State.Set(ctx, &responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -286,6 +249,7 @@ func UpdateIngressRule[T any](r Rule, ctx context.Context, req resource.UpdateRe
 
 	diags = resp.State.Set(ctx, &responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -309,7 +273,22 @@ func DeleteIngressRule[T any](r Rule, ctx context.Context, req resource.DeleteRe
 	appID := state.AppID.ValueString()
 	ruleID := state.ID.ValueString()
 
-	err := r.Provider().client.DeleteRule(ctx, appID, ruleID)
+	// With destroy_behavior = "disable" the rule is sent back as it is in
+	// state, but disabled, and left for a cleanup job to delete. Unlike a
+	// webhook or firehose rule's, the ingress PATCH requires the target, and
+	// the API returns all of it, so state holds it whole.
+	var err error
+	if state.DestroyBehavior.ValueString() == destroyBehaviorDisable {
+		state.Status = types.StringValue("disabled")
+		ruleValues, planDiags := GetPlanIngressRule(state)
+		resp.Diagnostics.Append(planDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, err = r.Provider().client.UpdateRule(ctx, appID, ruleID, ruleValues)
+	} else {
+		err = r.Provider().client.DeleteRule(ctx, appID, ruleID)
+	}
 	if err != nil {
 		if is404(err) {
 			resp.Diagnostics.AddWarning(
@@ -32,6 +32,33 @@ type AblyApp struct {
 	ApnsSigningKeyConfigured    types.Bool   `tfsdk:"apns_signing_key_configured"`
 }
 
+// AblyAppBundle represents an Ably application with the standard set of keys,
+// namespaces and queue an ably_app_bundle creates in it.
+type AblyAppBundle struct {
+	ID                    types.String        `tfsdk:"id"`
+	Name                  types.String        `tfsdk:"name"`
+	Namespaces            types.Set           `tfsdk:"namespaces"`
+	Queue                 *AblyAppBundleQueue `tfsdk:"queue"`
+	ServerKeyID           types.String        `tfsdk:"server_key_id"`
+	ServerKey             types.String        `tfsdk:"server_key"`
+	ClientKeyID           types.String        `tfsdk:"client_key_id"`
+	ClientKey             types.String        `tfsdk:"client_key"`
+	QueueID               types.String        `tfsdk:"queue_id"`
+	QueueAmqpURI          types.String        `tfsdk:"queue_amqp_uri"`
+	QueueAmqpQueueName    types.String        `tfsdk:"queue_amqp_queue_name"`
+	QueueStompURI         types.String        `tfsdk:"queue_stomp_uri"`
+	QueueStompHost        types.String        `tfsdk:"queue_stomp_host"`
+	QueueStompDestination types.String        `tfsdk:"queue_stomp_destination"`
+}
+
+// AblyAppBundleQueue represents the queue of an ably_app_bundle.
+type AblyAppBundleQueue struct {
+	Name      types.String `tfsdk:"name"`
+	Ttl       types.Int64  `tfsdk:"ttl"`
+	MaxLength types.Int64  `tfsdk:"max_length"`
+	Region    types.String `tfsdk:"region"`
+}
+
 // AblyNamespace represents an Ably namespace.
 type AblyNamespace struct {
 	AppID                   types.String `tfsdk:"app_id"`
@@ -50,6 +77,7 @@ type AblyNamespace struct {
 	ConflationEnabled       types.Bool   `tfsdk:"conflation_enabled"`
 	ConflationInterval      types.Int64  `tfsdk:"conflation_interval"`
 	ConflationKey           types.String `tfsdk:"conflation_key"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
 }
 
 // AblyKey represents an Ably API key.
@@ -82,6 +110,57 @@ type AblyQueue struct {
 	State            types.String `tfsdk:"state"`
 	Deadletter       types.Bool   `tfsdk:"deadletter"`
 	DeadletterID     types.String `tfsdk:"deadletter_id"`
+
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+	ReplacementMode     types.String `tfsdk:"replacement_mode"`
+	DrainTimeoutSeconds types.Int64  `tfsdk:"drain_timeout_seconds"`
+	WaitForState        types.String `tfsdk:"wait_for_state"`
+}
+
+// AblyQueueStatus represents the live state of an Ably queue, as read by the
+// ably_queue_status data source.
+type AblyQueueStatus struct {
+	AppID        types.String             `tfsdk:"app_id"`
+	ID           types.String             `tfsdk:"id"`
+	Name         types.String             `tfsdk:"name"`
+	State        types.String             `tfsdk:"state"`
+	Deadletter   types.Bool               `tfsdk:"deadletter"`
+	DeadletterID types.String             `tfsdk:"deadletter_id"`
+	Messages     *AblyQueueStatusMessages `tfsdk:"messages"`
+	Stats        *AblyQueueStatusStats    `tfsdk:"stats"`
+}
+
+// AblyQueueDeadletter represents the dead letter queue of an Ably queue, as
+// read by the ably_queue_deadletter data source.
+type AblyQueueDeadletter struct {
+	AppID     types.String `tfsdk:"app_id"`
+	QueueID   types.String `tfsdk:"queue_id"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Region    types.String `tfsdk:"region"`
+	State     types.String `tfsdk:"state"`
+	Ttl       types.Int64  `tfsdk:"ttl"`
+	MaxLength types.Int64  `tfsdk:"max_length"`
+
+	AmqpUri          types.String `tfsdk:"amqp_uri"`
+	AmqpQueueName    types.String `tfsdk:"amqp_queue_name"`
+	StompURI         types.String `tfsdk:"stomp_uri"`
+	StompHost        types.String `tfsdk:"stomp_host"`
+	StompDestination types.String `tfsdk:"stomp_destination"`
+}
+
+// AblyQueueStatusMessages holds the message counts of a queue.
+type AblyQueueStatusMessages struct {
+	Ready          types.Int64 `tfsdk:"ready"`
+	Unacknowledged types.Int64 `tfsdk:"unacknowledged"`
+	Total          types.Int64 `tfsdk:"total"`
+}
+
+// AblyQueueStatusStats holds the message rates of a queue.
+type AblyQueueStatusStats struct {
+	PublishRate         types.Float64 `tfsdk:"publish_rate"`
+	DeliveryRate        types.Float64 `tfsdk:"delivery_rate"`
+	AcknowledgementRate types.Float64 `tfsdk:"acknowledgement_rate"`
 }
 
 func emptyStringToNull(v *types.String) {
@@ -142,201 +221,45 @@ func toTypedStringSlice(slice []string) []types.String {
 // IngressRule returns the ingress rule from the decoder.
 func (r *AblyIngressRuleDecoder[_]) IngressRule() AblyIngressRule {
 	return AblyIngressRule{
-		ID:     r.ID,
-		AppID:  r.AppID,
-		Status: r.Status,
-		Target: r.Target,
+		ID:              r.ID,
+		AppID:           r.AppID,
+		Status:          r.Status,
+		Target:          r.Target,
+		DestroyBehavior: r.DestroyBehavior,
 	}
 }
 
 type AblyIngressRuleDecoder[T any] struct {
-	ID     types.String `tfsdk:"id"`
-	AppID  types.String `tfsdk:"app_id"`
-	Status types.String `tfsdk:"status"`
-	Target T            `tfsdk:"target"`
+	ID              types.String `tfsdk:"id"`
+	AppID           types.String `tfsdk:"app_id"`
+	Status          types.String `tfsdk:"status"`
+	Target          T            `tfsdk:"target"`
+	DestroyBehavior types.String `tfsdk:"destroy_behavior"`
 }
 
 type AblyIngressRule AblyIngressRuleDecoder[any]
 
-type AblyIngressRuleTargetMongo struct {
-	Url                      types.String `tfsdk:"url"`
-	Database                 types.String `tfsdk:"database"`
-	Collection               types.String `tfsdk:"collection"`
-	Pipeline                 types.String `tfsdk:"pipeline"`
-	FullDocument             types.String `tfsdk:"full_document"`
-	FullDocumentBeforeChange types.String `tfsdk:"full_document_before_change"`
-	PrimarySite              types.String `tfsdk:"primary_site"`
-}
-
-type AblyIngressRuleTargetPostgresOutbox struct {
-	Url               types.String `tfsdk:"url"`
-	OutboxTableSchema types.String `tfsdk:"outbox_table_schema"`
-	OutboxTableName   types.String `tfsdk:"outbox_table_name"`
-	NodesTableSchema  types.String `tfsdk:"nodes_table_schema"`
-	NodesTableName    types.String `tfsdk:"nodes_table_name"`
-	SslMode           types.String `tfsdk:"ssl_mode"`
-	SslRootCert       types.String `tfsdk:"ssl_root_cert"`
-	PrimarySite       types.String `tfsdk:"primary_site"`
-}
-
-// AblyRuleSource represents a source for Ably rules.
-type AblyRuleSource struct {
-	ChannelFilter types.String `tfsdk:"channel_filter"`
-	Type          types.String `tfsdk:"type"`
-}
-
 // Rule returns the rule from the decoder.
 func (r *AblyRuleDecoder[_]) Rule() AblyRule {
 	return AblyRule{
-		ID:          r.ID,
-		AppID:       r.AppID,
-		Status:      r.Status,
-		RequestMode: r.RequestMode,
-		Source:      r.Source,
-		Target:      r.Target,
+		ID:              r.ID,
+		AppID:           r.AppID,
+		Status:          r.Status,
+		RequestMode:     r.RequestMode,
+		Source:          r.Source,
+		Target:          r.Target,
+		DestroyBehavior: r.DestroyBehavior,
 	}
 }
 
 type AblyRuleDecoder[T any] struct {
-	ID          types.String    `tfsdk:"id"`
-	AppID       types.String    `tfsdk:"app_id"`
-	Status      types.String    `tfsdk:"status"`
-	RequestMode types.String    `tfsdk:"request_mode"`
-	Source      *AblyRuleSource `tfsdk:"source"`
-	Target      T               `tfsdk:"target"`
+	ID              types.String    `tfsdk:"id"`
+	AppID           types.String    `tfsdk:"app_id"`
+	Status          types.String    `tfsdk:"status"`
+	RequestMode     types.String    `tfsdk:"request_mode"`
+	Source          *AblyRuleSource `tfsdk:"source"`
+	Target          T               `tfsdk:"target"`
+	DestroyBehavior types.String    `tfsdk:"destroy_behavior"`
 }
 
 type AblyRule AblyRuleDecoder[any]
-
-type AblyRuleTargetKinesis struct {
-	Region       types.String `tfsdk:"region"`
-	StreamName   types.String `tfsdk:"stream_name"`
-	PartitionKey types.String `tfsdk:"partition_key"`
-	AwsAuth      AwsAuth      `tfsdk:"authentication"`
-	Enveloped    types.Bool   `tfsdk:"enveloped"`
-	Format       types.String `tfsdk:"format"`
-}
-
-type AwsAuth struct {
-	AuthenticationMode types.String `tfsdk:"mode"`
-	RoleArn            types.String `tfsdk:"role_arn"`
-	AccessKeyId        types.String `tfsdk:"access_key_id"`
-	SecretAccessKey    types.String `tfsdk:"secret_access_key"`
-}
-
-type AblyRuleTargetSqs struct {
-	Region       types.String `tfsdk:"region"`
-	AwsAccountID types.String `tfsdk:"aws_account_id"`
-	QueueName    types.String `tfsdk:"queue_name"`
-	AwsAuth      AwsAuth      `tfsdk:"authentication"`
-	Enveloped    types.Bool   `tfsdk:"enveloped"`
-	Format       types.String `tfsdk:"format"`
-}
-
-type AblyRuleTargetLambda struct {
-	Region       types.String `tfsdk:"region"`
-	FunctionName types.String `tfsdk:"function_name"`
-	AwsAuth      AwsAuth      `tfsdk:"authentication"`
-	Enveloped    types.Bool   `tfsdk:"enveloped"`
-}
-
-type AblyRuleTargetGoogleFunction struct {
-	Region       types.String      `tfsdk:"region"`
-	ProjectID    types.String      `tfsdk:"project_id"`
-	FunctionName types.String      `tfsdk:"function_name"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId types.String      `tfsdk:"signing_key_id"`
-	Enveloped    types.Bool        `tfsdk:"enveloped"`
-	Format       types.String      `tfsdk:"format"`
-}
-
-type AblyRuleTargetCloudflareWorker struct {
-	Url          types.String      `tfsdk:"url"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId types.String      `tfsdk:"signing_key_id"`
-}
-
-type AblyRuleTargetHTTP struct {
-	Url          types.String      `tfsdk:"url"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId types.String      `tfsdk:"signing_key_id"`
-	Format       types.String      `tfsdk:"format"`
-	Enveloped    types.Bool        `tfsdk:"enveloped"`
-}
-
-type AblyRuleTargetPulsar struct {
-	RoutingKey     types.String         `tfsdk:"routing_key"`
-	Topic          types.String         `tfsdk:"topic"`
-	ServiceURL     types.String         `tfsdk:"service_url"`
-	TlsTrustCerts  []types.String       `tfsdk:"tls_trust_certs"`
-	Authentication PulsarAuthentication `tfsdk:"authentication"`
-	Enveloped      types.Bool           `tfsdk:"enveloped"`
-	Format         types.String         `tfsdk:"format"`
-}
-
-type PulsarAuthentication struct {
-	Mode  types.String `tfsdk:"mode"`
-	Token types.String `tfsdk:"token"`
-}
-
-type AblyRuleTargetZapier struct {
-	Url          types.String      `tfsdk:"url"`
-	Headers      []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyId types.String      `tfsdk:"signing_key_id"`
-}
-
-type AblyRuleTargetIFTTT struct {
-	WebhookKey types.String `tfsdk:"webhook_key"`
-	EventName  types.String `tfsdk:"event_name"`
-}
-
-type AblyRuleTargetAzureFunction struct {
-	AzureAppID        types.String      `tfsdk:"azure_app_id"`
-	AzureFunctionName types.String      `tfsdk:"function_name"`
-	Headers           []AblyRuleHeaders `tfsdk:"headers"`
-	SigningKeyID      types.String      `tfsdk:"signing_key_id"`
-	Enveloped         types.Bool        `tfsdk:"enveloped"`
-	Format            types.String      `tfsdk:"format"`
-}
-
-type AblyRuleHeaders struct {
-	Name  types.String `tfsdk:"name"`
-	Value types.String `tfsdk:"value"`
-}
-
-type AblyRuleTargetKafka struct {
-	RoutingKey          types.String        `tfsdk:"routing_key"`
-	Brokers             []types.String      `tfsdk:"brokers"`
-	KafkaAuthentication KafkaAuthentication `tfsdk:"auth"`
-	Enveloped           types.Bool          `tfsdk:"enveloped"`
-	Format              types.String        `tfsdk:"format"`
-}
-
-type AblyRuleTargetAMQP struct {
-	QueueID   types.String      `tfsdk:"queue_id"`
-	Headers   []AblyRuleHeaders `tfsdk:"headers"`
-	Enveloped types.Bool        `tfsdk:"enveloped"`
-	Format    types.String      `tfsdk:"format"`
-}
-
-type AblyRuleTargetAMQPExternal struct {
-	Url                types.String      `tfsdk:"url"`
-	RoutingKey         types.String      `tfsdk:"routing_key"`
-	Exchange           types.String      `tfsdk:"exchange"`
-	MandatoryRoute     types.Bool        `tfsdk:"mandatory_route"`
-	PersistentMessages types.Bool        `tfsdk:"persistent_messages"`
-	MessageTtl         types.Int64       `tfsdk:"message_ttl"`
-	Headers            []AblyRuleHeaders `tfsdk:"headers"`
-	Enveloped          types.Bool        `tfsdk:"enveloped"`
-	Format             types.String      `tfsdk:"format"`
-}
-
-type KafkaAuthentication struct {
-	Sasl Sasl `tfsdk:"sasl"`
-}
-
-type Sasl struct {
-	Mechanism types.String `tfsdk:"mechanism"`
-	Username  types.String `tfsdk:"username"`
-	Password  types.String `tfsdk:"password"`
-}
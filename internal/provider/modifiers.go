@@ -3,6 +3,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -214,3 +215,100 @@ func (m sortSetsInMapModifier) PlanModifyMap(ctx context.Context, req planmodifi
 func SortSetsInMap() planmodifier.Map {
 	return sortSetsInMapModifier{}
 }
+
+// base64DigestModifier plans a computed attribute as the SHA-256 of the bytes
+// another, usually write-only, attribute encodes in base64. A changed source
+// plans a new digest and so an update; a source removed from config keeps the
+// prior digest, since nothing is uploaded.
+type base64DigestModifier struct {
+	source path.Path
+}
+
+func (m base64DigestModifier) Description(_ context.Context) string {
+	return "Plans the SHA-256 of the base64-encoded content of another attribute."
+}
+
+func (m base64DigestModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m base64DigestModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var source types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.source, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case source.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+	case source.IsNull():
+		resp.PlanValue = req.StateValue
+	default:
+		digest, err := base64Digest(source.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				m.source,
+				"Invalid base64",
+				fmt.Sprintf("%s is not valid base64: %s", m.source, err),
+			)
+			return
+		}
+		resp.PlanValue = types.StringValue(digest)
+	}
+}
+
+// Base64DigestAttribute returns a plan modifier that plans the SHA-256 of the
+// base64-encoded source attribute. See [base64DigestModifier].
+func Base64DigestAttribute(source path.Path) planmodifier.String {
+	return base64DigestModifier{source: source}
+}
+
+// stateUnlessChangedModifier keeps a computed attribute's prior state while
+// another attribute it derives from is unchanged. When the source changes the
+// value is unknown until apply, and when the source is removed it is null.
+// Unlike stringplanmodifier.UseStateForUnknown, it does not keep a value that
+// apply is about to replace.
+type stateUnlessChangedModifier struct {
+	source path.Path
+}
+
+func (m stateUnlessChangedModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Keeps the prior state value unless %s changes.", m.source)
+}
+
+func (m stateUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m stateUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	// prior stays nil on create, when there is no state.
+	var planned, prior attr.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.source, &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.source, &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case planned.IsNull():
+		resp.PlanValue = types.StringNull()
+	case prior != nil && planned.Equal(prior) && !req.StateValue.IsNull():
+		resp.PlanValue = req.StateValue
+	default:
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+// UseStateUnlessChanged returns a plan modifier that keeps the prior state of
+// a computed attribute while source is unchanged. See
+// [stateUnlessChangedModifier].
+func UseStateUnlessChanged(source path.Path) planmodifier.String {
+	return stateUnlessChangedModifier{source: source}
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBase64DigestModifier(t *testing.T) {
+	t.Parallel()
+
+	// The digest of some earlier bundle, and of "bundle" ("YnVuZGxl").
+	const digest = "0000000000000000000000000000000000000000000000000000000000000000"
+	bundleDigest, err := base64Digest("YnVuZGxl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  tftypes.Value
+		state   types.String
+		want    types.String
+		wantErr bool
+	}{
+		{name: "set on create", source: tftypes.NewValue(tftypes.String, "YnVuZGxl"), state: types.StringNull(), want: types.StringValue(bundleDigest)},
+		{name: "unchanged", source: tftypes.NewValue(tftypes.String, "YnVuZGxl"), state: types.StringValue(bundleDigest), want: types.StringValue(bundleDigest)},
+		{name: "changed", source: tftypes.NewValue(tftypes.String, "YnVuZGxl"), state: types.StringValue(digest), want: types.StringValue(bundleDigest)},
+		{name: "unset keeps the last digest", source: tftypes.NewValue(tftypes.String, nil), state: types.StringValue(digest), want: types.StringValue(digest)},
+		{name: "unset on create", source: tftypes.NewValue(tftypes.String, nil), state: types.StringNull(), want: types.StringNull()},
+		{name: "unknown", source: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), state: types.StringValue(digest), want: types.StringUnknown()},
+		{name: "not base64", source: tftypes.NewValue(tftypes.String, "not base64!"), state: types.StringNull(), wantErr: true},
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"bundle": schema.StringAttribute{Optional: true, WriteOnly: true},
+			"digest": schema.StringAttribute{Computed: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"bundle": tftypes.String,
+		"digest": tftypes.String,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				Path: path.Root("digest"),
+				Config: tfsdk.Config{
+					Schema: configSchema,
+					Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+						"bundle": tt.source,
+						"digest": tftypes.NewValue(tftypes.String, nil),
+					}),
+				},
+				ConfigValue: types.StringNull(),
+				PlanValue:   types.StringUnknown(),
+				StateValue:  tt.state,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			Base64DigestAttribute(path.Root("bundle")).PlanModifyString(context.Background(), req, resp)
+
+			if tt.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected an error, planned %s", resp.PlanValue)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("planned %s, want %s", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateUnlessChangedModifier(t *testing.T) {
+	t.Parallel()
+
+	str := func(v any) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	tests := []struct {
+		name   string
+		source tftypes.Value // planned
+		prior  tftypes.Value // nil on create
+		state  types.String
+		want   types.String
+	}{
+		{name: "create", source: str("q1"), state: types.StringNull(), want: types.StringUnknown()},
+		{name: "unchanged", source: str("q1"), prior: str("q1"), state: types.StringValue("id1"), want: types.StringValue("id1")},
+		{name: "changed", source: str("q2"), prior: str("q1"), state: types.StringValue("id1"), want: types.StringUnknown()},
+		{name: "added", source: str("q1"), prior: str(nil), state: types.StringNull(), want: types.StringUnknown()},
+		{name: "removed", source: str(nil), prior: str("q1"), state: types.StringValue("id1"), want: types.StringNull()},
+		{name: "unknown", source: str(tftypes.UnknownValue), prior: str("q1"), state: types.StringValue("id1"), want: types.StringUnknown()},
+		{name: "unchanged but lost", source: str("q1"), prior: str("q1"), state: types.StringNull(), want: types.StringUnknown()},
+	}
+
+	resourceSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"queue":    schema.StringAttribute{Optional: true},
+			"queue_id": schema.StringAttribute{Computed: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"queue":    tftypes.String,
+		"queue_id": tftypes.String,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := tftypes.NewValue(objectType, nil)
+			if tt.prior.Type() != nil {
+				state = tftypes.NewValue(objectType, map[string]tftypes.Value{"queue": tt.prior, "queue_id": str(nil)})
+			}
+			req := planmodifier.StringRequest{
+				Path: path.Root("queue_id"),
+				Plan: tfsdk.Plan{
+					Schema: resourceSchema,
+					Raw:    tftypes.NewValue(objectType, map[string]tftypes.Value{"queue": tt.source, "queue_id": str(tftypes.UnknownValue)}),
+				},
+				State:       tfsdk.State{Schema: resourceSchema, Raw: state},
+				ConfigValue: types.StringNull(),
+				PlanValue:   types.StringUnknown(),
+				StateValue:  tt.state,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			UseStateUnlessChanged(path.Root("queue")).PlanModifyString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("planned %s, want %s", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +23,7 @@ const controlAPIDefaultURL = "https://control.ably.net/v1"
 
 // Ensure AblyProvider satisfies various provider interfaces.
 var _ provider.Provider = &AblyProvider{}
+var _ provider.ProviderWithFunctions = &AblyProvider{}
 
 type AblyProvider struct {
 	// configured is set to true after the provider has been successfully configured.
@@ -30,6 +33,10 @@ type AblyProvider struct {
 	client     *control.Client
 	accountID  string
 	version    string
+
+	// protectApps, when set, matches the names of apps Delete refuses to
+	// remove. It comes from the protect_apps_matching attribute.
+	protectApps *regexp.Regexp
 }
 
 func (p *AblyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -70,6 +77,10 @@ func (p *AblyProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Description: "Maximum wait, in seconds, between retries, capping the exponential backoff. Can also be set via the `ABLY_RETRY_WAIT_MAX_SECONDS` environment variable. Defaults to 60.",
 				Optional:    true,
 			},
+			"protect_apps_matching": schema.StringAttribute{
+				Description: "A regular expression (RE2 syntax) matched against app names. Apps whose names match cannot be deleted by `ably_app` or `ably_app_bundle`, whatever their `deletion_protection`. Can also be set via the `ABLY_PROTECT_APPS_MATCHING` environment variable.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -81,6 +92,7 @@ type AblyProviderData struct {
 	RetryMax            types.Int64  `tfsdk:"retry_max"`
 	RetryWaitMinSeconds types.Int64  `tfsdk:"retry_wait_min_seconds"`
 	RetryWaitMaxSeconds types.Int64  `tfsdk:"retry_wait_max_seconds"`
+	ProtectAppsMatching types.String `tfsdk:"protect_apps_matching"`
 }
 
 func (p *AblyProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -188,6 +200,22 @@ func (p *AblyProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	// Resolve the app name pattern protected from deletion. An unknown
+	// pattern cannot protect anything, so it is an error rather than unset.
+	if config.ProtectAppsMatching.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Invalid protect_apps_matching",
+			"Cannot use unknown value as protect_apps_matching. Ensure the provider's protect_apps_matching parameter is known at plan time.",
+		)
+		return
+	}
+	protectApps, err := resolveProtectApps(config.ProtectAppsMatching)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid protect_apps_matching", err.Error())
+		return
+	}
+	p.protectApps = protectApps
+
 	c := control.NewClient(token, opts...)
 	c.BaseURL = url
 	c.UserAgent += " terraform-provider-ably/" + p.version
@@ -243,32 +271,28 @@ func resolveRetryInt(attr types.Int64, attrName, envVar string) (value int, ok b
 
 // Resources - Gets the resources that this provider provides
 func (p *AblyProvider) Resources(context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	// The rule resources are generated from the shims table in
+	// codegen/ruletypesgen/shims.go.
+	return append([]func() resource.Resource{
 		func() resource.Resource { return ResourceApp{p} },
+		func() resource.Resource { return ResourceAppBundle{p} },
 		func() resource.Resource { return ResourceNamespace{p} },
 		func() resource.Resource { return &ResourceKey{p} },
 		func() resource.Resource { return ResourceQueue{p} },
-		func() resource.Resource { return ResourceRuleKinesis{p} },
-		func() resource.Resource { return ResourceRuleSqs{p} },
-		func() resource.Resource { return ResourceRuleLambda{p} },
-		func() resource.Resource { return ResourceRulePulsar{p} },
-		func() resource.Resource { return ResourceRuleZapier{p} },
-		func() resource.Resource { return ResourceRuleGoogleFunction{p} },
-		func() resource.Resource { return ResourceRuleIFTTT{p} },
-		func() resource.Resource { return ResourceRuleCloudflareWorker{p} },
-		func() resource.Resource { return ResourceRuleAzureFunction{p} },
-		func() resource.Resource { return ResourceRuleHTTP{p} },
-		func() resource.Resource { return ResourceRuleKafka{p} },
-		func() resource.Resource { return ResourceRuleAMQP{p} },
-		func() resource.Resource { return ResourceRuleAMQPExternal{p} },
-		func() resource.Resource { return ResourceRuleBodyguard{p} },
-		func() resource.Resource { return ResourceIngressRuleMongo{p} },
-		func() resource.Resource { return ResourceIngressRulePostgresOutbox{p} },
-	}
-
+	}, ruleResources(p)...)
 }
 
 // DataSources - Gets the data sources this provider provides
 func (p *AblyProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		func() datasource.DataSource { return DataSourceQueueDeadletter{p} },
+		func() datasource.DataSource { return DataSourceQueueStatus{p} },
+	}
+}
+
+// Functions - Gets the functions this provider provides
+func (p *AblyProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function { return FunctionChannelFilterMatches{} },
+	}
 }
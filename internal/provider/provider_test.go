@@ -2,9 +2,12 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/ably/terraform-provider-ably/control"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -22,3 +25,29 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("ABLY_ACCOUNT_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccClient returns a Control API client configured like the provider
+// under test, from ABLY_ACCOUNT_TOKEN and ABLY_URL, and the account ID it
+// belongs to. Destroy checks use it to look behind Terraform's back.
+func testAccClient(ctx context.Context) (*control.Client, string, error) {
+	token := os.Getenv("ABLY_ACCOUNT_TOKEN")
+	if token == "" {
+		return nil, "", fmt.Errorf("ABLY_ACCOUNT_TOKEN not set")
+	}
+
+	client := control.NewClient(token)
+	url := os.Getenv("ABLY_URL")
+	if url != "" {
+		client.BaseURL = url
+	}
+
+	// Get account ID via /me
+	me, err := client.Me(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to call /me: %w", err)
+	}
+	if me.Account == nil || me.Account.ID == "" {
+		return nil, "", fmt.Errorf("could not determine account ID from /me")
+	}
+	return client, me.Account.ID, nil
+}
@@ -3,11 +3,15 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/ably/terraform-provider-ably/control"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,6 +23,7 @@ import (
 
 var _ resource.Resource = &ResourceApp{}
 var _ resource.ResourceWithImportState = &ResourceApp{}
+var _ resource.ResourceWithIdentity = &ResourceApp{}
 
 type ResourceApp struct {
 	p *AblyProvider
@@ -45,8 +50,13 @@ type AblyAppState struct {
 	ApnsTopicHeader             types.String `tfsdk:"apns_topic_header"`
 	ApnsCertificateConfigured   types.Bool   `tfsdk:"apns_certificate_configured"`
 	ApnsSigningKeyConfigured    types.Bool   `tfsdk:"apns_signing_key_configured"`
+	ApnsP12Base64               types.String `tfsdk:"apns_p12_base64"`
+	ApnsP12Password             types.String `tfsdk:"apns_p12_password"`
+	ApnsP12Sha256               types.String `tfsdk:"apns_p12_sha256"`
 	Created                     types.String `tfsdk:"created"`
 	Modified                    types.String `tfsdk:"modified"`
+	DeletionProtection          types.Bool   `tfsdk:"deletion_protection"`
+	DestroyBehavior             types.String `tfsdk:"destroy_behavior"`
 }
 
 // formatTimestamp converts a Unix timestamp in milliseconds to an RFC3339 string.
@@ -164,6 +174,31 @@ func (r ResourceApp) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Computed:    true,
 				Description: "Whether an APNS signing key has been configured.",
 			},
+			"apns_p12_base64": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "A base64-encoded PKCS#12 bundle holding the APNs certificate and private key, e.g. from filebase64(). It is uploaded when its content changes and never stored in state.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("apns_certificate"), path.MatchRoot("apns_private_key")),
+				},
+			},
+			"apns_p12_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The password protecting apns_p12_base64. Changing only the password does not upload the bundle again.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("apns_p12_base64")),
+				},
+			},
+			"apns_p12_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SHA-256 of the last PKCS#12 bundle uploaded from apns_p12_base64, in hex.",
+				PlanModifiers: []planmodifier.String{
+					Base64DigestAttribute(path.Root("apns_p12_base64")),
+				},
+			},
 			"fcm_service_account_configured": schema.BoolAttribute{
 				Computed:    true,
 				Description: "Whether a Firebase Cloud Messaging service account has been configured.",
@@ -179,6 +214,8 @@ func (r ResourceApp) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Computed:    true,
 				Description: "The timestamp when the app was last modified.",
 			},
+			"deletion_protection": deletionProtectionAttribute("app"),
+			"destroy_behavior":    destroyBehaviorAttribute("app"),
 		},
 		MarkdownDescription: "The `ably_app` resource allows you to create and manage Ably Apps " +
 			"and configure Ably Push notifications. Read more about Ably Push Notifications in Ably documentation: https://ably.com/docs/general/push",
@@ -203,6 +240,19 @@ func (r ResourceApp) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	// Write-only values are only in the config. The bundle's digest is worked
+	// out before the app is created, so a bundle that is not valid base64
+	// fails the apply without leaving an app behind.
+	var config AblyAppState
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apnsP12Sha256 := appliedApnsP12Sha256(plan, config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generates an API request body from the plan values
 	appValues := control.AppPost{
 		Name:                   plan.Name.ValueString(),
@@ -231,6 +281,23 @@ func (r ResourceApp) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	// The PKCS#12 bundle has its own endpoint, so it is uploaded once the app
+	// exists. The app is not in state yet, so if the upload fails it is
+	// deleted again rather than left for the next apply to duplicate.
+	if !config.ApnsP12Base64.IsNull() {
+		r.uploadApnsP12(ctx, ablyApp.ID, config, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// Roll back even if ctx was cancelled, which may be why we failed.
+			if err := r.p.client.DeleteApp(context.WithoutCancel(ctx), ablyApp.ID); err != nil {
+				resp.Diagnostics.AddError(
+					"Error rolling back ably_app",
+					fmt.Sprintf("Could not delete app %s after its PKCS#12 upload failed, so delete it by hand: %s", ablyApp.ID, err),
+				)
+			}
+			return
+		}
+	}
+
 	// Read back the resource via GET to ensure computed fields like `modified`
 	// reflect the settled server state (the POST response may return a value
 	// that the server updates asynchronously).
@@ -279,8 +346,13 @@ func (r ResourceApp) Create(ctx context.Context, req resource.CreateRequest, res
 		ApnsTopicHeader:             optStringValue(ablyApp.APNSTopicHeader),
 		ApnsCertificateConfigured:   types.BoolValue(deref(ablyApp.APNSCertificateConfigured)),
 		ApnsSigningKeyConfigured:    types.BoolValue(deref(ablyApp.APNSSigningKeyConfigured)),
+		ApnsP12Base64:               types.StringNull(),
+		ApnsP12Password:             types.StringNull(),
+		ApnsP12Sha256:               apnsP12Sha256,
 		Created:                     types.StringValue(formatTimestamp(ablyApp.Created)),
 		Modified:                    types.StringValue(formatTimestamp(ablyApp.Modified)),
+		DeletionProtection:          plan.DeletionProtection,
+		DestroyBehavior:             plan.DestroyBehavior,
 	}
 	emptyStringToNull(&respApps.FcmKey)
 	emptyStringToNull(&respApps.ApnsCertificate)
@@ -290,6 +362,7 @@ func (r ResourceApp) Create(ctx context.Context, req resource.CreateRequest, res
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, respApps)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, respApps.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -348,8 +421,13 @@ func (r ResourceApp) Read(ctx context.Context, req resource.ReadRequest, resp *r
 				ApnsTopicHeader:             optStringValue(v.APNSTopicHeader),
 				ApnsCertificateConfigured:   types.BoolValue(deref(v.APNSCertificateConfigured)),
 				ApnsSigningKeyConfigured:    types.BoolValue(deref(v.APNSSigningKeyConfigured)),
+				ApnsP12Base64:               types.StringNull(),
+				ApnsP12Password:             types.StringNull(),
+				ApnsP12Sha256:               state.ApnsP12Sha256,
 				Created:                     types.StringValue(formatTimestamp(v.Created)),
 				Modified:                    types.StringValue(formatTimestamp(v.Modified)),
+				DeletionProtection:          deletionProtectionValue(state.DeletionProtection),
+				DestroyBehavior:             destroyBehaviorValue(state.DestroyBehavior),
 			}
 			emptyStringToNull(&respApps.FcmKey)
 			emptyStringToNull(&respApps.ApnsCertificate)
@@ -361,6 +439,7 @@ func (r ResourceApp) Read(ctx context.Context, req resource.ReadRequest, resp *r
 			diags = resp.State.Set(ctx, &respApps)
 
 			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, respApps.ID.ValueString())...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -394,6 +473,17 @@ func (r ResourceApp) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
+	// Write-only values are only in the config.
+	var config AblyAppState
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apnsP12Sha256 := appliedApnsP12Sha256(plan, config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Gets the app ID
 	appID := plan.ID.ValueString()
 	if plan.ID.IsUnknown() {
@@ -428,6 +518,15 @@ func (r ResourceApp) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
+	// Uploads the PKCS#12 bundle only when its digest differs from the one in
+	// state, so an unchanged bundle is not sent on every update.
+	if !apnsP12Sha256.Equal(state.ApnsP12Sha256) && !config.ApnsP12Base64.IsNull() {
+		r.uploadApnsP12(ctx, appID, config, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Read back via GET to get settled computed fields.
 	apps, err := r.p.client.ListApps(ctx, r.p.accountID)
 	if err != nil {
@@ -473,8 +572,13 @@ func (r ResourceApp) Update(ctx context.Context, req resource.UpdateRequest, res
 		ApnsTopicHeader:             optStringValue(ablyApp.APNSTopicHeader),
 		ApnsCertificateConfigured:   types.BoolValue(deref(ablyApp.APNSCertificateConfigured)),
 		ApnsSigningKeyConfigured:    types.BoolValue(deref(ablyApp.APNSSigningKeyConfigured)),
+		ApnsP12Base64:               types.StringNull(),
+		ApnsP12Password:             types.StringNull(),
+		ApnsP12Sha256:               apnsP12Sha256,
 		Created:                     types.StringValue(formatTimestamp(ablyApp.Created)),
 		Modified:                    types.StringValue(formatTimestamp(ablyApp.Modified)),
+		DeletionProtection:          plan.DeletionProtection,
+		DestroyBehavior:             plan.DestroyBehavior,
 	}
 	emptyStringToNull(&respApps.FcmKey)
 	emptyStringToNull(&respApps.ApnsCertificate)
@@ -484,11 +588,64 @@ func (r ResourceApp) Update(ctx context.Context, req resource.UpdateRequest, res
 	// Sets state to new app.
 	diags = resp.State.Set(ctx, respApps)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, respApps.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// uploadApnsP12 uploads the configured PKCS#12 bundle as the app's APNs
+// certificate.
+func (r ResourceApp) uploadApnsP12(ctx context.Context, appID string, config AblyAppState, diags *diag.Diagnostics) {
+	bundle, err := base64.StdEncoding.DecodeString(config.ApnsP12Base64.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("apns_p12_base64"),
+			"Invalid apns_p12_base64",
+			"apns_p12_base64 is not valid base64: "+err.Error(),
+		)
+		return
+	}
+
+	_, err = r.p.client.UpdateAppPKCS12(ctx, appID, bundle, config.ApnsP12Password.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error uploading APNs PKCS#12 bundle",
+			"Could not upload the PKCS#12 bundle for ably_app, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// appliedApnsP12Sha256 returns the apns_p12_sha256 to store after an apply.
+// It is planned from apns_p12_base64, but is unknown when the bundle was, such
+// as when it is another resource's output, so it is then worked out from the
+// config, where the bundle is known by apply time.
+func appliedApnsP12Sha256(plan, config AblyAppState, diags *diag.Diagnostics) types.String {
+	if !plan.ApnsP12Sha256.IsUnknown() || config.ApnsP12Base64.IsNull() {
+		return plan.ApnsP12Sha256
+	}
+	digest, err := base64Digest(config.ApnsP12Base64.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("apns_p12_base64"),
+			"Invalid apns_p12_base64",
+			"apns_p12_base64 is not valid base64: "+err.Error(),
+		)
+		return plan.ApnsP12Sha256
+	}
+	return types.StringValue(digest)
+}
+
+// base64Digest returns the hex SHA-256 of the bytes a base64 string encodes.
+func base64Digest(encoded string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(decoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // Delete deletes the resource.
 func (r ResourceApp) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	if !r.p.ensureConfigured(&resp.Diagnostics) {
@@ -506,7 +663,19 @@ func (r ResourceApp) Delete(ctx context.Context, req resource.DeleteRequest, res
 	// Gets the current state. If it is unable to, the provider responds with an error.
 	appID := state.ID.ValueString()
 
-	err := r.p.client.DeleteApp(ctx, appID)
+	if !checkDeletionProtection("ably_app", appID, state.DeletionProtection, &resp.Diagnostics) ||
+		!r.p.checkAppProtected("ably_app", appID, state.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	// With destroy_behavior = "disable" the app, with its history and stats,
+	// is kept but disabled, and left for a cleanup job to delete.
+	var err error
+	if state.DestroyBehavior.ValueString() == destroyBehaviorDisable {
+		_, err = r.p.client.UpdateApp(ctx, appID, control.AppPatch{Status: "disabled"})
+	} else {
+		err = r.p.client.DeleteApp(ctx, appID)
+	}
 	if err != nil {
 		if is404(err) {
 			resp.Diagnostics.AddWarning(
@@ -526,8 +695,26 @@ func (r ResourceApp) Delete(ctx context.Context, req resource.DeleteRequest, res
 	resp.State.RemoveResource(ctx)
 }
 
-// ImportState handles the import state functionality.
+// IdentitySchema defines the identity of the resource.
+func (r ResourceApp) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppIdentitySchema()
+}
+
+// ImportState handles the import state functionality. The import identifier
+// is an app ID or, failing that, the name of exactly one app.
 func (r ResourceApp) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Save the import identifier in the id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	appID, err := resolveAppID(ctx, r.p, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot Resolve Import Identifier", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), appID)...)
 }
@@ -0,0 +1,615 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ resource.Resource = &ResourceAppBundle{}
+var _ resource.ResourceWithImportState = &ResourceAppBundle{}
+var _ resource.ResourceWithIdentity = &ResourceAppBundle{}
+
+type ResourceAppBundle struct {
+	p *AblyProvider
+}
+
+// The keys every bundle creates. The server key may do anything; the client
+// key may only subscribe, so it is safe to ship to browsers and devices.
+const (
+	appBundleServerKeyName = "Server"
+	appBundleClientKeyName = "Client (subscribe only)"
+)
+
+var (
+	appBundleServerCapability = map[string][]string{"*": {"*"}}
+	appBundleClientCapability = map[string][]string{"*": {"subscribe"}}
+)
+
+// Schema defines the schema for the resource.
+func (r ResourceAppBundle) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	computedString := func(description string, sensitive bool) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   sensitive,
+			Description: description,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	queueString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:    true,
+			Description: description,
+			PlanModifiers: []planmodifier.String{
+				UseStateUnlessChanged(path.Root("queue")),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": computedString("The application ID.", false),
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The application name.",
+			},
+			"namespaces": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The IDs of the namespaces to create in the app, e.g. `chat`, with default settings. Use `ably_namespace` for namespaces that need other settings.",
+			},
+			"queue": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "An Ably queue to create in the app. Queues cannot be modified, so changing any of these replaces the queue, but not the app.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "The name of the queue.",
+					},
+					"ttl": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Time to live in minutes. Defaults to 60.",
+						Default:     int64default.StaticInt64(60),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"max_length": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Message limit in number of messages. Defaults to 10000.",
+						Default:     int64default.StaticInt64(10000),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"region": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "The data center region, us-east-1-a or eu-west-1-a. Defaults to us-east-1-a.",
+						Default:     stringdefault.StaticString("us-east-1-a"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("us-east-1-a", "eu-west-1-a"),
+						},
+					},
+				},
+			},
+			"server_key_id": computedString("The ID of the server key, which may perform every operation on every channel.", false),
+			"server_key":    computedString("The complete server key, including its secret.", true),
+			"client_key_id": computedString("The ID of the client key, which may only subscribe.", false),
+			"client_key":    computedString("The complete client key, including its secret.", true),
+
+			"queue_id":                queueString("The ID of the queue."),
+			"queue_amqp_uri":          queueString("URI for the AMQP queue interface."),
+			"queue_amqp_queue_name":   queueString("Name of the queue for AMQP."),
+			"queue_stomp_uri":         queueString("URI for the STOMP queue interface."),
+			"queue_stomp_host":        queueString("The host for STOMP."),
+			"queue_stomp_destination": queueString("Destination of the queue for STOMP."),
+		},
+		MarkdownDescription: "The `ably_app_bundle` resource creates an Ably app with a standard setup: a server key, a subscribe-only client key, namespaces and optionally a queue. " +
+			"If any part fails to create, the whole app is deleted again, so a failed apply leaves nothing behind.",
+	}
+}
+
+func (r ResourceAppBundle) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "ably_app_bundle"
+}
+
+// Create creates a new resource.
+func (r ResourceAppBundle) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	// Gets plan values
+	var plan AblyAppBundle
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	namespaces, diags := appBundleNamespaces(ctx, plan.Namespaces)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, err := createAppBundle(ctx, r.p.client, r.p.accountID, plan, namespaces)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ably_app_bundle",
+			"Could not create ably_app_bundle: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, state.ID.ValueString())...)
+}
+
+// createAppBundle creates the app and everything in it. Everything lives in
+// the app, so if any step fails, deleting the app rolls back all of it.
+func createAppBundle(ctx context.Context, client *control.Client, accountID string, plan AblyAppBundle, namespaces []string) (AblyAppBundle, error) {
+	app, err := client.CreateApp(ctx, accountID, control.AppPost{Name: plan.Name.ValueString(), Status: "enabled"})
+	if err != nil {
+		return AblyAppBundle{}, fmt.Errorf("could not create app: %w", err)
+	}
+
+	state, err := populateAppBundle(ctx, client, app, plan, namespaces)
+	if err != nil {
+		// Roll back even if ctx was cancelled, which may be why we failed.
+		if rollbackErr := client.DeleteApp(context.WithoutCancel(ctx), app.ID); rollbackErr != nil {
+			return AblyAppBundle{}, fmt.Errorf("%w; rolling back also failed, so delete app %s by hand: %w", err, app.ID, rollbackErr)
+		}
+		return AblyAppBundle{}, fmt.Errorf("%w; the app was deleted again", err)
+	}
+	return state, nil
+}
+
+// populateAppBundle creates a new bundle app's keys, namespaces and queue.
+func populateAppBundle(ctx context.Context, client *control.Client, app control.AppResponse, plan AblyAppBundle, namespaces []string) (AblyAppBundle, error) {
+	state := plan
+	state.ID = types.StringValue(app.ID)
+	state.Name = types.StringValue(app.Name)
+
+	serverKey, err := client.CreateKey(ctx, app.ID, control.KeyPost{Name: appBundleServerKeyName, Capability: appBundleServerCapability})
+	if err != nil {
+		return state, fmt.Errorf("could not create server key: %w", err)
+	}
+	state.ServerKeyID = types.StringValue(serverKey.ID)
+	state.ServerKey = types.StringValue(serverKey.Key)
+
+	clientKey, err := client.CreateKey(ctx, app.ID, control.KeyPost{Name: appBundleClientKeyName, Capability: appBundleClientCapability})
+	if err != nil {
+		return state, fmt.Errorf("could not create client key: %w", err)
+	}
+	state.ClientKeyID = types.StringValue(clientKey.ID)
+	state.ClientKey = types.StringValue(clientKey.Key)
+
+	for _, namespace := range namespaces {
+		if _, err := client.CreateNamespace(ctx, app.ID, control.NamespacePost{ID: namespace}); err != nil {
+			return state, fmt.Errorf("could not create namespace %s: %w", namespace, err)
+		}
+	}
+
+	var queue *control.QueueResponse
+	if plan.Queue != nil {
+		created, err := client.CreateQueue(ctx, app.ID, appBundleQueueValues(plan.Queue))
+		if err != nil {
+			return state, fmt.Errorf("could not create queue %s: %w", plan.Queue.Name.ValueString(), err)
+		}
+		queue = &created
+	}
+	setAppBundleQueue(&state, queue)
+	return state, nil
+}
+
+// Read reads the resource.
+func (r ResourceAppBundle) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	// Gets the current state. If it is unable to, the provider responds with an error.
+	var state AblyAppBundle
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	appID := state.ID.ValueString()
+
+	apps, err := r.p.client.ListApps(ctx, r.p.accountID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ably_app_bundle",
+			"Could not read ably_app_bundle, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	i := slices.IndexFunc(apps, func(a control.AppResponse) bool { return a.ID == appID })
+	if i < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Name = types.StringValue(apps[i].Name)
+
+	// The keys cannot be recreated without changing their secrets, so a key
+	// revoked behind Terraform's back is reported rather than repaired.
+	keys, err := r.p.client.ListKeys(ctx, appID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ably_app_bundle",
+			"Could not read ably_app_bundle keys, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	for _, id := range []types.String{state.ServerKeyID, state.ClientKeyID} {
+		if !slices.ContainsFunc(keys, func(k control.KeyResponse) bool { return k.ID == id.ValueString() && k.Status == 0 }) {
+			resp.Diagnostics.AddWarning(
+				"ably_app_bundle key revoked",
+				fmt.Sprintf("Key %s of app %s has been revoked. Replace the bundle, or manage the app's keys with ably_api_key.", id.ValueString(), appID),
+			)
+		}
+	}
+
+	// Only the bundle's own namespaces are tracked; one deleted elsewhere is
+	// created again on the next apply.
+	if !state.Namespaces.IsNull() {
+		existing, err := r.p.client.ListNamespaces(ctx, appID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading ably_app_bundle",
+				"Could not read ably_app_bundle namespaces, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		namespaces, diags := appBundleNamespaces(ctx, state.Namespaces)
+		resp.Diagnostics.Append(diags...)
+		namespaces = slices.DeleteFunc(namespaces, func(id string) bool {
+			return !slices.ContainsFunc(existing, func(n control.NamespaceResponse) bool { return n.ID == id })
+		})
+		state.Namespaces, diags = types.SetValueFrom(ctx, types.StringType, namespaces)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// A queue deleted elsewhere is dropped from state, so it is created again
+	// on the next apply.
+	if !state.QueueID.IsNull() {
+		queues, err := r.p.client.ListQueues(ctx, appID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading ably_app_bundle",
+				"Could not read ably_app_bundle queue, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		var queue *control.QueueResponse
+		if i := slices.IndexFunc(queues, func(q control.QueueResponse) bool { return q.ID == state.QueueID.ValueString() }); i >= 0 {
+			queue = &queues[i]
+		}
+		setAppBundleQueue(&state, queue)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, appID)...)
+}
+
+// Update updates an existing resource. It renames the app, creates and
+// deletes namespaces, and replaces the queue as needed. If a step fails, the
+// steps before it are kept in state.
+func (r ResourceAppBundle) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state AblyAppBundle
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned, diags := appBundleNamespaces(ctx, plan.Namespaces)
+	resp.Diagnostics.Append(diags...)
+	current, diags := appBundleNamespaces(ctx, state.Namespaces)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.update(ctx, plan, &state, planned, current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ably_app_bundle",
+			"Could not update ably_app_bundle: "+err.Error(),
+		)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, state.ID.ValueString())...)
+}
+
+// update brings the bundle in state in line with plan, recording each step
+// in state as it succeeds.
+func (r ResourceAppBundle) update(ctx context.Context, plan AblyAppBundle, state *AblyAppBundle, planned, current []string) error {
+	appID := state.ID.ValueString()
+
+	if !plan.Name.Equal(state.Name) {
+		app, err := r.p.client.UpdateApp(ctx, appID, control.AppPatch{Name: plan.Name.ValueString()})
+		if err != nil {
+			return fmt.Errorf("could not rename app: %w", err)
+		}
+		state.Name = types.StringValue(app.Name)
+	}
+
+	namespacesDone := func() {
+		if plan.Namespaces.IsNull() && len(current) == 0 {
+			state.Namespaces = types.SetNull(types.StringType)
+			return
+		}
+		state.Namespaces, _ = types.SetValueFrom(ctx, types.StringType, append([]string{}, current...))
+	}
+	for _, namespace := range slices.Clone(current) {
+		if slices.Contains(planned, namespace) {
+			continue
+		}
+		if err := r.p.client.DeleteNamespace(ctx, appID, namespace); err != nil && !is404(err) {
+			namespacesDone()
+			return fmt.Errorf("could not delete namespace %s: %w", namespace, err)
+		}
+		current = slices.DeleteFunc(current, func(id string) bool { return id == namespace })
+	}
+	for _, namespace := range planned {
+		if slices.Contains(current, namespace) {
+			continue
+		}
+		if _, err := r.p.client.CreateNamespace(ctx, appID, control.NamespacePost{ID: namespace}); err != nil {
+			namespacesDone()
+			return fmt.Errorf("could not create namespace %s: %w", namespace, err)
+		}
+		current = append(current, namespace)
+	}
+	namespacesDone()
+
+	if appBundleQueueChanged(plan.Queue, state.Queue) || (plan.Queue != nil && state.QueueID.IsNull()) {
+		if !state.QueueID.IsNull() {
+			if err := r.p.client.DeleteQueue(ctx, appID, state.QueueID.ValueString()); err != nil && !is404(err) {
+				return fmt.Errorf("could not delete queue %s: %w", state.QueueID.ValueString(), err)
+			}
+			setAppBundleQueue(state, nil)
+		}
+		if plan.Queue != nil {
+			queue, err := r.p.client.CreateQueue(ctx, appID, appBundleQueueValues(plan.Queue))
+			if err != nil {
+				return fmt.Errorf("could not create queue %s: %w", plan.Queue.Name.ValueString(), err)
+			}
+			setAppBundleQueue(state, &queue)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the resource. Deleting the app deletes its keys, namespaces
+// and queue with it.
+func (r ResourceAppBundle) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	var state AblyAppBundle
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.p.checkAppProtected("ably_app_bundle", state.ID.ValueString(), state.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	err := r.p.client.DeleteApp(ctx, state.ID.ValueString())
+	if err != nil {
+		if is404(err) {
+			resp.Diagnostics.AddWarning(
+				"Resource does not exist",
+				"Resource does not exist, it may have already been deleted: "+err.Error(),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error deleting ably_app_bundle",
+				"Could not delete ably_app_bundle, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// IdentitySchema defines the identity of the resource, which is that of the
+// app.
+func (r ResourceAppBundle) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppIdentitySchema()
+}
+
+// ImportState handles the import state functionality. The import identifier
+// is an app ID or, failing that, the name of exactly one app. Only an app
+// with the keys a bundle creates can be imported. Every namespace in the app
+// is taken to be the bundle's, as is its queue if it has just one.
+func (r ResourceAppBundle) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !r.p.ensureConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	var appID string
+	if req.ID == "" && req.Identity != nil {
+		var identity AblyAppIdentity
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		appID = identity.ID.ValueString()
+	} else {
+		var err error
+		if appID, err = resolveAppID(ctx, r.p, req.ID); err != nil {
+			resp.Diagnostics.AddError("Cannot Resolve Import Identifier", err.Error())
+			return
+		}
+	}
+
+	state, diags := importAppBundle(ctx, r.p.client, appID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(setAppIdentity(ctx, resp.Identity, appID)...)
+}
+
+// importAppBundle reads the parts of an existing app that a bundle tracks.
+// Read fills in the app's name.
+func importAppBundle(ctx context.Context, client *control.Client, appID string) (AblyAppBundle, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	state := AblyAppBundle{ID: types.StringValue(appID)}
+	readFailed := func(what string, err error) (AblyAppBundle, diag.Diagnostics) {
+		diags.AddError(
+			"Error importing ably_app_bundle",
+			fmt.Sprintf("Could not read the %s of app %s, unexpected error: %s", what, appID, err),
+		)
+		return state, diags
+	}
+
+	keys, err := client.ListKeys(ctx, appID)
+	if err != nil {
+		return readFailed("keys", err)
+	}
+	keys = slices.DeleteFunc(keys, func(k control.KeyResponse) bool { return k.Status != 0 })
+	for _, bundleKey := range []struct {
+		name   string
+		id     *types.String
+		secret *types.String
+	}{
+		{appBundleServerKeyName, &state.ServerKeyID, &state.ServerKey},
+		{appBundleClientKeyName, &state.ClientKeyID, &state.ClientKey},
+	} {
+		i := slices.IndexFunc(keys, func(k control.KeyResponse) bool { return k.Name == bundleKey.name })
+		if i < 0 || slices.ContainsFunc(keys[i+1:], func(k control.KeyResponse) bool { return k.Name == bundleKey.name }) {
+			diags.AddError(
+				"Cannot import ably_app_bundle",
+				fmt.Sprintf("App %s does not have exactly one active key named %q, as every ably_app_bundle does. Import it as ably_app instead.", appID, bundleKey.name),
+			)
+			return state, diags
+		}
+		*bundleKey.id = types.StringValue(keys[i].ID)
+		*bundleKey.secret = types.StringValue(keys[i].Key)
+	}
+
+	existing, err := client.ListNamespaces(ctx, appID)
+	if err != nil {
+		return readFailed("namespaces", err)
+	}
+	namespaces := []string{}
+	for _, n := range existing {
+		namespaces = append(namespaces, n.ID)
+	}
+	state.Namespaces = types.SetNull(types.StringType)
+	if len(namespaces) > 0 {
+		var setDiags diag.Diagnostics
+		state.Namespaces, setDiags = types.SetValueFrom(ctx, types.StringType, namespaces)
+		diags.Append(setDiags...)
+	}
+
+	queues, err := client.ListQueues(ctx, appID)
+	if err != nil {
+		return readFailed("queues", err)
+	}
+	queues = slices.DeleteFunc(queues, func(q control.QueueResponse) bool { return q.Deadletter })
+	var queue *control.QueueResponse
+	switch len(queues) {
+	case 0:
+	case 1:
+		queue = &queues[0]
+	default:
+		diags.AddWarning(
+			"ably_app_bundle queue not imported",
+			fmt.Sprintf("App %s has %d queues, and a bundle has at most one, so none was imported. Manage them with ably_queue.", appID, len(queues)),
+		)
+	}
+	setAppBundleQueue(&state, queue)
+	return state, diags
+}
+
+// appBundleNamespaces returns the IDs in a bundle's namespaces, none when it
+// is null.
+func appBundleNamespaces(ctx context.Context, namespaces types.Set) ([]string, diag.Diagnostics) {
+	ids := []string{}
+	diags := namespaces.ElementsAs(ctx, &ids, true)
+	return ids, diags
+}
+
+// appBundleQueueValues generates an API request body for a bundle's queue.
+func appBundleQueueValues(queue *AblyAppBundleQueue) control.Queue {
+	return control.Queue{
+		Name:      queue.Name.ValueString(),
+		TTL:       int(queue.Ttl.ValueInt64()),
+		MaxLength: int(queue.MaxLength.ValueInt64()),
+		Region:    queue.Region.ValueString(),
+	}
+}
+
+// appBundleQueueChanged reports whether a bundle's queue must be replaced.
+func appBundleQueueChanged(planned, current *AblyAppBundleQueue) bool {
+	if planned == nil || current == nil {
+		return planned != current
+	}
+	return !planned.Name.Equal(current.Name) ||
+		!planned.Ttl.Equal(current.Ttl) ||
+		!planned.MaxLength.Equal(current.MaxLength) ||
+		!planned.Region.Equal(current.Region)
+}
+
+// setAppBundleQueue maps a bundle's queue, or its absence, to state.
+func setAppBundleQueue(state *AblyAppBundle, queue *control.QueueResponse) {
+	if queue == nil {
+		state.Queue = nil
+		state.QueueID = types.StringNull()
+		state.QueueAmqpURI = types.StringNull()
+		state.QueueAmqpQueueName = types.StringNull()
+		state.QueueStompURI = types.StringNull()
+		state.QueueStompHost = types.StringNull()
+		state.QueueStompDestination = types.StringNull()
+		return
+	}
+	state.Queue = &AblyAppBundleQueue{
+		Name:      types.StringValue(queue.Name),
+		Ttl:       types.Int64Value(int64(queue.TTL)),
+		MaxLength: types.Int64Value(int64(queue.MaxLength)),
+		Region:    types.StringValue(queue.Region),
+	}
+	state.QueueID = types.StringValue(queue.ID)
+	state.QueueAmqpURI = types.StringValue(queue.AMQP.URI)
+	state.QueueAmqpQueueName = types.StringValue(queue.AMQP.QueueName)
+	state.QueueStompURI = types.StringValue(queue.Stomp.URI)
+	state.QueueStompHost = types.StringValue(queue.Stomp.Host)
+	state.QueueStompDestination = types.StringValue(queue.Stomp.Destination)
+}
@@ -0,0 +1,90 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAblyAppBundle(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	queueName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyAppBundleConfig(appName, `["chat", "events"]`, fmt.Sprintf(`{ name = %q }`, queueName)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "name", appName),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "id"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "server_key_id"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "server_key"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "client_key_id"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "client_key"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "namespaces.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ably_app_bundle.bundle0", "namespaces.*", "chat"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "queue.name", queueName),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "queue.ttl", "60"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "queue.max_length", "10000"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "queue.region", "us-east-1-a"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "queue_id"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "queue_amqp_uri"),
+					resource.TestCheckResourceAttrSet("ably_app_bundle.bundle0", "queue_stomp_uri"),
+				),
+			},
+			// Renaming the app, changing namespaces and replacing the queue
+			// all update the bundle in place.
+			{
+				Config: testAccAblyAppBundleConfig(appName+"new", `["chat", "alerts"]`, fmt.Sprintf(`{ name = %q, ttl = 30 }`, queueName)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ably_app_bundle.bundle0", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ably_app_bundle.bundle0", tfjsonpath.New("queue_id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "name", appName+"new"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "namespaces.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ably_app_bundle.bundle0", "namespaces.*", "alerts"),
+					resource.TestCheckResourceAttr("ably_app_bundle.bundle0", "queue.ttl", "30"),
+				),
+			},
+			// Dropping the queue deletes it and nulls its outputs.
+			{
+				Config: testAccAblyAppBundleConfig(appName+"new", `["chat", "alerts"]`, "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ably_app_bundle.bundle0", "queue_id"),
+					resource.TestCheckNoResourceAttr("ably_app_bundle.bundle0", "queue.name"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Function with inline HCL to provision an ably_app_bundle resource
+// Takes the app name and HCL for the namespaces and queue as function params.
+func testAccAblyAppBundleConfig(appName, namespaces, queue string) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app_bundle" "bundle0" {
+	name       = %[1]q
+	namespaces = %[2]s
+	queue      = %[3]s
+}
+`, appName, namespaces, queue)
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCreateAppBundle(t *testing.T) {
+	t.Parallel()
+
+	plan := AblyAppBundle{
+		Name: types.StringValue("Orders"),
+		Queue: &AblyAppBundleQueue{
+			Name:      types.StringValue("orders"),
+			Ttl:       types.Int64Value(60),
+			MaxLength: types.Int64Value(10000),
+			Region:    types.StringValue("us-east-1-a"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		fail      []string // "METHOD path-suffix" of requests to fail
+		errSubstr string
+	}{
+		{name: "success"},
+		{name: "namespace fails", fail: []string{"POST /namespaces"}, errSubstr: "could not create namespace chat"},
+		{name: "queue fails", fail: []string{"POST /queues"}, errSubstr: "could not create queue orders"},
+		{name: "rollback fails", fail: []string{"POST /queues", "DELETE /apps/"}, errSubstr: "delete app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := controltest.NewServer()
+			t.Cleanup(fake.Close)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, fail := range tt.fail {
+					method, suffix, _ := strings.Cut(fail, " ")
+					if r.Method == method && strings.Contains(r.URL.Path, suffix) {
+						http.Error(w, `{"message":"injected failure","statusCode":500}`, http.StatusInternalServerError)
+						return
+					}
+				}
+				fake.ServeHTTP(w, r)
+			}))
+			t.Cleanup(server.Close)
+			client := control.NewClient("fake-token", control.WithRetryMax(0))
+			client.BaseURL = server.URL
+
+			state, err := createAppBundle(context.Background(), client, controltest.AccountID, plan, []string{"chat", "events"})
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.errSubstr)
+				}
+				rolledBack := !slices.Contains(tt.fail, "DELETE /apps/")
+				fake.Inspect(func(state *controltest.State) {
+					if rolledBack != (len(state.Apps) == 0) {
+						t.Errorf("rolled back = %t, but the fake has %d apps", rolledBack, len(state.Apps))
+					}
+				})
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			appID := state.ID.ValueString()
+			var stored controltest.State
+			fake.Inspect(func(state *controltest.State) { stored = *state })
+			// The queue comes with the app's dead letter queue.
+			if len(stored.Namespaces[appID]) != 2 || len(stored.Queues[appID]) != 2 {
+				t.Errorf("created %d namespaces and %d queues, want 2 and 2", len(stored.Namespaces[appID]), len(stored.Queues[appID]))
+			}
+			for id, want := range map[string]map[string][]string{
+				state.ServerKeyID.ValueString(): appBundleServerCapability,
+				state.ClientKeyID.ValueString(): appBundleClientCapability,
+			} {
+				key := stored.Keys[appID][id]
+				if key == nil {
+					t.Fatalf("key %s was not created", id)
+				}
+				got := map[string][]string{}
+				for resource, ops := range key["capability"].(map[string]any) {
+					for _, op := range ops.([]any) {
+						got[resource] = append(got[resource], op.(string))
+					}
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("key %s capability = %v, want %v", id, got, want)
+				}
+			}
+			if state.ServerKey.ValueString() == "" || state.QueueAmqpURI.ValueString() == "" {
+				t.Errorf("state is missing the server key or queue URI: %+v", state)
+			}
+		})
+	}
+}
+
+func TestImportAppBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := controltest.NewServer()
+	t.Cleanup(fake.Close)
+	client := control.NewClient("fake-token", control.WithRetryMax(0))
+	client.BaseURL = fake.URL
+	p := &AblyProvider{configured: true, client: client, accountID: controltest.AccountID}
+	r := ResourceAppBundle{p: p}
+
+	plan := AblyAppBundle{
+		Name: types.StringValue("Orders"),
+		Queue: &AblyAppBundleQueue{
+			Name:      types.StringValue("orders"),
+			Ttl:       types.Int64Value(60),
+			MaxLength: types.Int64Value(10000),
+			Region:    types.StringValue("us-east-1-a"),
+		},
+	}
+	created, err := createAppBundle(ctx, client, controltest.AccountID, plan, []string{"chat", "events"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created.Namespaces, _ = types.SetValueFrom(ctx, types.StringType, []string{"chat", "events"})
+	fake.SeedApp(controltest.Record{"id": "plain", "name": "Plain"})
+	fake.SeedKey("plain", controltest.Record{"id": "key1", "name": "root"})
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	emptyState := func() tfsdk.State {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	}
+	emptyIdentity := func() *tfsdk.ResourceIdentity {
+		return &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil)}
+	}
+	byIdentity := emptyIdentity()
+	if diags := byIdentity.Set(ctx, AblyAppIdentity{ID: created.ID}); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	tests := []struct {
+		name      string
+		req       resource.ImportStateRequest
+		errSubstr string
+	}{
+		{name: "by ID", req: resource.ImportStateRequest{ID: created.ID.ValueString()}},
+		{name: "by name", req: resource.ImportStateRequest{ID: "Orders"}},
+		{name: "by identity", req: resource.ImportStateRequest{Identity: byIdentity}},
+		{name: "not a bundle", req: resource.ImportStateRequest{ID: "plain"}, errSubstr: `exactly one active key named "Server"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importResp := &resource.ImportStateResponse{State: emptyState(), Identity: emptyIdentity()}
+			r.ImportState(ctx, tt.req, importResp)
+			if tt.errSubstr != "" {
+				if !importResp.Diagnostics.HasError() || !strings.Contains(importResp.Diagnostics.Errors()[0].Detail(), tt.errSubstr) {
+					t.Fatalf("diagnostics = %v, want an error containing %q", importResp.Diagnostics, tt.errSubstr)
+				}
+				return
+			}
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", importResp.Diagnostics)
+			}
+
+			// Terraform reads the resource straight after importing it.
+			readResp := &resource.ReadResponse{State: importResp.State, Identity: importResp.Identity}
+			r.Read(ctx, resource.ReadRequest{State: importResp.State, Identity: importResp.Identity}, readResp)
+			if readResp.Diagnostics.HasError() || len(readResp.Diagnostics.Warnings()) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+			}
+			var imported AblyAppBundle
+			readResp.State.Get(ctx, &imported)
+			if !imported.Namespaces.Equal(created.Namespaces) {
+				t.Errorf("imported namespaces = %s, want %s", imported.Namespaces, created.Namespaces)
+			}
+			imported.Namespaces = created.Namespaces // in no particular order
+			if !reflect.DeepEqual(imported, created) {
+				t.Errorf("imported = %+v\nwant %+v", imported, created)
+			}
+			var identity AblyAppIdentity
+			readResp.Identity.Get(ctx, &identity)
+			if !identity.ID.Equal(created.ID) {
+				t.Errorf("identity = %s, want %s", identity.ID, created.ID)
+			}
+		})
+	}
+}
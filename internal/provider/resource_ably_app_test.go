@@ -2,15 +2,27 @@
 package provider
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/ably/terraform-provider-ably/control"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 var cert string = `-----BEGIN CERTIFICATE-----
@@ -105,6 +117,19 @@ func TestAccAblyApp(t *testing.T) {
 					"apns_private_key",
 				},
 			},
+			// ImportState testing of ably_app.app0 by name
+			{
+				ResourceName:      "ably_app.app0",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     appName,
+				ImportStateVerifyIgnore: []string{
+					"fcm_key",
+					"fcm_service_account",
+					"apns_certificate",
+					"apns_private_key",
+				},
+			},
 			// Update and Read testing of ably_app.app0
 			{
 				Config: testAccAblyAppConfig(&control.AppResponse{
@@ -230,3 +255,174 @@ resource "ably_app" "app0" {
 		},
 	})
 }
+
+// TestAccAblyApp_APNsP12 uploads a PKCS#12 bundle through the write-only
+// attributes. An unchanged bundle plans nothing, a changed one plans an update
+// that uploads it again, and dropping it from config keeps the last digest.
+func TestAccAblyApp_APNsP12(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	first := base64.StdEncoding.EncodeToString(testAccSelfSignedPKCS12(t, "first-password"))
+	second := base64.StdEncoding.EncodeToString(testAccSelfSignedPKCS12(t, "second-password"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes need Terraform 1.11.
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyAppP12Config(appName, first, "first-password"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_app.app0", "apns_p12_sha256", testAccSha256(t, first)),
+					resource.TestCheckResourceAttr("ably_app.app0", "apns_certificate_configured", "true"),
+					resource.TestCheckNoResourceAttr("ably_app.app0", "apns_p12_base64"),
+					resource.TestCheckNoResourceAttr("ably_app.app0", "apns_p12_password"),
+				),
+			},
+			{
+				Config: testAccAblyAppP12Config(appName, first, "first-password"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testAccAblyAppP12Config(appName, second, "second-password"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ably_app.app0", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ably_app.app0", "apns_p12_sha256", testAccSha256(t, second)),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name = %q
+}
+`, appName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ably_app.app0", "apns_p12_sha256", testAccSha256(t, second)),
+			},
+		},
+	})
+}
+
+// TestAccAblyApp_APNsP12Unknown takes the bundle from another resource's
+// output, which is unknown until apply, so apns_p12_sha256 is planned unknown
+// and worked out when the bundle is uploaded.
+func TestAccAblyApp_APNsP12Unknown(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	first := base64.StdEncoding.EncodeToString(testAccSelfSignedPKCS12(t, "first-password"))
+	second := base64.StdEncoding.EncodeToString(testAccSelfSignedPKCS12(t, "second-password"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Write-only attributes need Terraform 1.11.
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyAppP12OutputConfig(appName, first, "first-password"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("ably_app.app0", tfjsonpath.New("apns_p12_sha256")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ably_app.app0", "apns_p12_sha256", testAccSha256(t, first)),
+			},
+			{
+				Config: testAccAblyAppP12OutputConfig(appName, second, "second-password"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ably_app.app0", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ably_app.app0", tfjsonpath.New("apns_p12_sha256")),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("ably_app.app0", "apns_p12_sha256", testAccSha256(t, second)),
+			},
+		},
+	})
+}
+
+// testAccAblyAppP12OutputConfig passes the bundle through terraform_data, so
+// it is unknown at plan time whenever it changes.
+func testAccAblyAppP12OutputConfig(name, bundle, password string) string {
+	return fmt.Sprintf(`
+provider "ably" {}
+
+resource "terraform_data" "bundle" {
+	input = %[2]q
+}
+
+resource "ably_app" "app0" {
+	name              = %[1]q
+	apns_p12_base64   = terraform_data.bundle.output
+	apns_p12_password = %[3]q
+}
+`, name, bundle, password)
+}
+
+func testAccAblyAppP12Config(name, bundle, password string) string {
+	return fmt.Sprintf(`
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name              = %q
+	apns_p12_base64   = %q
+	apns_p12_password = %q
+}
+`, name, bundle, password)
+}
+
+func testAccSha256(t *testing.T, encoded string) string {
+	t.Helper()
+	digest, err := base64Digest(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return digest
+}
+
+// testAccSelfSignedPKCS12 builds a PKCS#12 bundle around a throwaway
+// self-signed certificate, which the Control API accepts as an upload.
+func testAccSelfSignedPKCS12(t *testing.T, password string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "acc-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := pkcs12.Modern.Encode(key, certificate, nil, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
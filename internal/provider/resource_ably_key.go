@@ -14,6 +14,7 @@ import (
 
 var _ resource.Resource = &ResourceKey{}
 var _ resource.ResourceWithImportState = &ResourceKey{}
+var _ resource.ResourceWithIdentity = &ResourceKey{}
 
 type ResourceKey struct {
 	p *AblyProvider
@@ -171,6 +172,7 @@ func (r ResourceKey) Create(ctx context.Context, req resource.CreateRequest, res
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, respKey)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respKey.AppID.ValueString(), respKey.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -238,6 +240,7 @@ func (r ResourceKey) Read(ctx context.Context, req resource.ReadRequest, resp *r
 			found = true
 
 			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respKey.AppID.ValueString(), respKey.ID.ValueString())...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -333,6 +336,7 @@ func (r ResourceKey) Update(ctx context.Context, req resource.UpdateRequest, res
 	// Sets state.
 	diags = resp.State.Set(ctx, respKey)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respKey.AppID.ValueString(), respKey.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -376,7 +380,12 @@ func (r ResourceKey) Delete(ctx context.Context, req resource.DeleteRequest, res
 	resp.State.RemoveResource(ctx)
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r ResourceKey) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppScopedIdentitySchema()
+}
+
 // ImportState handles the import state functionality.
 func (r ResourceKey) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ImportResource(ctx, req, resp, "app_id", "id")
+	ImportResource(ctx, r.p, req, resp, resolveKeyID)
 }
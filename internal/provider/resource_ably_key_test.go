@@ -7,7 +7,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // Test Create and Update of an Ably Key with:
@@ -57,6 +60,13 @@ func TestAccAblyKey(t *testing.T) {
 					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["app_id"], rs.Primary.ID), nil
 				},
 			},
+			// ImportState testing of ably_api_key.key0 by app and key name
+			{
+				ResourceName:      "ably_api_key.key0",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     appName + "/" + keyName,
+			},
 			// Update and Read testing of ably_app.app0
 			{
 				Config: testAccAblyKeyConfig(updateAppName, updateKeyName, "channel100", `["history"]`, false),
@@ -78,6 +88,35 @@ func TestAccAblyKey(t *testing.T) {
 	})
 }
 
+// TestAccAblyKey_Identity checks the key's resource identity and imports it
+// through an import block's identity rather than an ID string.
+func TestAccAblyKey_Identity(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	keyName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Import blocks take an identity from Terraform 1.12.
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyKeyConfig(appName, keyName, "channel100", `["publish"]`, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("ably_api_key.key0", tfjsonpath.New("app_id")),
+					statecheck.ExpectIdentityValueMatchesState("ably_api_key.key0", tfjsonpath.New("id")),
+				},
+			},
+			{
+				ResourceName:    "ably_api_key.key0",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 // Function with inline HCL to provision an ably_app resource
 // Takes App name, Key Name, Capability Name and Capability List as function params.
 func testAccAblyKeyConfig(appName string, keyName string, keyCapabilityName0 string, keyCapabilityCap0 string, revocableTokens bool) string {
@@ -22,6 +22,7 @@ type ResourceNamespace struct {
 
 var _ resource.Resource = &ResourceNamespace{}
 var _ resource.ResourceWithImportState = &ResourceNamespace{}
+var _ resource.ResourceWithIdentity = &ResourceNamespace{}
 
 // Schema defines the schema for the resource.
 func (r ResourceNamespace) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -166,6 +167,7 @@ func (r ResourceNamespace) Schema(_ context.Context, _ resource.SchemaRequest, r
 					DefaultStringAttribute(types.StringNull()),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("namespace"),
 		},
 		MarkdownDescription: "The Ably namespace resource allows you to manage namespaces for channel rules in Ably. Read more in the Ably documentation: https://ably.com/docs/general/channel-rules-namespaces.",
 	}
@@ -264,6 +266,7 @@ func (r ResourceNamespace) Create(ctx context.Context, req resource.CreateReques
 		PopulateChannelRegistry: types.BoolValue(ablyNamespace.PopulateChannelRegistry),
 		BatchingEnabled:         optBoolValue(ablyNamespace.BatchingEnabled),
 		ConflationEnabled:       optBoolValue(ablyNamespace.ConflationEnabled),
+		DeletionProtection:      plan.DeletionProtection,
 	}
 
 	if ablyNamespace.BatchingEnabled != nil && *ablyNamespace.BatchingEnabled {
@@ -278,6 +281,7 @@ func (r ResourceNamespace) Create(ctx context.Context, req resource.CreateReques
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, respApps)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respApps.AppID.ValueString(), respApps.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -339,6 +343,7 @@ func (r ResourceNamespace) Read(ctx context.Context, req resource.ReadRequest, r
 				PopulateChannelRegistry: types.BoolValue(v.PopulateChannelRegistry),
 				BatchingEnabled:         optBoolValue(v.BatchingEnabled),
 				ConflationEnabled:       optBoolValue(v.ConflationEnabled),
+				DeletionProtection:      deletionProtectionValue(state.DeletionProtection),
 			}
 
 			if v.BatchingEnabled != nil && *v.BatchingEnabled {
@@ -355,6 +360,7 @@ func (r ResourceNamespace) Read(ctx context.Context, req resource.ReadRequest, r
 			found = true
 
 			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respNamespaces.AppID.ValueString(), respNamespaces.ID.ValueString())...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -441,6 +447,7 @@ func (r ResourceNamespace) Update(ctx context.Context, req resource.UpdateReques
 		PopulateChannelRegistry: types.BoolValue(ablyNamespace.PopulateChannelRegistry),
 		BatchingEnabled:         optBoolValue(ablyNamespace.BatchingEnabled),
 		ConflationEnabled:       optBoolValue(ablyNamespace.ConflationEnabled),
+		DeletionProtection:      plan.DeletionProtection,
 	}
 
 	if ablyNamespace.BatchingEnabled != nil && *ablyNamespace.BatchingEnabled {
@@ -455,6 +462,7 @@ func (r ResourceNamespace) Update(ctx context.Context, req resource.UpdateReques
 	// Sets state to new namespace.
 	diags = resp.State.Set(ctx, respNamespaces)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respNamespaces.AppID.ValueString(), respNamespaces.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -478,6 +486,10 @@ func (r ResourceNamespace) Delete(ctx context.Context, req resource.DeleteReques
 	appID := state.AppID.ValueString()
 	namespaceID := state.ID.ValueString()
 
+	if !checkDeletionProtection("ably_namespace", namespaceID, state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
 	err := r.p.client.DeleteNamespace(ctx, appID, namespaceID)
 	if err != nil {
 		if is404(err) {
@@ -498,7 +510,12 @@ func (r ResourceNamespace) Delete(ctx context.Context, req resource.DeleteReques
 	resp.State.RemoveResource(ctx)
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r ResourceNamespace) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppScopedIdentitySchema()
+}
+
 // ImportState handles the import state functionality.
 func (r ResourceNamespace) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ImportResource(ctx, req, resp, "app_id", "id")
+	ImportResource(ctx, r.p, req, resp, nil)
 }
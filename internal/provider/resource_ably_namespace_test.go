@@ -61,6 +61,13 @@ func TestAccAblyNamespace(t *testing.T) {
 					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["app_id"], rs.Primary.ID), nil
 				},
 			},
+			// ImportState testing of ably_namespace.namespace0 by app name
+			{
+				ResourceName:      "ably_namespace.namespace0",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     appName + "/" + namespaceName,
+			},
 			// Update and Read testing of ably_app.app0
 			{
 				Config: testAccAblyNamespaceConfig(appName, control.NamespacePost{
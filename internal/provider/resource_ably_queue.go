@@ -3,22 +3,47 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ably/terraform-provider-ably/control"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var _ resource.Resource = &ResourceQueue{}
 var _ resource.ResourceWithImportState = &ResourceQueue{}
+var _ resource.ResourceWithIdentity = &ResourceQueue{}
+var _ resource.ResourceWithModifyPlan = &ResourceQueue{}
+
+// Values of the replacement_mode attribute.
+const (
+	queueReplacementReplace   = "replace"
+	queueReplacementBlueGreen = "blue_green"
+)
+
+// queuePollInterval is how often the provider checks on a queue it is waiting
+// for, whether to reach wait_for_state or to drain.
+var queuePollInterval = 10 * time.Second
+
+// queueStateTimeout is how long the provider waits for a queue to reach
+// wait_for_state.
+var queueStateTimeout = 10 * time.Minute
 
 type ResourceQueue struct {
 	p *AblyProvider
@@ -46,14 +71,14 @@ func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:    true,
 				Description: "The name of the queue.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					queueRequiresReplaceString(),
 				},
 			},
 			"ttl": schema.Int64Attribute{
 				Required:    true,
 				Description: "Time to live in minutes.",
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					queueRequiresReplaceInt64(),
 				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
@@ -63,7 +88,7 @@ func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:    true,
 				Description: "Message limit in number of messages.",
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					queueRequiresReplaceInt64(),
 				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
@@ -73,7 +98,7 @@ func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:    true,
 				Description: "The data center region. US East (Virginia) or EU West (Ireland). Values are us-east-1-a or eu-west-1-a.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					queueRequiresReplaceString(),
 				},
 				Validators: []validator.String{
 					stringvalidator.OneOf("us-east-1-a", "eu-west-1-a"),
@@ -127,6 +152,32 @@ func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:    true,
 				Description: "The ID of the dead letter queue.",
 			},
+			"deletion_protection": deletionProtectionAttribute("queue"),
+			"replacement_mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(queueReplacementReplace),
+				Description: "How changes to name, ttl, max_length and region are applied. `replace` destroys the queue, and the messages in it, before creating " +
+					"the new one. `blue_green` creates the new queue first, repoints the `ably_rule_amqp` rules that target the old queue at it, waits for " +
+					"the old queue's ready messages to drain and then deletes it; as queue names are unique within an app, the new queue needs a new name. Defaults to `replace`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(queueReplacementReplace, queueReplacementBlueGreen),
+				},
+			},
+			"drain_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Description: "How long, in seconds, a `blue_green` replacement waits for the old queue to drain. If messages are still ready after it, the old queue is left in place and the apply fails. Defaults to 600.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"wait_for_state": schema.StringAttribute{
+				Optional: true,
+				Description: "A state, such as `Running`, to wait for the queue to reach after it is created, matched case-insensitively. " +
+					"Creating the queue fails if it has not reached the state within 10 minutes. By default the provider does not wait.",
+			},
 		},
 		MarkdownDescription: "The ably_queue resource allows you to create and manage Ably queues. Read more about Ably queues in Ably documentation: https://ably.com/docs/general/queues.",
 	}
@@ -134,6 +185,107 @@ func (r ResourceQueue) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 func (r ResourceQueue) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "ably_queue"
+	// A blue/green replacement gives the queue a new ID in place.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// queueRequiresReplace reports whether a change to a queue attribute replaces
+// the queue, which it does unless replacement_mode is blue_green.
+func queueRequiresReplace(ctx context.Context, plan tfsdk.Plan) (bool, error) {
+	var mode types.String
+	if diags := plan.GetAttribute(ctx, path.Root("replacement_mode"), &mode); diags.HasError() {
+		return false, fmt.Errorf("could not read replacement_mode")
+	}
+	return mode.ValueString() != queueReplacementBlueGreen, nil
+}
+
+const queueRequiresReplaceDescription = "Changing this replaces the queue unless replacement_mode is blue_green."
+
+// queueRequiresReplaceString returns a plan modifier that replaces the queue
+// when a string attribute changes, unless replacement_mode is blue_green.
+func queueRequiresReplaceString() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		replace, err := queueRequiresReplace(ctx, req.Plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Error planning ably_queue", err.Error())
+			return
+		}
+		resp.RequiresReplace = replace
+	}, queueRequiresReplaceDescription, queueRequiresReplaceDescription)
+}
+
+// queueRequiresReplaceInt64 returns a plan modifier that replaces the queue
+// when an int64 attribute changes, unless replacement_mode is blue_green.
+func queueRequiresReplaceInt64() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+		replace, err := queueRequiresReplace(ctx, req.Plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Error planning ably_queue", err.Error())
+			return
+		}
+		resp.RequiresReplace = replace
+	}, queueRequiresReplaceDescription, queueRequiresReplaceDescription)
+}
+
+// queueChanged reports whether plan changes any attribute the Control API
+// fixes when it creates a queue.
+func queueChanged(plan, state AblyQueue) bool {
+	return !plan.Name.Equal(state.Name) || !plan.Ttl.Equal(state.Ttl) ||
+		!plan.MaxLength.Equal(state.MaxLength) || !plan.Region.Equal(state.Region)
+}
+
+// ModifyPlan marks the attributes of the queue a blue/green replacement
+// creates as unknown. UseStateForUnknown would otherwise plan them as the old
+// queue's. It refuses a blue/green replacement that keeps the queue's name:
+// queue names are unique within an app, so the new queue could not be created
+// alongside the old one.
+func (r ResourceQueue) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state AblyQueue
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !queueChanged(plan, state) {
+		return
+	}
+	if plan.ReplacementMode.ValueString() == queueReplacementBlueGreen && plan.Name.Equal(state.Name) {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Blue/green replacement needs a new queue name",
+			fmt.Sprintf("Queue names are unique within an app, so the new queue cannot be created alongside %s. "+
+				"Change name along with the other attributes, or use replacement_mode = %q.", state.Name.ValueString(), queueReplacementReplace))
+		return
+	}
+
+	plan.ID = types.StringUnknown()
+	plan.AmqpUri = types.StringUnknown()
+	plan.AmqpQueueName = types.StringUnknown()
+	plan.StompURI = types.StringUnknown()
+	plan.StompHost = types.StringUnknown()
+	plan.StompDestination = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// ablyQueueState maps a queue returned by the Control API onto the resource
+// model. The attributes that live only in state are left for the caller.
+func ablyQueueState(appID string, q control.QueueResponse) AblyQueue {
+	return AblyQueue{
+		AppID:     types.StringValue(appID),
+		ID:        types.StringValue(q.ID),
+		Name:      types.StringValue(q.Name),
+		Ttl:       types.Int64Value(int64(q.TTL)),
+		MaxLength: types.Int64Value(int64(q.MaxLength)),
+		Region:    types.StringValue(q.Region),
+
+		AmqpUri:          types.StringValue(q.AMQP.URI),
+		AmqpQueueName:    types.StringValue(q.AMQP.QueueName),
+		StompURI:         types.StringValue(q.Stomp.URI),
+		StompHost:        types.StringValue(q.Stomp.Host),
+		StompDestination: types.StringValue(q.Stomp.Destination),
+		State:            types.StringValue(q.State),
+		Deadletter:       types.BoolValue(q.Deadletter),
+		DeadletterID:     optStringValue(q.DeadletterID),
+	}
 }
 
 // Create creates a new resource.
@@ -168,28 +320,31 @@ func (r ResourceQueue) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Maps response body to resource schema attributes.
-	respApps := AblyQueue{
-		AppID:     types.StringValue(plan.AppID.ValueString()),
-		ID:        types.StringValue(ablyQueue.ID),
-		Name:      types.StringValue(ablyQueue.Name),
-		Ttl:       types.Int64Value(int64(ablyQueue.TTL)),
-		MaxLength: types.Int64Value(int64(ablyQueue.MaxLength)),
-		Region:    types.StringValue(ablyQueue.Region),
-
-		AmqpUri:          types.StringValue(ablyQueue.AMQP.URI),
-		AmqpQueueName:    types.StringValue(ablyQueue.AMQP.QueueName),
-		StompURI:         types.StringValue(ablyQueue.Stomp.URI),
-		StompHost:        types.StringValue(ablyQueue.Stomp.Host),
-		StompDestination: types.StringValue(ablyQueue.Stomp.Destination),
-		State:            types.StringValue(ablyQueue.State),
-		Deadletter:       types.BoolValue(ablyQueue.Deadletter),
-		DeadletterID:     optStringValue(ablyQueue.DeadletterID),
+	if want := plan.WaitForState.ValueString(); want != "" {
+		queue, err := waitForQueueState(ctx, r.p.client, plan.AppID.ValueString(), ablyQueue.ID, want, queueStateTimeout)
+		if err != nil {
+			// The queue exists, so it is kept in state, where the error
+			// taints it.
+			resp.Diagnostics.AddError(
+				"Error creating ably_queue",
+				"Created ably_queue, but it did not become ready: "+err.Error(),
+			)
+		} else {
+			ablyQueue = queue
+		}
 	}
 
+	// Maps response body to resource schema attributes.
+	respApps := ablyQueueState(plan.AppID.ValueString(), ablyQueue)
+	respApps.DeletionProtection = plan.DeletionProtection
+	respApps.ReplacementMode = plan.ReplacementMode
+	respApps.DrainTimeoutSeconds = plan.DrainTimeoutSeconds
+	respApps.WaitForState = plan.WaitForState
+
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, respApps)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respApps.AppID.ValueString(), respApps.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -233,28 +388,23 @@ func (r ResourceQueue) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Loops through queues and if id matches, sets state.
 	for _, v := range queues {
 		if v.ID == queueID {
-			respQueues := AblyQueue{
-				AppID:     types.StringValue(v.AppID),
-				ID:        types.StringValue(v.ID),
-				Name:      types.StringValue(v.Name),
-				Ttl:       types.Int64Value(int64(v.TTL)),
-				MaxLength: types.Int64Value(int64(v.MaxLength)),
-				Region:    types.StringValue(v.Region),
-
-				AmqpUri:          types.StringValue(v.AMQP.URI),
-				AmqpQueueName:    types.StringValue(v.AMQP.QueueName),
-				StompURI:         types.StringValue(v.Stomp.URI),
-				StompHost:        types.StringValue(v.Stomp.Host),
-				StompDestination: types.StringValue(v.Stomp.Destination),
-				State:            types.StringValue(v.State),
-				Deadletter:       types.BoolValue(v.Deadletter),
-				DeadletterID:     optStringValue(v.DeadletterID),
+			respQueues := ablyQueueState(v.AppID, v)
+			respQueues.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+			respQueues.ReplacementMode = state.ReplacementMode
+			if respQueues.ReplacementMode.IsNull() {
+				respQueues.ReplacementMode = types.StringValue(queueReplacementReplace)
 			}
+			respQueues.DrainTimeoutSeconds = state.DrainTimeoutSeconds
+			if respQueues.DrainTimeoutSeconds.IsNull() {
+				respQueues.DrainTimeoutSeconds = types.Int64Value(600)
+			}
+			respQueues.WaitForState = state.WaitForState
 			// Sets state to queue values.
 			diags = resp.State.Set(ctx, &respQueues)
 			found = true
 
 			resp.Diagnostics.Append(diags...)
+			resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, respQueues.AppID.ValueString(), respQueues.ID.ValueString())...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
@@ -273,12 +423,242 @@ func (r ResourceQueue) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// This function should never end up being run but needs to exist to satisfy the interface
-	// this error is just in case terraform decides to call it.
-	resp.Diagnostics.AddError(
-		"Error updating ably_queue",
-		"ably_queue can not be modified",
-	)
+	var plan, state AblyQueue
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The Control API cannot update a queue. Unless replacement_mode is
+	// blue_green, changing a queue attribute replaces the queue, so only the
+	// attributes that live in state alone can change here.
+	newState := state
+	if queueChanged(plan, state) {
+		if !checkDeletionProtection("ably_queue", state.ID.ValueString(), state.DeletionProtection, &resp.Diagnostics) {
+			return
+		}
+		queue, diags := replaceQueueBlueGreen(ctx, r.p.client, state, plan)
+		resp.Diagnostics.Append(diags...)
+		if queue != nil {
+			newState = ablyQueueState(state.AppID.ValueString(), *queue)
+		}
+	}
+
+	newState.DeletionProtection = plan.DeletionProtection
+	newState.ReplacementMode = plan.ReplacementMode
+	newState.DrainTimeoutSeconds = plan.DrainTimeoutSeconds
+	newState.WaitForState = plan.WaitForState
+	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, newState.AppID.ValueString(), newState.ID.ValueString())...)
+}
+
+// replaceQueueBlueGreen replaces the queue old with one built from plan
+// without losing messages. It creates the new queue, waits for it to reach
+// plan's wait_for_state, if any, repoints the AMQP rules that target the old
+// queue at it, waits up to plan's drain_timeout_seconds
+// for the old queue's ready messages to be consumed and then deletes the old
+// queue.
+//
+// A non-nil queue is returned once the rules point at the new queue, even
+// with an error, because from then on the new queue is the one in use. A
+// failure before that removes the new queue again and leaves the rules alone,
+// unless some rules could not be pointed back at the old queue: the new queue
+// is then kept for them and each is reported.
+func replaceQueueBlueGreen(ctx context.Context, client *control.Client, old, plan AblyQueue) (*control.QueueResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	appID := old.AppID.ValueString()
+	oldID := old.ID.ValueString()
+	failed := func(err error) {
+		diags.AddError(
+			"Error updating ably_queue",
+			fmt.Sprintf("Blue/green replacement of queue %s failed: %s", oldID, err),
+		)
+	}
+
+	queue, err := client.CreateQueue(ctx, appID, control.Queue{
+		Name:      plan.Name.ValueString(),
+		TTL:       int(plan.Ttl.ValueInt64()),
+		MaxLength: int(plan.MaxLength.ValueInt64()),
+		Region:    plan.Region.ValueString(),
+	})
+	if err != nil {
+		failed(fmt.Errorf("could not create the new queue %s: %w", plan.Name.ValueString(), err))
+		return nil, diags
+	}
+
+	newID := queue.ID
+	if want := plan.WaitForState.ValueString(); want != "" {
+		queue, err = waitForQueueState(ctx, client, appID, newID, want, queueStateTimeout)
+	}
+	var stranded []strandedRule
+	if err == nil {
+		stranded, err = repointAMQPRules(ctx, client, appID, oldID, newID)
+	}
+	if len(stranded) > 0 {
+		failed(fmt.Errorf("%w; the new queue %s was kept because rules still target it", err, newID))
+		for _, s := range stranded {
+			diags.AddError(
+				"Error restoring ably_rule_amqp",
+				fmt.Sprintf("Rule %s targets the new queue %s and could not be pointed back at queue %s: %s. "+
+					"Point it back by hand, then delete queue %s.", s.id, newID, oldID, s.err, newID),
+			)
+		}
+		return nil, diags
+	}
+	if err != nil {
+		if delErr := client.DeleteQueue(context.WithoutCancel(ctx), appID, newID); delErr != nil {
+			failed(fmt.Errorf("%w; the new queue %s could not be deleted again, delete it by hand: %s", err, newID, delErr))
+			return nil, diags
+		}
+		failed(fmt.Errorf("%w; the new queue %s was deleted again", err, newID))
+		return nil, diags
+	}
+
+	timeout := time.Duration(plan.DrainTimeoutSeconds.ValueInt64()) * time.Second
+	drained, err := waitForQueueDrain(ctx, client, appID, oldID, timeout)
+	if err != nil {
+		failed(fmt.Errorf("the AMQP rules now target the new queue %s, but the old queue was left in place: %w", newID, err))
+		diags.AddWarning(
+			"Old ably_queue left in place",
+			fmt.Sprintf("Queue %s is no longer in state and was not deleted. Delete it by hand once it drains.", oldID),
+		)
+		return &queue, diags
+	}
+	if !drained {
+		// The old queue is already gone.
+		return &queue, diags
+	}
+
+	if err := client.DeleteQueue(ctx, appID, oldID); err != nil && !is404(err) {
+		failed(fmt.Errorf("the old queue %s drained but could not be deleted, delete it by hand: %w", oldID, err))
+	}
+	return &queue, diags
+}
+
+// strandedRule is a rule that repointAMQPRules moved to the new queue but
+// could not point back at the old one.
+type strandedRule struct {
+	id  string
+	err error
+}
+
+// repointAMQPRules points every AMQP rule in the app that targets queue from
+// at queue to instead. If an update fails, the rules already repointed are
+// pointed back at from, and those that can't be are returned.
+func repointAMQPRules(ctx context.Context, client *control.Client, appID, from, to string) ([]strandedRule, error) {
+	rules, err := client.ListRules(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list the rules targeting the queue: %w", err)
+	}
+
+	var moved []control.RuleResponse
+	for _, rule := range rules {
+		if rule.RuleType != "amqp" {
+			continue
+		}
+		target, err := unmarshalTarget[control.AMQPRuleTarget](rule.Target)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the target of rule %s: %w", rule.ID, err)
+		}
+		if target.QueueID != from {
+			continue
+		}
+		if err := repointAMQPRule(ctx, client, rule, target, to); err != nil {
+			var stranded []strandedRule
+			for _, m := range moved {
+				t, _ := unmarshalTarget[control.AMQPRuleTarget](m.Target)
+				if err := repointAMQPRule(context.WithoutCancel(ctx), client, m, t, from); err != nil {
+					stranded = append(stranded, strandedRule{id: m.ID, err: err})
+				}
+			}
+			return stranded, fmt.Errorf("could not repoint rule %s at the new queue: %w", rule.ID, err)
+		}
+		moved = append(moved, rule)
+	}
+	return nil, nil
+}
+
+// repointAMQPRule sends rule back to the Control API with target aimed at
+// queueID.
+func repointAMQPRule(ctx context.Context, client *control.Client, rule control.RuleResponse, target control.AMQPRuleTarget, queueID string) error {
+	target.QueueID = queueID
+	body := control.AMQPRulePost{
+		Status:      rule.Status,
+		RuleType:    rule.RuleType,
+		RequestMode: rule.RequestMode,
+		Target:      target,
+	}
+	if rule.Source != nil {
+		body.Source = *rule.Source
+	}
+	_, err := client.UpdateRule(ctx, rule.AppID, rule.ID, body)
+	return err
+}
+
+// waitForQueueState polls the queue until its state matches want, ignoring
+// case, or until timeout, and returns the queue as last read.
+func waitForQueueState(ctx context.Context, client *control.Client, appID, queueID, want string, timeout time.Duration) (control.QueueResponse, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		queues, err := client.ListQueues(ctx, appID)
+		if err != nil {
+			return control.QueueResponse{}, fmt.Errorf("could not read queue %s: %w", queueID, err)
+		}
+		idx := slices.IndexFunc(queues, func(q control.QueueResponse) bool { return q.ID == queueID })
+		switch {
+		case idx < 0:
+			return control.QueueResponse{}, fmt.Errorf("queue %s disappeared while waiting for it to become %s", queueID, want)
+		case strings.EqualFold(queues[idx].State, want):
+			return queues[idx], nil
+		case !time.Now().Before(deadline):
+			return queues[idx], fmt.Errorf("queue %s is still %s after %s, not %s", queueID, queues[idx].State, timeout, want)
+		}
+
+		select {
+		case <-ctx.Done():
+			return queues[idx], fmt.Errorf("stopped waiting for queue %s to become %s: %w", queueID, want, ctx.Err())
+		case <-time.After(queuePollInterval):
+		}
+	}
+}
+
+// waitForQueueDrain polls the queue until it has no ready messages, or until
+// timeout. It returns false if the queue no longer exists. A queue that does
+// not report its ready count is not taken to be drained, since it may still
+// hold messages.
+func waitForQueueDrain(ctx context.Context, client *control.Client, appID, queueID string, timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		queues, err := client.ListQueues(ctx, appID)
+		if err != nil {
+			return false, fmt.Errorf("could not read queue %s: %w", queueID, err)
+		}
+		var ready *int
+		found := false
+		for _, q := range queues {
+			if q.ID == queueID {
+				ready, found = q.Messages.Ready, true
+				break
+			}
+		}
+		switch {
+		case !found:
+			return false, nil
+		case ready != nil && *ready == 0:
+			return true, nil
+		case !time.Now().Before(deadline) && ready == nil:
+			return false, fmt.Errorf("queue %s did not report how many messages it holds within %s", queueID, timeout)
+		case !time.Now().Before(deadline):
+			return false, fmt.Errorf("queue %s still holds %d ready messages after %s", queueID, *ready, timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("stopped waiting for queue %s to drain: %w", queueID, ctx.Err())
+		case <-time.After(queuePollInterval):
+		}
+	}
 }
 
 // Delete deletes the resource.
@@ -299,6 +679,10 @@ func (r ResourceQueue) Delete(ctx context.Context, req resource.DeleteRequest, r
 	appID := state.AppID.ValueString()
 	queueID := state.ID.ValueString()
 
+	if !checkDeletionProtection("ably_queue", queueID, state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
 	err := r.p.client.DeleteQueue(ctx, appID, queueID)
 	if err != nil {
 		if is404(err) {
@@ -319,7 +703,12 @@ func (r ResourceQueue) Delete(ctx context.Context, req resource.DeleteRequest, r
 	resp.State.RemoveResource(ctx)
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r ResourceQueue) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = GetAppScopedIdentitySchema()
+}
+
 // ImportState handles the import state functionality.
 func (r ResourceQueue) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ImportResource(ctx, req, resp, "app_id", "id")
+	ImportResource(ctx, r.p, req, resp, resolveQueueID)
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/ably/terraform-provider-ably/control"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccAblyQueue(t *testing.T) {
@@ -55,6 +57,19 @@ func TestAccAblyQueue(t *testing.T) {
 					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["app_id"], rs.Primary.ID), nil
 				},
 			},
+			// ImportState testing of ably_queue.queue0 by app ID and queue name
+			{
+				ResourceName:      "ably_queue.queue0",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["ably_queue.queue0"]
+					if !ok {
+						return "", fmt.Errorf("resource not found")
+					}
+					return rs.Primary.Attributes["app_id"] + "/" + queueName, nil
+				},
+			},
 			{
 
 				Config: testAccAblyQueueConfig(appName, control.Queue{
@@ -106,6 +121,87 @@ resource "ably_queue" "queue0" {
 `, appName, queue.Name, queue.TTL, queue.MaxLength, queue.Region)
 }
 
+func TestAccAblyQueue_BlueGreen(t *testing.T) {
+	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAblyQueueBlueGreenConfig(appName, "orders", 60),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_queue.queue0", "replacement_mode", "blue_green"),
+					resource.TestCheckResourceAttr("ably_queue.queue0", "drain_timeout_seconds", "30"),
+					resource.TestCheckResourceAttrPair("ably_rule_amqp.rule0", "target.queue_id", "ably_queue.queue0", "id"),
+				),
+			},
+			// Queue names are unique within an app, so the new queue needs a
+			// new one.
+			{
+				Config:      testAccAblyQueueBlueGreenConfig(appName, "orders", 120),
+				ExpectError: regexp.MustCompile("Blue/green replacement needs a new queue name"),
+			},
+			// Changing ttl and name creates a new queue in place rather than
+			// replacing the resource, and the rule follows it.
+			{
+				Config: testAccAblyQueueBlueGreenConfig(appName, "orders-v2", 120),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ably_queue.queue0", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("ably_queue.queue0", tfjsonpath.New("id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ably_queue.queue0", "ttl", "120"),
+					resource.TestCheckResourceAttr("ably_queue.queue0", "name", "orders-v2"),
+					resource.TestCheckResourceAttrPair("ably_rule_amqp.rule0", "target.queue_id", "ably_queue.queue0", "id"),
+				),
+			},
+		},
+	})
+}
+
+// Function with inline HCL to provision a blue_green queue with an AMQP rule
+// targeting it.
+func testAccAblyQueueBlueGreenConfig(appName, name string, ttl int) string {
+	return fmt.Sprintf(`
+terraform {
+	required_providers {
+		ably = {
+			source = "registry.terraform.io/ably/ably"
+		}
+	}
+}
+provider "ably" {}
+
+resource "ably_app" "app0" {
+	name = %[1]q
+}
+
+resource "ably_queue" "queue0" {
+	app_id                = ably_app.app0.id
+	name                  = %[2]q
+	ttl                   = %[3]d
+	max_length            = 100
+	region                = "us-east-1-a"
+	replacement_mode      = "blue_green"
+	drain_timeout_seconds = 30
+}
+
+resource "ably_rule_amqp" "rule0" {
+	app_id       = ably_app.app0.id
+	request_mode = "single"
+	source = {
+		channel_filter = "^orders"
+		type           = "channel.message"
+	}
+	target = {
+		queue_id = ably_queue.queue0.id
+	}
+}
+`, appName, name, ttl)
+}
+
 func TestAccAblyQueue_InvalidTTL(t *testing.T) {
 	appName := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ably/terraform-provider-ably/control"
+	"github.com/ably/terraform-provider-ably/control/controltest"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReplaceQueueBlueGreen(t *testing.T) {
+	defer func(d time.Duration) { queuePollInterval = d }(queuePollInterval)
+	queuePollInterval = time.Millisecond
+
+	old := AblyQueue{
+		AppID:     types.StringValue("app1"),
+		ID:        types.StringValue("q-old"),
+		Name:      types.StringValue("orders"),
+		Ttl:       types.Int64Value(60),
+		MaxLength: types.Int64Value(100),
+		Region:    types.StringValue("us-east-1-a"),
+	}
+	plan := old
+	plan.Name = types.StringValue("orders-v2")
+	plan.Ttl = types.Int64Value(120)
+	plan.DrainTimeoutSeconds = types.Int64Value(0)
+
+	amqpRule := func(id, queueID string) controltest.Record {
+		return controltest.Record{
+			"id":          id,
+			"ruleType":    "amqp",
+			"requestMode": "single",
+			"source":      map[string]any{"channelFilter": "^orders", "type": "channel.message"},
+			"target":      map[string]any{"queueId": queueID, "format": "json"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		ready     int
+		noReady   bool     // whether the old queue leaves out messages.ready
+		fail      []string // "METHOD path" of requests to fail
+		failLater []string // "METHOD path" of requests to fail after the first match
+		errSubstr string
+		warning   bool // whether the old queue is warned about
+		wantQueue bool // whether a new queue is returned
+		wantOld   bool // whether the old queue is left in place
+		wantMoved bool // whether rules r1 and r2 target the new queue
+		stranded  bool // whether a failed rollback leaves a rule on the new queue
+	}{
+		{name: "drained", wantQueue: true, wantMoved: true},
+		{name: "drain timeout", ready: 3, errSubstr: "still holds 3 ready messages", warning: true, wantQueue: true, wantOld: true, wantMoved: true},
+		{name: "ready count missing", noReady: true, errSubstr: "did not report how many messages it holds", warning: true, wantQueue: true, wantOld: true, wantMoved: true},
+		{name: "create fails", fail: []string{"POST /apps/app1/queues"}, errSubstr: "could not create the new queue orders-v2", wantOld: true},
+		{name: "repoint fails", fail: []string{"PATCH /apps/app1/rules/r2"}, errSubstr: "could not repoint rule r2", wantOld: true},
+		{
+			// The rules are listed in no particular order, so either can be
+			// the one repointed before the other fails.
+			name:      "rollback fails",
+			failLater: []string{"PATCH /apps/app1/rules/*"},
+			errSubstr: "was kept because rules still target it",
+			wantOld:   true,
+			stranded:  true,
+		},
+		{name: "delete fails", fail: []string{"DELETE /apps/app1/queues/q-old"}, errSubstr: "could not be deleted", wantQueue: true, wantOld: true, wantMoved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := controltest.NewServer()
+			t.Cleanup(fake.Close)
+			messages := map[string]any{"ready": tt.ready}
+			if tt.noReady {
+				messages = map[string]any{}
+			}
+			fake.SeedQueue("app1", controltest.Record{"id": "q-old", "name": "orders", "deadletterId": "dlq", "messages": messages})
+			fake.SeedQueue("app1", controltest.Record{"id": "dlq", "name": "deadletter", "deadletter": true})
+			fake.SeedRule("app1", amqpRule("r1", "q-old"))
+			fake.SeedRule("app1", amqpRule("r2", "q-old"))
+			fake.SeedRule("app1", amqpRule("other", "q-other"))
+			for _, fail := range tt.fail {
+				method, path, _ := strings.Cut(fail, " ")
+				fake.InjectFault(method, path, controltest.Fault{Status: http.StatusInternalServerError})
+			}
+			for _, fail := range tt.failLater {
+				method, path, _ := strings.Cut(fail, " ")
+				fake.InjectFault(method, path, controltest.Fault{Times: 1})
+				fake.InjectFault(method, path, controltest.Fault{Status: http.StatusInternalServerError})
+			}
+			client := control.NewClient("fake-token", control.WithRetryMax(0))
+			client.BaseURL = fake.URL
+
+			queue, diags := replaceQueueBlueGreen(context.Background(), client, old, plan)
+			var stored controltest.State
+			fake.Inspect(func(state *controltest.State) { stored = *state })
+
+			errs := diags.Errors()
+			if tt.errSubstr == "" && len(errs) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if tt.errSubstr != "" && (len(errs) == 0 || !strings.Contains(errs[0].Detail(), tt.errSubstr)) {
+				t.Fatalf("diagnostics = %v, want an error containing %q", diags, tt.errSubstr)
+			}
+			var stranded string
+			for _, id := range []string{"r1", "r2"} {
+				if len(errs) == 2 && strings.Contains(errs[1].Detail(), "Rule "+id+" targets the new queue") {
+					stranded = id
+				}
+			}
+			if tt.stranded && stranded == "" {
+				t.Errorf("diagnostics = %v, want an error naming the stranded rule", diags)
+			}
+			warnings := diags.Warnings()
+			if tt.warning && (len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "Queue q-old ")) {
+				t.Errorf("warnings = %v, want one naming queue q-old", warnings)
+			}
+			if !tt.warning && len(warnings) > 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+			if (queue != nil) != tt.wantQueue {
+				t.Fatalf("returned queue = %v, want one: %t", queue, tt.wantQueue)
+			}
+			if _, ok := stored.Queues["app1"]["q-old"]; ok != tt.wantOld {
+				t.Errorf("old queue left in place = %t, want %t", ok, tt.wantOld)
+			}
+			if !tt.wantQueue && !tt.stranded && len(stored.Queues["app1"]) != 2 {
+				t.Errorf("the fake has %d queues, want the new one deleted again", len(stored.Queues["app1"]))
+			}
+
+			var newID string
+			for id, q := range stored.Queues["app1"] {
+				if q["name"] == "orders-v2" {
+					newID = id
+				}
+			}
+			if tt.stranded && newID == "" {
+				t.Error("the new queue was deleted, want it kept for the stranded rule")
+			}
+			if tt.wantMoved && queue.TTL != 120 {
+				t.Errorf("new queue ttl = %d, want 120", queue.TTL)
+			}
+			for _, id := range []string{"r1", "r2"} {
+				wantTarget := "q-old"
+				if tt.wantMoved || id == stranded {
+					wantTarget = newID
+				}
+				if got := stored.Rules["app1"][id]["target"].(map[string]any)["queueId"]; got != wantTarget {
+					t.Errorf("rule %s targets %v, want %s", id, got, wantTarget)
+				}
+			}
+			if got := stored.Rules["app1"]["other"]["target"].(map[string]any)["queueId"]; got != "q-other" {
+				t.Errorf("rule other targets %v, want it left on q-other", got)
+			}
+		})
+	}
+}
+
+func TestWaitForQueueState(t *testing.T) {
+	defer func(d time.Duration) { queuePollInterval = d }(queuePollInterval)
+	queuePollInterval = time.Millisecond
+
+	tests := []struct {
+		name      string
+		startAt   string // the state the queue reports until it has been read twice
+		thenAt    string // "" means the queue is deleted instead
+		timeout   time.Duration
+		errSubstr string
+	}{
+		{name: "already there", startAt: "Running", thenAt: "Running"},
+		{name: "case-insensitive", startAt: "running", thenAt: "running"},
+		{name: "reaches state", startAt: "Starting", thenAt: "Running", timeout: time.Minute},
+		{name: "timeout", startAt: "Starting", thenAt: "Starting", errSubstr: "still Starting after 0s, not Running"},
+		{name: "disappears", startAt: "Starting", timeout: time.Minute, errSubstr: "disappeared"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := controltest.NewServer()
+			t.Cleanup(fake.Close)
+			fake.SeedQueue("app1", controltest.Record{"id": "q1", "name": "orders", "state": tt.startAt})
+			reads := 0
+			fake.InjectFault(http.MethodGet, "/apps/app1/queues", controltest.Fault{
+				Then: func(state *controltest.State) {
+					if reads++; reads == 2 {
+						if tt.thenAt == "" {
+							delete(state.Queues["app1"], "q1")
+						} else {
+							state.Queues["app1"]["q1"]["state"] = tt.thenAt
+						}
+					}
+				},
+			})
+			client := control.NewClient("fake-token", control.WithRetryMax(0))
+			client.BaseURL = fake.URL
+
+			queue, err := waitForQueueState(context.Background(), client, "app1", "q1", "Running", tt.timeout)
+
+			if tt.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.errSubstr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if queue.ID != "q1" || !strings.EqualFold(queue.State, "Running") {
+				t.Errorf("queue = %+v, want q1 in state Running", queue)
+			}
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/ably/terraform-provider-ably/internal/provider/codegen/resource_rule_bodyguard"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -41,7 +40,7 @@ type AblyRuleBodyguardTarget struct {
 // Note: unlike webhook/firehose rules (AblyRule), moderation rules have NO
 // `source` and NO `request_mode`. They instead carry `invocation_mode`,
 // `chat_room_filter` and a `before_publish_config` block, which is why this
-// resource does not reuse the generic GetRuleSchema/CreateRule[T] plumbing.
+// resource does not reuse the generic CreateRule[T] plumbing.
 type AblyRuleBodyguard struct {
 	ID                  types.String                          `tfsdk:"id"`
 	AppID               types.String                          `tfsdk:"app_id"`
@@ -50,15 +49,9 @@ type AblyRuleBodyguard struct {
 	ChatRoomFilter      types.String                          `tfsdk:"chat_room_filter"`
 	BeforePublishConfig *AblyRuleBodyguardBeforePublishConfig `tfsdk:"before_publish_config"`
 	Target              *AblyRuleBodyguardTarget              `tfsdk:"target"`
+	DestroyBehavior     types.String                          `tfsdk:"destroy_behavior"`
 }
 
-type ResourceRuleBodyguard struct {
-	p *AblyProvider
-}
-
-var _ resource.Resource = &ResourceRuleBodyguard{}
-var _ resource.ResourceWithImportState = &ResourceRuleBodyguard{}
-
 // Schema defines the schema for the resource.
 //
 // PORTED ONTO GENERATED CODE (see DEVELOPMENT.md "Porting a resource onto
@@ -66,35 +59,10 @@ var _ resource.ResourceWithImportState = &ResourceRuleBodyguard{}
 // sensitivity, descriptions, validators, defaults and plan modifiers, comes
 // from the generated schema in internal/provider/codegen, produced by `make
 // generate` from the in-repo control rule types plus the overrides table in
-// codegen/ruletypesgen. The only hand-work left is stripping the generated
-// CustomType from the nested blocks so the hand-written plain-struct model
-// reflects cleanly, and setting the resource-level description.
+// codegen/rule_overrides.yml. adoptRuleSchema does the little hand-work left.
 func (r ResourceRuleBodyguard) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	s := resource_rule_bodyguard.RuleBodyguardResourceSchema(ctx)
-
-	bpc := s.Attributes["before_publish_config"].(schema.SingleNestedAttribute)
-	bpc.CustomType = nil
-	s.Attributes["before_publish_config"] = bpc
-
-	tgt := s.Attributes["target"].(schema.SingleNestedAttribute)
-	tgt.CustomType = nil
-	s.Attributes["target"] = tgt
-
-	s.MarkdownDescription = "The `ably_rule_bodyguard` resource allows you to create and manage an Ably integration rule for Bodyguard text moderation. This rule moderates messages before they are published. Read more at https://ably.com/docs/integrations/moderation"
-
-	resp.Schema = s
-}
-
-func (r ResourceRuleBodyguard) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = "ably_rule_bodyguard"
-}
-
-func (r *ResourceRuleBodyguard) Provider() *AblyProvider {
-	return r.p
-}
-
-func (r *ResourceRuleBodyguard) Name() string {
-	return "Bodyguard"
+	resp.Schema = adoptRuleSchema(resource_rule_bodyguard.RuleBodyguardResourceSchema(ctx),
+		"The `ably_rule_bodyguard` resource allows you to create and manage an Ably integration rule for Bodyguard text moderation. This rule moderates messages before they are published. Read more at https://ably.com/docs/integrations/moderation")
 }
 
 // getPlanBodyguardPost converts the plan model into the Control API create body.
@@ -199,9 +167,11 @@ func (r ResourceRuleBodyguard) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	responseValues.DestroyBehavior = destroyBehaviorValue(plan.DestroyBehavior)
 
 	diags = resp.State.Set(ctx, responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 }
 
 // Read reads the resource.
@@ -231,9 +201,11 @@ func (r ResourceRuleBodyguard) Read(ctx context.Context, req resource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	responseValues.DestroyBehavior = destroyBehaviorValue(state.DestroyBehavior)
 
 	diags = resp.State.Set(ctx, &responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 }
 
 // Update updates an existing resource.
@@ -259,9 +231,11 @@ func (r ResourceRuleBodyguard) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	responseValues.DestroyBehavior = destroyBehaviorValue(plan.DestroyBehavior)
 
 	diags = resp.State.Set(ctx, &responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 }
 
 // Delete deletes the resource.
@@ -273,7 +247,17 @@ func (r ResourceRuleBodyguard) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.Provider().client.DeleteRule(ctx, state.AppID.ValueString(), state.ID.ValueString())
+	// With destroy_behavior = "disable" the rule is sent back as it is in
+	// state, but disabled, and left for a cleanup job to delete. The
+	// moderation PATCH requires the target, and the API returns all of it,
+	// API key included, so state holds it whole.
+	var err error
+	if state.DestroyBehavior.ValueString() == destroyBehaviorDisable {
+		state.Status = types.StringValue("disabled")
+		_, err = r.Provider().client.UpdateRule(ctx, state.AppID.ValueString(), state.ID.ValueString(), getPlanBodyguardPost(state))
+	} else {
+		err = r.Provider().client.DeleteRule(ctx, state.AppID.ValueString(), state.ID.ValueString())
+	}
 	if err != nil {
 		if is404(err) {
 			resp.Diagnostics.AddWarning(
@@ -291,8 +275,3 @@ func (r ResourceRuleBodyguard) Delete(ctx context.Context, req resource.DeleteRe
 
 	resp.State.RemoveResource(ctx)
 }
-
-// ImportState handles the import state functionality.
-func (r ResourceRuleBodyguard) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ImportResource(ctx, req, resp, "app_id", "id")
-}
@@ -4,71 +4,13 @@ package provider
 import (
 	"context"
 
+	"github.com/ably/terraform-provider-ably/internal/provider/codegen/resource_rule_kinesis"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
-type ResourceRuleKinesis struct {
-	p *AblyProvider
-}
-
-var _ resource.Resource = &ResourceRuleKinesis{}
-var _ resource.ResourceWithImportState = &ResourceRuleKinesis{}
-
-// Schema defines the schema for the resource.
-func (r ResourceRuleKinesis) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = GetRuleSchema(
-		map[string]schema.Attribute{
-			"region": schema.StringAttribute{
-				Optional: true,
-			},
-			"stream_name": schema.StringAttribute{
-				Optional: true,
-			},
-			"partition_key": schema.StringAttribute{
-				Optional: true,
-			},
-			"enveloped":      GetEnvelopedSchema(),
-			"format":         GetFormatSchema(),
-			"authentication": GetAwsAuthSchema(),
-		},
-		"The `ably_rule_kinesis` resource allows you to create and manage an Ably integration rule for AWS Kinesis. Read more at https://ably.com/docs/general/firehose/kinesis-rule",
-	)
-}
-
-func (r ResourceRuleKinesis) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = "ably_rule_kinesis"
-}
-
-func (r *ResourceRuleKinesis) Provider() *AblyProvider {
-	return r.p
-}
-
-func (r *ResourceRuleKinesis) Name() string {
-	return "AWS Kinesis"
-}
-
-// Create creates a new resource.
-func (r ResourceRuleKinesis) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	CreateRule[AblyRuleTargetKinesis](&r, ctx, req, resp)
-}
-
-// Read resource
-func (r ResourceRuleKinesis) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	ReadRule[AblyRuleTargetKinesis](&r, ctx, req, resp)
-}
-
-// Update updates an existing resource.
-func (r ResourceRuleKinesis) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	UpdateRule[AblyRuleTargetKinesis](&r, ctx, req, resp)
-}
-
-// Delete deletes the resource.
-func (r ResourceRuleKinesis) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	DeleteRule[AblyRuleTargetKinesis](&r, ctx, req, resp)
-}
-
-// ImportState handles the import state functionality.
-func (r ResourceRuleKinesis) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ImportResource(ctx, req, resp, "app_id", "id")
+// Schema defines the schema for the resource. It is generated by `make
+// generate` from the control rule types and codegen/rule_overrides.yml.
+func (r ResourceRuleKinesis) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = adoptRuleSchema(resource_rule_kinesis.RuleKinesisResourceSchema(ctx),
+		"The `ably_rule_kinesis` resource allows you to create and manage an Ably integration rule for AWS Kinesis. Read more at https://ably.com/docs/general/firehose/kinesis-rule")
 }
@@ -9,36 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
-// RuleTypeResources maps a Control API ruleType onto the Terraform resource type
-// that manages it.
-//
-// Every integration rule comes back from GET /apps/{id}/rules regardless of
-// family: webhooks, firehoses, ingress and moderation rules all arrive in one
-// list, told apart only by ruleType. Anything walking an account needs this
-// mapping to know which resource owns a rule, and it is otherwise implicit in the
-// type switches in rules.go and ingress_rules.go.
-//
-// Adding a rule resource means adding a line here. TestRuleTypeResources fails if
-// you forget, or if this table names a resource the provider doesn't register.
-var RuleTypeResources = map[string]string{
-	"aws/kinesis":                "ably_rule_kinesis",
-	"aws/sqs":                    "ably_rule_sqs",
-	"aws/lambda":                 "ably_rule_lambda",
-	"pulsar":                     "ably_rule_pulsar",
-	"kafka":                      "ably_rule_kafka",
-	"amqp":                       "ably_rule_amqp",
-	"amqp/external":              "ably_rule_amqp_external",
-	"http":                       "ably_rule_http",
-	"http/zapier":                "ably_rule_zapier",
-	"http/cloudflare-worker":     "ably_rule_cloudflare_worker",
-	"http/azure-function":        "ably_rule_azure_function",
-	"http/google-cloud-function": "ably_rule_google_function",
-	"http/ifttt":                 "ably_rule_ifttt",
-	"bodyguard/text-moderation":  "ably_rule_bodyguard",
-	"ingress/mongodb":            "ably_ingress_rule_mongodb",
-	"ingress-postgres-outbox":    "ably_ingress_rule_postgres_outbox",
-}
-
 // ResourceTypeNames returns the sorted type name of every resource the provider
 // registers. It asks each resource for its Metadata, so the list can't drift from
 // what the provider serves.
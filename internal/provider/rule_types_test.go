@@ -33,7 +33,7 @@ func TestRuleTypeResources(t *testing.T) {
 		}
 		if _, ok := mapped[name]; !ok {
 			t.Errorf("rule resource %q has no ruleType in RuleTypeResources.\n"+
-				"Add the Control API ruleType it manages to the table in rule_types.go, "+
+				"Add it to the shims table in codegen/ruletypesgen/shims.go and run `make generate`, "+
 				"otherwise the exporter cannot tell which rules belong to it.", name)
 		}
 	}
@@ -5,49 +5,69 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"reflect"
 
 	"github.com/ably/terraform-provider-ably/control"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func GetPlanAwsAuth(plan AblyRule) control.AWSAuthentication {
-	var auth AwsAuth
+// awsAuthTarget is a rule target carrying an AWS authentication block. The
+// shims generated for rules declaring the awsAuth hook implement it.
+type awsAuthTarget interface {
+	awsAuth() AblyAWSAuthentication
+}
 
-	switch t := plan.Target.(type) {
-	case *AblyRuleTargetKinesis:
-		if t != nil {
-			auth = t.AwsAuth
-		}
-	case *AblyRuleTargetSqs:
-		if t != nil {
-			auth = t.AwsAuth
-		}
-	case *AblyRuleTargetLambda:
-		if t != nil {
-			auth = t.AwsAuth
-		}
+// writeOnlyTarget is a rule target with fields the Control API accepts but
+// never returns. The shims generated for rules declaring the writeOnly hook
+// implement it, copying those fields from plan (a target of the same type).
+type writeOnlyTarget interface {
+	preserveWriteOnly(plan any)
+}
+
+// preserveFromPlan sets field to the plan's value when the API returned
+// nothing for it and the plan has something, so a write-only field doesn't
+// read back as a diff.
+func preserveFromPlan[T any](field *T, plan T) {
+	if isEmptyValue(*field) && !isEmptyValue(plan) {
+		*field = plan
+	}
+}
+
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case types.String:
+		return v.IsNull() || v.IsUnknown() || v.ValueString() == ""
+	case attr.Value:
+		return v.IsNull() || v.IsUnknown()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+func GetPlanAwsAuth(plan AblyRule) control.AWSAuthentication {
+	var auth AblyAWSAuthentication
+	if t, ok := plan.Target.(awsAuthTarget); ok {
+		auth = t.awsAuth()
 	}
 
 	var controlAuth control.AWSAuthentication
 	if auth.AuthenticationMode.ValueString() == "assumeRole" {
 		controlAuth = control.AWSAuthentication{
 			AuthenticationMode: string(control.AWSAuthModeAssumeRole),
-			AssumeRoleArn:      auth.RoleArn.ValueString(),
+			AssumeRoleArn:      auth.AssumeRoleArn.ValueString(),
 		}
 	} else if auth.AuthenticationMode.ValueString() == "credentials" {
 		controlAuth = control.AWSAuthentication{
 			AuthenticationMode: string(control.AWSAuthModeCredentials),
-			AccessKeyID:        auth.AccessKeyId.ValueString(),
+			AccessKeyID:        auth.AccessKeyID.ValueString(),
 			SecretAccessKey:    auth.SecretAccessKey.ValueString(),
 		}
 	}
@@ -115,7 +135,7 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			Source:      source,
 			Target: control.AWSSQSTarget{
 				Region:         t.Region.ValueString(),
-				AWSAccountID:   t.AwsAccountID.ValueString(),
+				AWSAccountID:   t.AWSAccountID.ValueString(),
 				QueueName:      t.QueueName.ValueString(),
 				Enveloped:      ptr(t.Enveloped.ValueBool()),
 				Format:         t.Format.ValueString(),
@@ -142,9 +162,9 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			RequestMode: requestMode,
 			Source:      source,
 			Target: control.ZapierRuleTarget{
-				URL:          t.Url.ValueString(),
+				URL:          t.URL.ValueString(),
 				Headers:      GetHeaders(t.Headers),
-				SigningKeyID: optionalStringPtr(t.SigningKeyId),
+				SigningKeyID: optionalStringPtr(t.SigningKeyID),
 			},
 		}, diags
 	case *AblyRuleTargetCloudflareWorker:
@@ -154,9 +174,9 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			RequestMode: requestMode,
 			Source:      source,
 			Target: control.CloudflareWorkerRuleTarget{
-				URL:          t.Url.ValueString(),
+				URL:          t.URL.ValueString(),
 				Headers:      GetHeaders(t.Headers),
-				SigningKeyID: optionalStringPtr(t.SigningKeyId),
+				SigningKeyID: optionalStringPtr(t.SigningKeyID),
 			},
 		}, diags
 	case *AblyRuleTargetPulsar:
@@ -169,9 +189,9 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 				RoutingKey:    t.RoutingKey.ValueString(),
 				Topic:         t.Topic.ValueString(),
 				ServiceURL:    t.ServiceURL.ValueString(),
-				TLSTrustCerts: sliceString(t.TlsTrustCerts),
+				TLSTrustCerts: sliceString(t.TLSTrustCerts),
 				Authentication: &control.PulsarAuth{
-					AuthenticationMode: t.Authentication.Mode.ValueString(),
+					AuthenticationMode: t.Authentication.AuthenticationMode.ValueString(),
 					Token:              t.Authentication.Token.ValueString(),
 				},
 				Enveloped: ptr(t.Enveloped.ValueBool()),
@@ -185,9 +205,9 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			RequestMode: requestMode,
 			Source:      source,
 			Target: control.HTTPRuleTarget{
-				URL:          t.Url.ValueString(),
+				URL:          t.URL.ValueString(),
 				Headers:      GetHeaders(t.Headers),
-				SigningKeyID: optionalStringPtr(t.SigningKeyId),
+				SigningKeyID: optionalStringPtr(t.SigningKeyID),
 				Format:       t.Format.ValueString(),
 				Enveloped:    webhookEnveloped(t.Enveloped, requestMode),
 			},
@@ -229,12 +249,20 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 				ProjectID:    t.ProjectID.ValueString(),
 				FunctionName: t.FunctionName.ValueString(),
 				Headers:      GetHeaders(t.Headers),
-				SigningKeyID: optionalStringPtr(t.SigningKeyId),
+				SigningKeyID: optionalStringPtr(t.SigningKeyID),
 				Enveloped:    webhookEnveloped(t.Enveloped, requestMode),
 				Format:       t.Format.ValueString(),
 			},
 		}, diags
 	case *AblyRuleTargetKafka:
+		auth := &control.KafkaAuth{}
+		if t.Auth.SASL != nil {
+			auth.SASL = &control.KafkaSASL{
+				Mechanism: t.Auth.SASL.Mechanism.ValueString(),
+				Username:  t.Auth.SASL.Username.ValueString(),
+				Password:  t.Auth.SASL.Password.ValueString(),
+			}
+		}
 		return control.KafkaRulePost{
 			Status:      status,
 			RuleType:    "kafka",
@@ -243,15 +271,9 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			Target: control.KafkaRuleTarget{
 				RoutingKey: t.RoutingKey.ValueString(),
 				Brokers:    sliceString(t.Brokers),
-				Auth: &control.KafkaAuth{
-					SASL: &control.KafkaSASL{
-						Mechanism: t.KafkaAuthentication.Sasl.Mechanism.ValueString(),
-						Username:  t.KafkaAuthentication.Sasl.Username.ValueString(),
-						Password:  t.KafkaAuthentication.Sasl.Password.ValueString(),
-					},
-				},
-				Enveloped: ptr(t.Enveloped.ValueBool()),
-				Format:    t.Format.ValueString(),
+				Auth:       auth,
+				Enveloped:  ptr(t.Enveloped.ValueBool()),
+				Format:     t.Format.ValueString(),
 			},
 		}, diags
 	case *AblyRuleTargetAMQP:
@@ -269,8 +291,8 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 		}, diags
 	case *AblyRuleTargetAMQPExternal:
 		msgTTL := (*int)(nil)
-		if !t.MessageTtl.IsNull() && !t.MessageTtl.IsUnknown() {
-			v := int(t.MessageTtl.ValueInt64())
+		if !t.MessageTTL.IsNull() && !t.MessageTTL.IsUnknown() {
+			v := int(t.MessageTTL.ValueInt64())
 			msgTTL = &v
 		}
 		exchange := t.Exchange.ValueString()
@@ -280,7 +302,7 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 			RequestMode: requestMode,
 			Source:      source,
 			Target: control.AMQPExternalRuleTarget{
-				URL:                t.Url.ValueString(),
+				URL:                t.URL.ValueString(),
 				RoutingKey:         t.RoutingKey.ValueString(),
 				Exchange:           exchange,
 				MandatoryRoute:     ptr(t.MandatoryRoute.ValueBool()),
@@ -300,7 +322,7 @@ func GetPlanRule(plan AblyRule) (any, diag.Diagnostics) {
 	return nil, diags
 }
 
-func GetHeaders(headers []AblyRuleHeaders) []control.RuleHeader {
+func GetHeaders(headers []AblyRuleHeader) []control.RuleHeader {
 	var retHeaders []control.RuleHeader
 	for _, h := range headers {
 		retHeaders = append(retHeaders, control.RuleHeader{
@@ -321,38 +343,26 @@ func GetRequestMode(plan AblyRule) string {
 
 // GetAwsAuth converts AWS authentication from control SDK format to terraform format.
 // Using plan to fill in values that the api does not return.
-func GetAwsAuth(auth control.AWSAuthentication, plan *AblyRule) AwsAuth {
-	var planAuth AwsAuth
-
-	switch p := plan.Target.(type) {
-	case *AblyRuleTargetKinesis:
-		if p != nil {
-			planAuth = p.AwsAuth
-		}
-	case *AblyRuleTargetSqs:
-		if p != nil {
-			planAuth = p.AwsAuth
-		}
-	case *AblyRuleTargetLambda:
-		if p != nil {
-			planAuth = p.AwsAuth
-		}
+func GetAwsAuth(auth control.AWSAuthentication, plan *AblyRule) AblyAWSAuthentication {
+	var planAuth AblyAWSAuthentication
+	if p, ok := plan.Target.(awsAuthTarget); ok {
+		planAuth = p.awsAuth()
 	}
 
-	var respAwsAuth AwsAuth
+	var respAwsAuth AblyAWSAuthentication
 	switch control.AWSAuthMode(auth.AuthenticationMode) {
 	case control.AWSAuthModeCredentials:
-		respAwsAuth = AwsAuth{
+		respAwsAuth = AblyAWSAuthentication{
 			AuthenticationMode: types.StringValue(auth.AuthenticationMode),
-			AccessKeyId:        types.StringValue(auth.AccessKeyID),
+			AccessKeyID:        types.StringValue(auth.AccessKeyID),
 			SecretAccessKey:    planAuth.SecretAccessKey,
-			RoleArn:            types.StringNull(),
+			AssumeRoleArn:      types.StringNull(),
 		}
 	case control.AWSAuthModeAssumeRole:
-		respAwsAuth = AwsAuth{
+		respAwsAuth = AblyAWSAuthentication{
 			AuthenticationMode: types.StringValue(auth.AuthenticationMode),
-			RoleArn:            types.StringValue(auth.AssumeRoleArn),
-			AccessKeyId:        types.StringNull(),
+			AssumeRoleArn:      types.StringValue(auth.AssumeRoleArn),
+			AccessKeyID:        types.StringNull(),
 			SecretAccessKey:    types.StringNull(),
 		}
 	}
@@ -371,11 +381,11 @@ func unmarshalTarget[T any](target interface{}) (T, error) {
 	return result, err
 }
 
-// ToHeaders converts a slice of control.RuleHeader to terraform AblyRuleHeaders.
-func ToHeaders(headers []control.RuleHeader) []AblyRuleHeaders {
-	var respHeaders []AblyRuleHeaders
+// ToHeaders converts a slice of control.RuleHeader to terraform AblyRuleHeader.
+func ToHeaders(headers []control.RuleHeader) []AblyRuleHeader {
+	var respHeaders []AblyRuleHeader
 	for _, b := range headers {
-		item := AblyRuleHeaders{
+		item := AblyRuleHeader{
 			Name:  types.StringValue(b.Name),
 			Value: types.StringValue(b.Value),
 		}
@@ -399,12 +409,12 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			return AblyRule{}, diags
 		}
 		respTarget = &AblyRuleTargetKinesis{
-			Region:       types.StringValue(target.Region),
-			StreamName:   types.StringValue(target.StreamName),
-			PartitionKey: types.StringValue(target.PartitionKey),
-			AwsAuth:      GetAwsAuth(target.Authentication, plan),
-			Enveloped:    types.BoolValue(deref(target.Enveloped)),
-			Format:       types.StringValue(target.Format),
+			Region:         types.StringValue(target.Region),
+			StreamName:     types.StringValue(target.StreamName),
+			PartitionKey:   types.StringValue(target.PartitionKey),
+			Authentication: GetAwsAuth(target.Authentication, plan),
+			Enveloped:      types.BoolValue(deref(target.Enveloped)),
+			Format:         types.StringValue(target.Format),
 		}
 	case "aws/sqs":
 		target, err := unmarshalTarget[control.AWSSQSTarget](ablyRule.Target)
@@ -413,12 +423,12 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			return AblyRule{}, diags
 		}
 		respTarget = &AblyRuleTargetSqs{
-			Region:       types.StringValue(target.Region),
-			AwsAccountID: types.StringValue(target.AWSAccountID),
-			QueueName:    types.StringValue(target.QueueName),
-			AwsAuth:      GetAwsAuth(target.Authentication, plan),
-			Enveloped:    types.BoolValue(deref(target.Enveloped)),
-			Format:       types.StringValue(target.Format),
+			Region:         types.StringValue(target.Region),
+			AWSAccountID:   types.StringValue(target.AWSAccountID),
+			QueueName:      types.StringValue(target.QueueName),
+			Authentication: GetAwsAuth(target.Authentication, plan),
+			Enveloped:      types.BoolValue(deref(target.Enveloped)),
+			Format:         types.StringValue(target.Format),
 		}
 	case "aws/lambda":
 		target, err := unmarshalTarget[control.AWSLambdaTarget](ablyRule.Target)
@@ -427,10 +437,10 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			return AblyRule{}, diags
 		}
 		respTarget = &AblyRuleTargetLambda{
-			Region:       types.StringValue(target.Region),
-			FunctionName: types.StringValue(target.FunctionName),
-			AwsAuth:      GetAwsAuth(target.Authentication, plan),
-			Enveloped:    types.BoolValue(deref(target.Enveloped)),
+			Region:         types.StringValue(target.Region),
+			FunctionName:   types.StringValue(target.FunctionName),
+			Authentication: GetAwsAuth(target.Authentication, plan),
+			Enveloped:      types.BoolValue(deref(target.Enveloped)),
 		}
 	case "http/zapier":
 		target, err := unmarshalTarget[control.ZapierRuleTarget](ablyRule.Target)
@@ -440,8 +450,8 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 		}
 		headers := ToHeaders(target.Headers)
 		respTarget = &AblyRuleTargetZapier{
-			Url:          types.StringValue(target.URL),
-			SigningKeyId: optStringValue(target.SigningKeyID),
+			URL:          types.StringValue(target.URL),
+			SigningKeyID: optStringValue(target.SigningKeyID),
 			Headers:      headers,
 		}
 	case "http/cloudflare-worker":
@@ -452,8 +462,8 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 		}
 		headers := ToHeaders(target.Headers)
 		respTarget = &AblyRuleTargetCloudflareWorker{
-			Url:          types.StringValue(target.URL),
-			SigningKeyId: optStringValue(target.SigningKeyID),
+			URL:          types.StringValue(target.URL),
+			SigningKeyID: optStringValue(target.SigningKeyID),
 			Headers:      headers,
 		}
 	case "pulsar":
@@ -462,13 +472,6 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			diags.AddError("Error unmarshalling rule target", fmt.Sprintf("Could not unmarshal pulsar target: %s", err.Error()))
 			return AblyRule{}, diags
 		}
-		// TlsTrustCerts is write-only in the API (accepted on create/update but
-		// never returned on read), so preserve whatever the user configured in
-		// state rather than overwriting it with nil from the API response.
-		var tlsTrustCerts []types.String
-		if p, ok := plan.Target.(*AblyRuleTargetPulsar); ok && p != nil {
-			tlsTrustCerts = p.TlsTrustCerts
-		}
 		authMode := ""
 		authToken := ""
 		if target.Authentication != nil {
@@ -476,13 +479,12 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			authToken = target.Authentication.Token
 		}
 		respTarget = &AblyRuleTargetPulsar{
-			RoutingKey:    types.StringValue(target.RoutingKey),
-			Topic:         types.StringValue(target.Topic),
-			ServiceURL:    types.StringValue(target.ServiceURL),
-			TlsTrustCerts: tlsTrustCerts,
-			Authentication: PulsarAuthentication{
-				Mode:  types.StringValue(authMode),
-				Token: types.StringValue(authToken),
+			RoutingKey: types.StringValue(target.RoutingKey),
+			Topic:      types.StringValue(target.Topic),
+			ServiceURL: types.StringValue(target.ServiceURL),
+			Authentication: AblyPulsarAuth{
+				AuthenticationMode: types.StringValue(authMode),
+				Token:              types.StringValue(authToken),
 			},
 			Enveloped: types.BoolValue(deref(target.Enveloped)),
 			Format:    types.StringValue(target.Format),
@@ -509,7 +511,7 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			ProjectID:    types.StringValue(target.ProjectID),
 			FunctionName: types.StringValue(target.FunctionName),
 			Headers:      headers,
-			SigningKeyId: optStringValue(target.SigningKeyID),
+			SigningKeyID: optStringValue(target.SigningKeyID),
 			Enveloped:    types.BoolValue(deref(target.Enveloped)),
 			Format:       types.StringValue(target.Format),
 		}
@@ -536,9 +538,9 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 		}
 		headers := ToHeaders(target.Headers)
 		respTarget = &AblyRuleTargetHTTP{
-			Url:          types.StringValue(target.URL),
+			URL:          types.StringValue(target.URL),
 			Headers:      headers,
-			SigningKeyId: optStringValue(target.SigningKeyID),
+			SigningKeyID: optStringValue(target.SigningKeyID),
 			Format:       types.StringValue(target.Format),
 			Enveloped:    types.BoolValue(deref(target.Enveloped)),
 		}
@@ -548,26 +550,20 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			diags.AddError("Error unmarshalling rule target", fmt.Sprintf("Could not unmarshal kafka target: %s", err.Error()))
 			return AblyRule{}, diags
 		}
-		saslMechanism := ""
-		saslUsername := ""
-		saslPassword := ""
+		var sasl *AblyKafkaSASL
 		if target.Auth != nil && target.Auth.SASL != nil {
-			saslMechanism = target.Auth.SASL.Mechanism
-			saslUsername = target.Auth.SASL.Username
-			saslPassword = target.Auth.SASL.Password
+			sasl = &AblyKafkaSASL{
+				Mechanism: types.StringValue(target.Auth.SASL.Mechanism),
+				Username:  types.StringValue(target.Auth.SASL.Username),
+				Password:  types.StringValue(target.Auth.SASL.Password),
+			}
 		}
 		respTarget = &AblyRuleTargetKafka{
 			RoutingKey: types.StringValue(target.RoutingKey),
 			Brokers:    toTypedStringSlice(target.Brokers),
-			KafkaAuthentication: KafkaAuthentication{
-				Sasl{
-					Mechanism: types.StringValue(saslMechanism),
-					Username:  types.StringValue(saslUsername),
-					Password:  types.StringValue(saslPassword),
-				},
-			},
-			Enveloped: types.BoolValue(deref(target.Enveloped)),
-			Format:    types.StringValue(target.Format),
+			Auth:       AblyKafkaAuth{SASL: sasl},
+			Enveloped:  types.BoolValue(deref(target.Enveloped)),
+			Format:     types.StringValue(target.Format),
 		}
 	case "amqp":
 		target, err := unmarshalTarget[control.AMQPRuleTarget](ablyRule.Target)
@@ -605,23 +601,23 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 			ttl = types.Int64Value(int64(*target.MessageTTL))
 		}
 		if p, ok := plan.Target.(*AblyRuleTargetAMQPExternal); ok && p != nil {
-			if !p.Url.IsNull() {
-				url = p.Url
+			if !p.URL.IsNull() {
+				url = p.URL
 			}
 			if target.Exchange == "" {
 				exchange = p.Exchange
 			}
-			if ttl.IsNull() && !p.MessageTtl.IsNull() {
-				ttl = p.MessageTtl
+			if ttl.IsNull() && !p.MessageTTL.IsNull() {
+				ttl = p.MessageTTL
 			}
 		}
 		respTarget = &AblyRuleTargetAMQPExternal{
-			Url:                url,
+			URL:                url,
 			RoutingKey:         types.StringValue(target.RoutingKey),
 			Exchange:           exchange,
 			MandatoryRoute:     types.BoolValue(deref(target.MandatoryRoute)),
 			PersistentMessages: types.BoolValue(deref(target.PersistentMessages)),
-			MessageTtl:         ttl,
+			MessageTTL:         ttl,
 			Headers:            headers,
 			Enveloped:          types.BoolValue(deref(target.Enveloped)),
 			Format:             types.StringValue(target.Format),
@@ -649,6 +645,12 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 		Type:          types.StringValue(sourceType),
 	}
 
+	// Write-only fields (the shims table's writeOnly hook) come back empty:
+	// keep whatever the user configured rather than the API's nothing.
+	if t, ok := respTarget.(writeOnlyTarget); ok && plan != nil {
+		t.preserveWriteOnly(plan.Target)
+	}
+
 	respRule := AblyRule{
 		ID:          types.StringValue(ablyRule.ID),
 		AppID:       types.StringValue(ablyRule.AppID),
@@ -657,151 +659,54 @@ func GetRuleResponse(ablyRule *control.RuleResponse, plan *AblyRule) (AblyRule,
 		Target:      respTarget,
 		RequestMode: types.StringValue(ablyRule.RequestMode),
 	}
-
-	return respRule, diags
-}
-
-// GetRuleSchema returns the schema for a rule resource.
-func GetRuleSchema(target map[string]schema.Attribute, markdownDescription string) schema.Schema {
-	return schema.Schema{
-		MarkdownDescription: markdownDescription,
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed:    true,
-				Description: "The rule ID.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-			"app_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The Ably application ID.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-			},
-			"status": schema.StringAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "The status of the rule. Rules can be enabled or disabled.",
-				Default:     stringdefault.StaticString("enabled"),
-				Validators: []validator.String{
-					stringvalidator.OneOf("enabled", "disabled"),
-				},
-			},
-			"request_mode": schema.StringAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "This is Single Request mode or Batch Request mode. Single Request mode sends each event separately to the endpoint specified by the rule",
-				PlanModifiers: []planmodifier.String{
-					DefaultStringAttribute(types.StringValue("single")),
-					stringplanmodifier.UseStateForUnknown(),
-				},
-				Validators: []validator.String{
-					stringvalidator.OneOf("single", "batch"),
-				},
-			},
-			"source": schema.SingleNestedAttribute{
-				Required:    true,
-				Description: "The source for the rule",
-				Attributes: map[string]schema.Attribute{
-					"channel_filter": schema.StringAttribute{
-						Optional: true,
-					},
-					"type": schema.StringAttribute{
-						Required: true,
-					},
-				},
-			},
-			"target": schema.SingleNestedAttribute{
-				Required:    true,
-				Description: "The target for the rule",
-				Attributes:  target,
-			},
-		},
+	if plan != nil {
+		respRule.DestroyBehavior = destroyBehaviorValue(plan.DestroyBehavior)
 	}
-}
 
-func GetAwsAuthSchema() schema.Attribute {
-	return schema.SingleNestedAttribute{
-		Required:    true,
-		Description: "AWS authentication configuration",
-		Attributes: map[string]schema.Attribute{
-			"mode": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "Authentication method. Use 'credentials' or 'assumeRole'",
-				Validators: []validator.String{
-					stringvalidator.OneOf("credentials", "assumeRole"),
-				},
-			},
-			"role_arn": schema.StringAttribute{
-				Optional:    true,
-				Description: "If you are using the 'ARN of an assumable role' authentication method, this is your Assume Role ARN",
-			},
-			"access_key_id": schema.StringAttribute{
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The AWS key ID for the AWS IAM user",
-			},
-			"secret_access_key": schema.StringAttribute{
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The AWS secret key for the AWS IAM user",
-			},
-		},
-	}
+	return respRule, diags
 }
 
-func GetHeaderSchema() schema.Attribute {
-	return schema.ListNestedAttribute{
-		Optional:    true,
-		Description: "If you have additional information to send, you'll need to include the relevant headers",
-		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"name": schema.StringAttribute{
-					Required:    true,
-					Description: "The name of the header",
-				},
-				"value": schema.StringAttribute{
-					Required:    true,
-					Description: "The value of the header",
-				},
-			},
-		},
-	}
+// adoptRuleSchema makes a rule schema generated by ruletypesgen the resource's
+// schema. It strips the generated CustomType from the nested blocks, so the
+// plain-struct models in rule_models_gen.go reflect cleanly, adds destroy_behavior,
+// which only the provider knows about, and sets the resource-level
+// description.
+func adoptRuleSchema(s schema.Schema, markdownDescription string) schema.Schema {
+	stripCustomTypes(s.Attributes)
+	s.Attributes["destroy_behavior"] = destroyBehaviorAttribute("rule")
+	s.MarkdownDescription = markdownDescription
+	return s
 }
 
-func GetEnvelopedSchema() schema.Attribute {
-	return schema.BoolAttribute{
-		Optional:    true,
-		Computed:    true,
-		Description: "Delivered messages are wrapped in an Ably envelope by default that contains metadata about the message and its payload. The form of the envelope depends on whether it is part of a Webhook/Function or a Queue/Firehose rule. For everything besides Webhooks, you can ensure you only get the raw payload by unchecking \"Enveloped\" when setting up the rule.",
-		PlanModifiers: []planmodifier.Bool{
-			DefaultBoolAttribute(types.BoolValue(false)),
-		},
+// stripCustomTypes clears CustomType on every nested block in attrs, recursing
+// into their attributes.
+func stripCustomTypes(attrs map[string]schema.Attribute) {
+	for name, a := range attrs {
+		switch a := a.(type) {
+		case schema.SingleNestedAttribute:
+			a.CustomType = nil
+			stripCustomTypes(a.Attributes)
+			attrs[name] = a
+		case schema.ListNestedAttribute:
+			a.CustomType = nil
+			a.NestedObject.CustomType = nil
+			stripCustomTypes(a.NestedObject.Attributes)
+			attrs[name] = a
+		}
 	}
 }
 
-func GetFormatSchema() schema.Attribute {
-	return schema.StringAttribute{
-		Optional:    true,
-		Computed:    true,
-		Description: "JSON provides a text-based encoding, whereas MsgPack provides a more efficient binary encoding",
-		PlanModifiers: []planmodifier.String{
-			DefaultStringAttribute(types.StringValue("json")),
-		},
-		Validators: []validator.String{
-			stringvalidator.OneOf("json", "msgpack", "json/ably-compact"),
-		},
-	}
+// ruleStatusPatch is a rule PATCH body that changes only the status, leaving
+// the source and target as they are.
+type ruleStatusPatch struct {
+	Status   string `json:"status"`
+	RuleType string `json:"ruleType"`
 }
 
 type Rule interface {
 	Provider() *AblyProvider
 	Name() string
+	RuleType() string
 }
 
 // CreateRule creates a new rule resource.
@@ -844,6 +749,7 @@ func CreateRule[T any](r Rule, ctx context.Context, req resource.CreateRequest,
 	// Sets state for the new Ably App.
 	diags = resp.State.Set(ctx, responseValues)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -891,6 +797,7 @@ func ReadRule[T any](r Rule, ctx context.Context, req resource.ReadRequest, resp
 	diags = resp.State.Set(ctx, &responseValues)
 
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -940,6 +847,7 @@ func UpdateRule[T any](r Rule, ctx context.Context, req resource.UpdateRequest,
 	diags = resp.State.Set(ctx, &responseValues)
 
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setAppScopedIdentity(ctx, resp.Identity, responseValues.AppID.ValueString(), responseValues.ID.ValueString())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -963,7 +871,16 @@ func DeleteRule[T any](r Rule, ctx context.Context, req resource.DeleteRequest,
 	appID := state.AppID.ValueString()
 	ruleID := state.ID.ValueString()
 
-	err := r.Provider().client.DeleteRule(ctx, appID, ruleID)
+	// With destroy_behavior = "disable" the rule is only disabled, and left
+	// for a cleanup job to delete. Nothing but the status is sent: an imported
+	// rule holds "" in state for the credentials the API never returns, and
+	// sending those back would clear the live ones.
+	var err error
+	if state.DestroyBehavior.ValueString() == destroyBehaviorDisable {
+		_, err = r.Provider().client.UpdateRule(ctx, appID, ruleID, ruleStatusPatch{Status: "disabled", RuleType: r.RuleType()})
+	} else {
+		err = r.Provider().client.DeleteRule(ctx, appID, ruleID)
+	}
 	if err != nil {
 		if is404(err) {
 			resp.Diagnostics.AddWarning(
@@ -982,29 +899,3 @@ func DeleteRule[T any](r Rule, ctx context.Context, req resource.DeleteRequest,
 	// Remove resource from state
 	resp.State.RemoveResource(ctx)
 }
-
-// ImportResource handles importing a resource.
-func ImportResource(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, fields ...string) {
-	// Save the import identifier in the id attribute
-	// identifier should be in the format appID,key_id
-	idParts := strings.Split(req.ID, ",")
-	anyEmpty := false
-
-	for _, v := range idParts {
-		if v == "" {
-			anyEmpty = true
-		}
-	}
-
-	if len(idParts) != len(fields) || anyEmpty {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: '%s'. Got: %q", strings.Join(fields, ","), req.ID),
-		)
-		return
-	}
-	// Recent PR in TF Plugin Framework for paths but Hashicorp examples not updated - https://github.com/hashicorp/terraform-plugin-framework/pull/390
-	for i, v := range fields {
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(v), idParts[i])...)
-	}
-}
@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/ably/terraform-provider-ably/control"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -50,10 +53,10 @@ func TestGetPlanRule_HTTPWebhook_BatchOmitsEnveloped(t *testing.T) {
 		{
 			name: "http",
 			target: &AblyRuleTargetHTTP{
-				Url:          types.StringValue("https://example.com"),
+				URL:          types.StringValue("https://example.com"),
 				Format:       types.StringValue("json"),
 				Enveloped:    types.BoolValue(true),
-				SigningKeyId: types.StringNull(),
+				SigningKeyID: types.StringNull(),
 			},
 		},
 		{
@@ -74,7 +77,7 @@ func TestGetPlanRule_HTTPWebhook_BatchOmitsEnveloped(t *testing.T) {
 				FunctionName: types.StringValue("func0"),
 				Format:       types.StringValue("json"),
 				Enveloped:    types.BoolValue(true),
-				SigningKeyId: types.StringNull(),
+				SigningKeyID: types.StringNull(),
 			},
 		},
 	}
@@ -138,10 +141,10 @@ func TestGetPlanRule_HTTPWebhook_SinglePreservesEnveloped(t *testing.T) {
 			Type:          types.StringValue("channel.message"),
 		},
 		Target: &AblyRuleTargetHTTP{
-			Url:          types.StringValue("https://example.com"),
+			URL:          types.StringValue("https://example.com"),
 			Format:       types.StringValue("json"),
 			Enveloped:    types.BoolValue(true),
-			SigningKeyId: types.StringNull(),
+			SigningKeyID: types.StringNull(),
 		},
 	}
 
@@ -206,8 +209,8 @@ func TestGetAwsAuth_ImportWithNilPlan(t *testing.T) {
 	if result.AuthenticationMode.ValueString() != "credentials" {
 		t.Fatalf("expected mode=credentials, got %q", result.AuthenticationMode.ValueString())
 	}
-	if result.AccessKeyId.ValueString() != "AKID" {
-		t.Fatalf("expected access_key_id=AKID, got %q", result.AccessKeyId.ValueString())
+	if result.AccessKeyID.ValueString() != "AKID" {
+		t.Fatalf("expected access_key_id=AKID, got %q", result.AccessKeyID.ValueString())
 	}
 }
 
@@ -228,10 +231,217 @@ func TestGetAwsAuth_AssumeRoleImport(t *testing.T) {
 	if result.AuthenticationMode.ValueString() != "assumeRole" {
 		t.Fatalf("expected mode=assumeRole, got %q", result.AuthenticationMode.ValueString())
 	}
-	if result.RoleArn.ValueString() != "arn:aws:iam::123:role/test" {
-		t.Fatalf("expected role_arn, got %q", result.RoleArn.ValueString())
+	if result.AssumeRoleArn.ValueString() != "arn:aws:iam::123:role/test" {
+		t.Fatalf("expected role_arn, got %q", result.AssumeRoleArn.ValueString())
 	}
-	if !result.AccessKeyId.IsNull() {
+	if !result.AccessKeyID.IsNull() {
 		t.Fatal("expected access_key_id to be null for assumeRole")
 	}
 }
+
+// TestGetRuleResponse_KafkaPreservesPassword verifies that the write-only SASL
+// password, which the API leaves out of its responses, is kept from the plan.
+func TestGetRuleResponse_KafkaPreservesPassword(t *testing.T) {
+	t.Parallel()
+
+	response := &control.RuleResponse{
+		ID:          "rule1",
+		AppID:       "app1",
+		RuleType:    "kafka",
+		RequestMode: "single",
+		Status:      "enabled",
+		Target: map[string]any{
+			"routingKey": "topic:key",
+			"brokers":    []any{"kafka.example.com:9092"},
+			"auth":       map[string]any{"sasl": map[string]any{"mechanism": "scram-sha-256", "username": "user"}},
+		},
+	}
+	plan := &AblyRule{
+		Target: &AblyRuleTargetKafka{
+			Auth: AblyKafkaAuth{SASL: &AblyKafkaSASL{
+				Mechanism: types.StringValue("scram-sha-256"),
+				Username:  types.StringValue("user"),
+				Password:  types.StringValue("secret"),
+			}},
+		},
+	}
+
+	rule, diags := GetRuleResponse(response, plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	sasl := rule.Target.(*AblyRuleTargetKafka).Auth.SASL
+	if sasl == nil {
+		t.Fatal("expected the sasl block from the response")
+	}
+	if sasl.Password.ValueString() != "secret" {
+		t.Fatalf("expected the password kept from the plan, got %q", sasl.Password.ValueString())
+	}
+	if sasl.Username.ValueString() != "user" {
+		t.Fatalf("expected username=user, got %q", sasl.Username.ValueString())
+	}
+}
+
+// TestKafkaRuleWithoutSASLRoundTrips verifies that a Kafka rule without SASL
+// decodes from the schema, is sent without a sasl block, and reads back
+// without one.
+func TestKafkaRuleWithoutSASLRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	ResourceRuleKafka{}.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, AblyRuleDecoder[*AblyRuleTargetKafka]{
+		ID:          types.StringUnknown(),
+		AppID:       types.StringValue("app1"),
+		Status:      types.StringValue("enabled"),
+		RequestMode: types.StringValue("single"),
+		Source:      &AblyRuleSource{ChannelFilter: types.StringNull(), Type: types.StringValue("channel.message")},
+		Target: &AblyRuleTargetKafka{
+			RoutingKey: types.StringValue("topic:key"),
+			Brokers:    []types.String{types.StringValue("kafka.example.com:9092")},
+			Enveloped:  types.BoolValue(true),
+			Format:     types.StringValue("json"),
+		},
+		DestroyBehavior: types.StringValue("delete"),
+	}); diags.HasError() {
+		t.Fatalf("setting the plan: %v", diags)
+	}
+
+	var p AblyRuleDecoder[*AblyRuleTargetKafka]
+	if diags := plan.Get(ctx, &p); diags.HasError() {
+		t.Fatalf("decoding the plan: %v", diags)
+	}
+	if p.Target.Auth.SASL != nil {
+		t.Fatalf("expected no sasl block, got %+v", p.Target.Auth.SASL)
+	}
+	rule := p.Rule()
+
+	post, diags := GetPlanRule(rule)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	body, err := json.Marshal(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent struct {
+		Target map[string]any `json:"target"`
+	}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if auth, _ := sent.Target["auth"].(map[string]any); auth["sasl"] != nil {
+		t.Fatalf("expected no sasl in the request, got %s", body)
+	}
+
+	response := &control.RuleResponse{
+		ID:          "rule1",
+		AppID:       "app1",
+		RuleType:    "kafka",
+		RequestMode: "single",
+		Status:      "enabled",
+		Source:      &control.RuleSource{Type: "channel.message"},
+		Target:      sent.Target,
+	}
+	got, diags := GetRuleResponse(response, &rule)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if sasl := got.Target.(*AblyRuleTargetKafka).Auth.SASL; sasl != nil {
+		t.Fatalf("expected no sasl block read back, got %+v", sasl)
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, got); diags.HasError() {
+		t.Fatalf("setting the state: %v", diags)
+	}
+}
+
+// TestGetRuleResponse_PulsarPreservesTlsTrustCerts verifies that the
+// write-only TLS trust certs are kept from the plan, and stay empty on import.
+func TestGetRuleResponse_PulsarPreservesTlsTrustCerts(t *testing.T) {
+	t.Parallel()
+
+	response := &control.RuleResponse{
+		ID:          "rule1",
+		AppID:       "app1",
+		RuleType:    "pulsar",
+		RequestMode: "single",
+		Status:      "enabled",
+		Target: map[string]any{
+			"routingKey": "key",
+			"topic":      "persistent://tenant/ns/topic",
+			"serviceUrl": "pulsar://pulsar.example.com:6650",
+		},
+	}
+	certs := []types.String{types.StringValue("-----BEGIN CERTIFICATE-----")}
+	plan := &AblyRule{Target: &AblyRuleTargetPulsar{TLSTrustCerts: certs}}
+
+	rule, diags := GetRuleResponse(response, plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := rule.Target.(*AblyRuleTargetPulsar).TLSTrustCerts; len(got) != 1 || !got[0].Equal(certs[0]) {
+		t.Fatalf("expected the certs kept from the plan, got %v", got)
+	}
+
+	rule, diags = GetRuleResponse(response, &AblyRule{})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := rule.Target.(*AblyRuleTargetPulsar).TLSTrustCerts; got != nil {
+		t.Fatalf("expected no certs without a plan, got %v", got)
+	}
+}
+
+// TestAdoptedRuleSchemasMatchModels checks each rule resource's model
+// reflects onto the schema it adopts from the generated code, so a
+// regeneration that renames, adds or drops an attribute fails here rather than
+// in the first apply.
+func TestAdoptedRuleSchemasMatchModels(t *testing.T) {
+	t.Parallel()
+
+	source := &AblyRuleSource{}
+	tests := []struct {
+		resource resource.Resource
+		model    any
+	}{
+		{ResourceRuleKinesis{}, AblyRuleDecoder[*AblyRuleTargetKinesis]{Source: source, Target: &AblyRuleTargetKinesis{}}},
+		{ResourceRuleSqs{}, AblyRuleDecoder[*AblyRuleTargetSqs]{Source: source, Target: &AblyRuleTargetSqs{}}},
+		{ResourceRuleLambda{}, AblyRuleDecoder[*AblyRuleTargetLambda]{Source: source, Target: &AblyRuleTargetLambda{}}},
+		{ResourceRulePulsar{}, AblyRuleDecoder[*AblyRuleTargetPulsar]{Source: source, Target: &AblyRuleTargetPulsar{}}},
+		{ResourceRuleZapier{}, AblyRuleDecoder[*AblyRuleTargetZapier]{Source: source, Target: &AblyRuleTargetZapier{}}},
+		{ResourceRuleGoogleFunction{}, AblyRuleDecoder[*AblyRuleTargetGoogleFunction]{Source: source, Target: &AblyRuleTargetGoogleFunction{}}},
+		{ResourceRuleIFTTT{}, AblyRuleDecoder[*AblyRuleTargetIFTTT]{Source: source, Target: &AblyRuleTargetIFTTT{}}},
+		{ResourceRuleCloudflareWorker{}, AblyRuleDecoder[*AblyRuleTargetCloudflareWorker]{Source: source, Target: &AblyRuleTargetCloudflareWorker{}}},
+		{ResourceRuleAzureFunction{}, AblyRuleDecoder[*AblyRuleTargetAzureFunction]{Source: source, Target: &AblyRuleTargetAzureFunction{}}},
+		{ResourceRuleHTTP{}, AblyRuleDecoder[*AblyRuleTargetHTTP]{Source: source, Target: &AblyRuleTargetHTTP{}}},
+		{ResourceRuleKafka{}, AblyRuleDecoder[*AblyRuleTargetKafka]{Source: source, Target: &AblyRuleTargetKafka{}}},
+		{ResourceRuleAMQP{}, AblyRuleDecoder[*AblyRuleTargetAMQP]{Source: source, Target: &AblyRuleTargetAMQP{}}},
+		{ResourceRuleAMQPExternal{}, AblyRuleDecoder[*AblyRuleTargetAMQPExternal]{Source: source, Target: &AblyRuleTargetAMQPExternal{}}},
+		{ResourceRuleBodyguard{}, AblyRuleBodyguard{BeforePublishConfig: &AblyRuleBodyguardBeforePublishConfig{}, Target: &AblyRuleBodyguardTarget{}}},
+		{ResourceIngressRuleMongo{}, AblyIngressRuleDecoder[*AblyIngressRuleTargetMongo]{Target: &AblyIngressRuleTargetMongo{}}},
+		{ResourceIngressRulePostgresOutbox{}, AblyIngressRuleDecoder[*AblyIngressRuleTargetPostgresOutbox]{Target: &AblyIngressRuleTargetPostgresOutbox{}}},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		var meta resource.MetadataResponse
+		tt.resource.Metadata(ctx, resource.MetadataRequest{}, &meta)
+		t.Run(meta.TypeName, func(t *testing.T) {
+			t.Parallel()
+
+			var resp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &resp)
+			if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+
+			state := tfsdk.State{Schema: resp.Schema}
+			if diags := state.Set(ctx, tt.model); diags.HasError() {
+				t.Errorf("the model does not match the schema: %v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package provider implements the Ably provider for Terraform
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// singleRequestModeValidator rejects request_mode = "batch" on rule types
+// that only deliver events one at a time.
+type singleRequestModeValidator struct {
+	ruleType string
+}
+
+func (v singleRequestModeValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s rules do not support request_mode.", v.ruleType)
+}
+
+func (v singleRequestModeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v singleRequestModeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var requestMode types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("request_mode"), &requestMode)...)
+	if !requestMode.IsNull() && !requestMode.IsUnknown() && requestMode.ValueString() != "single" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_mode"),
+			"Unsupported request_mode",
+			fmt.Sprintf("%s rules do not support request_mode %q.", v.ruleType, requestMode.ValueString()),
+		)
+	}
+}
+
+// SingleRequestModeOnly returns a resource validator for rule types that do
+// not support batch mode.
+func SingleRequestModeOnly(ruleType string) resource.ConfigValidator {
+	return singleRequestModeValidator{ruleType: ruleType}
+}
@@ -0,0 +1,232 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Ably evaluates a rule's channel filter as a JavaScript regular expression,
+// searched for anywhere in the channel name. The provider checks filters with
+// Go's RE2 parser instead, so the functions here only report what both
+// dialects agree on, plus RE2 syntax that JavaScript rejects or reads
+// differently. Lookarounds, backreferences and other JavaScript syntax RE2
+// lacks are left for the Control API to judge.
+
+// structuralRegexpErrors are the RE2 parse errors that are also errors in a
+// JavaScript regular expression.
+var structuralRegexpErrors = []syntax.ErrorCode{
+	syntax.ErrMissingParen,
+	syntax.ErrUnexpectedParen,
+	syntax.ErrMissingBracket,
+	syntax.ErrMissingRepeatArgument,
+	syntax.ErrInvalidRepeatOp,
+	syntax.ErrInvalidCharRange,
+	syntax.ErrTrailingBackslash,
+}
+
+// channelFilterError returns why a channel filter is not a valid JavaScript
+// regular expression, if it is not.
+func channelFilterError(filter string) error {
+	_, err := regexp.Compile(filter)
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		for _, code := range structuralRegexpErrors {
+			if syntaxErr.Code == code {
+				return syntaxErr
+			}
+		}
+	}
+
+	var groupErr error
+	scanChannelFilter(filter, func(token string, _ bool) {
+		if groupErr != nil {
+			return
+		}
+		switch group := strings.TrimPrefix(token, "(?"); {
+		case group == token:
+		case group == "P":
+			groupErr = errors.New("JavaScript names groups with (?<name>...), not (?P<name>...)")
+		case isInlineFlags(group) && strings.HasSuffix(group, ")"):
+			groupErr = fmt.Errorf("inline flags %s: JavaScript regular expressions do not support them", token)
+		}
+	})
+	return groupErr
+}
+
+// channelFilterWarnings returns the ways a valid channel filter is unlikely
+// to do what its author meant: RE2 syntax JavaScript reads differently, and
+// filters no legal channel name can match.
+func channelFilterWarnings(filter string) []string {
+	var warnings []string
+	seen := map[string]bool{}
+	warn := func(warning string) {
+		if !seen[warning] {
+			seen[warning] = true
+			warnings = append(warnings, warning)
+		}
+	}
+
+	scanChannelFilter(filter, func(token string, inClass bool) {
+		switch {
+		case token == `\A` || token == `\z` || token == `\Z`:
+			warn(fmt.Sprintf("%s is not an anchor in JavaScript, which reads it as the letter %q. Use ^ or $ instead.", token, token[1:]))
+		case token == `\Q` || token == `\E`:
+			warn(fmt.Sprintf("JavaScript does not support %s quoting and reads it as the letter %q. Escape the characters individually instead.", token, token[1:]))
+		case token == `\p` || token == `\P`:
+			warn(fmt.Sprintf("Ably does not evaluate channel filters in Unicode mode, so JavaScript reads %s as the letter %q rather than a Unicode class.", token, token[1:]))
+		case inClass && token == "[:":
+			warn("JavaScript does not support POSIX classes such as [[:alpha:]] and reads them as ordinary brackets. List the characters instead, e.g. [a-zA-Z].")
+		case strings.HasPrefix(token, "(?") && isInlineFlags(strings.TrimPrefix(token, "(?")):
+			warn(fmt.Sprintf("Pattern modifiers such as %s...) need a JavaScript engine with ES2025 support.", token))
+		}
+	})
+
+	if !matchesLegalChannelName(filter) {
+		warn("The filter cannot match any legal channel name, so the rule will never fire. Channel names are not empty and do not start with \"[\" or \":\".")
+	}
+	return warnings
+}
+
+// scanChannelFilter calls visit with each escape sequence, group opener such
+// as "(?:" or "(?i)", and "[:" inside a character class, with whether it is
+// inside a character class.
+func scanChannelFilter(filter string, visit func(token string, inClass bool)) {
+	inClass := false
+	for i := 0; i < len(filter); i++ {
+		switch c := filter[i]; {
+		case c == '\\' && i+1 < len(filter):
+			visit(filter[i:i+2], inClass)
+			i++
+		case c == '[' && !inClass:
+			inClass = true
+			// A leading ] is a literal in RE2, so it cannot close the class.
+			if strings.HasPrefix(filter[i+1:], "]") {
+				i++
+			} else if strings.HasPrefix(filter[i+1:], "^]") {
+				i += 2
+			}
+		case c == '[' && strings.HasPrefix(filter[i+1:], ":"):
+			visit("[:", inClass)
+		case c == ']':
+			inClass = false
+		case c == '(' && !inClass && strings.HasPrefix(filter[i+1:], "?"):
+			end := i + 2
+			for end < len(filter) && strings.IndexByte("-imsU", filter[end]) >= 0 {
+				end++
+			}
+			if end < len(filter) {
+				end++
+			}
+			visit(filter[i:end], inClass)
+		}
+	}
+}
+
+// isInlineFlags reports whether group, the part of a group opener after
+// "(?", sets flags, as in (?i) or (?i:...).
+func isInlineFlags(group string) bool {
+	flags := strings.TrimRight(group, ":)")
+	return flags != "" && strings.Trim(flags, "-imsU") == "" && len(flags) < len(group)
+}
+
+// matchesLegalChannelName reports whether filter can match some legal channel
+// name: one that is not empty and does not start with "[", which introduces a
+// channel qualifier, or ":". Filters RE2 cannot compile are given the benefit
+// of the doubt.
+//
+// It walks the compiled program, tracking whether the name has started and
+// whether it must end. Since the filter is searched for anywhere in the name,
+// a match may follow a legal prefix and be followed by more characters.
+func matchesLegalChannelName(filter string) bool {
+	re, err := syntax.Parse(filter, syntax.Perl)
+	if err != nil {
+		return true
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return true
+	}
+
+	type state struct {
+		pc      uint32
+		started bool
+		ended   bool
+	}
+	seen := map[state]bool{}
+	stack := []state{{pc: uint32(prog.Start)}, {pc: uint32(prog.Start), started: true}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+
+		inst := &prog.Inst[s.pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, state{inst.Out, s.started, s.ended}, state{inst.Arg, s.started, s.ended})
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, state{inst.Out, s.started, s.ended})
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(inst.Arg)
+			if op&(syntax.EmptyBeginText|syntax.EmptyBeginLine) != 0 && s.started {
+				continue
+			}
+			ended := s.ended || op&(syntax.EmptyEndText|syntax.EmptyEndLine) != 0
+			stack = append(stack, state{inst.Out, s.started, ended})
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			// An empty character class compiles to a rune instruction with no runes.
+			empty := inst.Op == syntax.InstRune && len(inst.Rune) == 0
+			if s.ended || empty || (!s.started && !canStartChannelName(inst)) {
+				continue
+			}
+			stack = append(stack, state{inst.Out, true, false})
+		case syntax.InstMatch:
+			// An empty match still matches a name that carries on after it.
+			if s.started || !s.ended {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canStartChannelName reports whether a rune instruction matches a rune a
+// channel name may start with.
+func canStartChannelName(inst *syntax.Inst) bool {
+	illegal := func(r rune) bool { return r == '[' || r == ':' }
+	switch inst.Op {
+	case syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+		return true
+	}
+	if len(inst.Rune) == 1 {
+		return !illegal(inst.Rune[0])
+	}
+	for i := 0; i+1 < len(inst.Rune); i += 2 {
+		if inst.Rune[i] != inst.Rune[i+1] || !illegal(inst.Rune[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelFilterMatches reports whether a channel filter matches a channel
+// name, as Ably evaluates it: an empty filter matches every channel, and
+// otherwise the filter is searched for anywhere in the name.
+func ChannelFilterMatches(filter, channel string) (bool, error) {
+	if filter == "" {
+		return true, nil
+	}
+	if err := channelFilterError(filter); err != nil {
+		return false, fmt.Errorf("%q is not a valid regular expression: %w", filter, err)
+	}
+	re, err := regexp.Compile(filter)
+	if err != nil {
+		return false, fmt.Errorf("%q uses JavaScript syntax the provider cannot evaluate, such as lookarounds or backreferences: %w", filter, err)
+	}
+	return re.MatchString(channel), nil
+}
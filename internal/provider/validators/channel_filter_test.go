@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChannelFilterError(t *testing.T) {
+	t.Parallel()
+
+	for _, filter := range []string{
+		"",
+		"^my-channel.*",
+		"^(chat|support):",
+		"^(?:chat|support):",
+		"^(?<room>chat):",
+		"[]a]",
+		// JavaScript syntax RE2 lacks is left to the Control API.
+		"^(?!private:).*",
+		"(?<=chat:)x",
+		`^(a)\1$`,
+		`^\q`,
+		// Pattern modifiers are only warned about.
+		"(?i:chat)",
+	} {
+		if err := channelFilterError(filter); err != nil {
+			t.Errorf("channelFilterError(%q) = %v, want nil", filter, err)
+		}
+	}
+	for _, filter := range []string{
+		"^(chat", "chat)", "[a-", "*chat", "a**", "[z-a]", `chat\`,
+		"(?P<room>chat)", "(?i)chat", "^(?s-i)chat",
+	} {
+		if err := channelFilterError(filter); err == nil {
+			t.Errorf("channelFilterError(%q) = nil, want an error", filter)
+		}
+	}
+}
+
+func TestChannelFilterWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter string
+		want   []string // substrings of the warnings, in order
+	}{
+		{filter: "^chat:"},
+		{filter: ""},
+		{filter: `\[meta\]`},
+		{filter: "[:]"},
+		{filter: "a|^:"},
+		{filter: "(^|,)chat$"},
+		{filter: `\bchat\b`},
+		{filter: "^(?!x)$"},
+		{filter: `\Achat\z`, want: []string{`\A is not an anchor`, `\z is not an anchor`}},
+		{filter: `\Qa.b\E`, want: []string{`\Q quoting`, `\E quoting`}},
+		{filter: `^\p{Greek}`, want: []string{`reads \p as the letter`}},
+		{filter: "^[[:alpha:]]", want: []string{"POSIX classes"}},
+		{filter: "(?i:chat)", want: []string{"Pattern modifiers such as (?i:...)"}},
+		{filter: "^$", want: []string{"cannot match any legal channel name"}},
+		{filter: "^:chat", want: []string{"cannot match any legal channel name"}},
+		{filter: `^\[meta\]`, want: []string{"cannot match any legal channel name"}},
+		{filter: "^[:[]", want: []string{"cannot match any legal channel name"}},
+		{filter: "chat$:", want: []string{"cannot match any legal channel name"}},
+		{filter: "chat^x", want: []string{"cannot match any legal channel name"}},
+		{filter: `[^\x00-\x{10FFFF}]`, want: []string{"cannot match any legal channel name"}},
+	}
+	for _, tt := range tests {
+		got := channelFilterWarnings(tt.filter)
+		if len(got) != len(tt.want) {
+			t.Errorf("channelFilterWarnings(%q) = %q, want %d warnings", tt.filter, got, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(got[i], want) {
+				t.Errorf("channelFilterWarnings(%q)[%d] = %q, want it to contain %q", tt.filter, i, got[i], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package validators holds validators shared by generated schemas and
+// hand-written resources. Like planmodifiers, it exists so that generated code
+// can reference them via the overrides table in codegen/ruletypesgen: the
+// generated schema packages can't import the provider package itself.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// channelFilterValidator checks that a channel filter is a valid JavaScript
+// regular expression, and warns about filters that are unlikely to do what
+// was meant. See [channelFilterError] and [channelFilterWarnings].
+type channelFilterValidator struct{}
+
+func (v channelFilterValidator) Description(_ context.Context) string {
+	return "Value must be a valid JavaScript regular expression."
+}
+
+func (v channelFilterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v channelFilterValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := channelFilterError(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid channel filter",
+			fmt.Sprintf("%q is not a valid regular expression: %s", req.ConfigValue.ValueString(), err),
+		)
+		return
+	}
+	for _, warning := range channelFilterWarnings(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Suspicious channel filter", warning)
+	}
+}
+
+// ChannelFilter returns a validator that checks a channel filter is a valid
+// regular expression. See [channelFilterValidator].
+func ChannelFilter() validator.String {
+	return channelFilterValidator{}
+}
+
+// requiredWhenValidator requires sibling attributes when a string attribute
+// has a given value, e.g. role_arn when an AWS authentication mode is
+// assumeRole.
+type requiredWhenValidator struct {
+	value       string
+	expressions path.Expressions
+}
+
+func (v requiredWhenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("When the value is %q, %s must be configured.", v.value, v.expressions)
+}
+
+func (v requiredWhenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requiredWhenValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() != v.value {
+		return
+	}
+
+	for _, expression := range req.PathExpression.MergeExpressions(v.expressions...) {
+		matches, diags := req.Config.PathMatches(ctx, expression)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+		for _, match := range matches {
+			var value attr.Value
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, match, &value)...)
+			if value != nil && value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					match,
+					"Missing required attribute",
+					fmt.Sprintf("%s is required when %s is %q.", match, req.Path, v.value),
+				)
+			}
+		}
+	}
+}
+
+// RequiredWhen returns a validator that requires the attributes at
+// expressions to be configured when the string attribute equals value.
+func RequiredWhen(value string, expressions ...path.Expression) validator.String {
+	return requiredWhenValidator{value: value, expressions: expressions}
+}
+
+// unenvelopedInBatchValidator rejects enveloped = true on a webhook target in
+// batch mode, which the Control API does not accept. Leaving enveloped unset
+// is fine: the provider sends it as false.
+type unenvelopedInBatchValidator struct{}
+
+func (v unenvelopedInBatchValidator) Description(_ context.Context) string {
+	return `Value must not be true when request_mode is "batch".`
+}
+
+func (v unenvelopedInBatchValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v unenvelopedInBatchValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !req.ConfigValue.ValueBool() {
+		return
+	}
+
+	var requestMode types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("request_mode"), &requestMode)...)
+	if requestMode.ValueString() == "batch" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid enveloped for batch mode",
+			`enveloped cannot be true when request_mode is "batch"; batched webhook requests are never enveloped.`,
+		)
+	}
+}
+
+// UnenvelopedInBatch returns a validator that rejects enveloped = true in
+// batch mode. See [unenvelopedInBatchValidator].
+func UnenvelopedInBatch() validator.Bool {
+	return unenvelopedInBatchValidator{}
+}
+
+// headerNamePattern matches an HTTP header field name (an RFC 9110 token).
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// headerValuePattern matches a header value that stays on one line.
+var headerValuePattern = regexp.MustCompile(`^[^\r\n]*$`)
+
+// HeaderName returns a validator that checks a string is a valid HTTP header
+// name.
+func HeaderName() validator.String {
+	return stringvalidator.RegexMatches(headerNamePattern, "must be a valid HTTP header name")
+}
+
+// HeaderValue returns a validator that checks a header value has no line
+// breaks.
+func HeaderValue() validator.String {
+	return stringvalidator.RegexMatches(headerValuePattern, "must not contain line breaks")
+}
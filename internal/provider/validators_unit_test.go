@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestRuleConfigValidation runs rule configurations through the provider's
+// ValidateResourceConfig, as terraform validate does.
+func TestRuleConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	source := map[string]any{"channel_filter": "^chat:", "type": "channel.message"}
+	kinesis := func(requestMode string, authentication map[string]any) map[string]any {
+		return map[string]any{
+			"app_id":       "app1",
+			"request_mode": requestMode,
+			"source":       source,
+			"target": map[string]any{
+				"region":         "us-west-1",
+				"stream_name":    "events",
+				"partition_key":  "key",
+				"authentication": authentication,
+			},
+		}
+	}
+	credentials := map[string]any{"mode": "credentials", "access_key_id": "id", "secret_access_key": "secret"}
+	http := func(requestMode string, enveloped any, filter string, headerName string) map[string]any {
+		return map[string]any{
+			"app_id":       "app1",
+			"request_mode": requestMode,
+			"source":       map[string]any{"channel_filter": filter, "type": "channel.message"},
+			"target": map[string]any{
+				"url":       "https://example.com",
+				"enveloped": enveloped,
+				"headers":   []any{map[string]any{"name": headerName, "value": "v"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		resourceType string
+		config       map[string]any
+		errSubstr    string // "" means valid
+	}{
+		{name: "kinesis valid", resourceType: "ably_rule_kinesis", config: kinesis("single", credentials)},
+		{name: "kinesis assumeRole without role_arn", resourceType: "ably_rule_kinesis",
+			config: kinesis("single", map[string]any{"mode": "assumeRole"}), errSubstr: "role_arn is required"},
+		{name: "kinesis credentials without secret", resourceType: "ably_rule_kinesis",
+			config: kinesis("single", map[string]any{"mode": "credentials", "access_key_id": "id"}), errSubstr: "secret_access_key is required"},
+		{name: "kinesis batch", resourceType: "ably_rule_kinesis", config: kinesis("batch", credentials), errSubstr: "do not support request_mode"},
+		{name: "http enveloped single", resourceType: "ably_rule_http", config: http("single", true, "^chat:", "X-Token")},
+		{name: "http unenveloped batch", resourceType: "ably_rule_http", config: http("batch", false, "^chat:", "X-Token")},
+		{name: "http unset enveloped batch", resourceType: "ably_rule_http", config: http("batch", nil, "^chat:", "X-Token")},
+		{name: "http enveloped batch", resourceType: "ably_rule_http", config: http("batch", true, "^chat:", "X-Token"), errSubstr: "enveloped cannot be true"},
+		{name: "http bad channel filter", resourceType: "ably_rule_http", config: http("single", nil, "^(chat", "X-Token"), errSubstr: "not a valid regular expression"},
+		{name: "http bad header name", resourceType: "ably_rule_http", config: http("single", nil, "^chat:", "X Token"), errSubstr: "valid HTTP header name"},
+		{name: "kafka sasl without mechanism", resourceType: "ably_rule_kafka", config: map[string]any{
+			"app_id": "app1",
+			"source": source,
+			"target": map[string]any{
+				"routing_key": "topic:key",
+				"brokers":     []any{"broker:9092"},
+				"auth":        map[string]any{"sasl": map[string]any{"username": "u", "password": "p"}},
+			},
+		}, errSubstr: "mechanism"},
+	}
+
+	server := providerserver.NewProtocol6(New("test")())()
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schemaType := schemas.ResourceSchemas[tt.resourceType].ValueType()
+			config, err := tfprotov6.NewDynamicValue(schemaType, configValue(t, schemaType, tt.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: tt.resourceType,
+				Config:   &config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var errs []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Summary+": "+d.Detail)
+				}
+			}
+			if tt.errSubstr == "" {
+				if len(errs) > 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(errs, "\n"), tt.errSubstr) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.errSubstr)
+			}
+		})
+	}
+}
+
+// configValue builds a configuration value of typ from Go values, leaving
+// anything not given null.
+func configValue(t *testing.T, typ tftypes.Type, value any) tftypes.Value {
+	t.Helper()
+
+	if value == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		given := value.(map[string]any)
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range typ.AttributeTypes {
+			attributes[name] = configValue(t, attributeType, given[name])
+		}
+		for name := range given {
+			if _, ok := typ.AttributeTypes[name]; !ok {
+				t.Fatalf("no attribute %q in %s", name, typ)
+			}
+		}
+		return tftypes.NewValue(typ, attributes)
+	case tftypes.List:
+		var elements []tftypes.Value
+		for _, element := range value.([]any) {
+			elements = append(elements, configValue(t, typ.ElementType, element))
+		}
+		return tftypes.NewValue(typ, elements)
+	default:
+		return tftypes.NewValue(typ, value)
+	}
+}